@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Release comment modes.
+const (
+	commentModeIssue    = "per_issue"
+	commentModeAssignee = "per_assignee"
+)
+
+// isValidCommentMode reports whether mode is a supported comment_mode.
+func isValidCommentMode(mode string) bool {
+	return mode == commentModeIssue || mode == commentModeAssignee
+}
+
+// assigneeGroup holds the shipped issues of one assignee.
+type assigneeGroup struct {
+	Assignee *User
+	Issues   []*Issue
+}
+
+// groupByAssignee groups shipped issues by assignee, ordered by name with
+// unassigned issues last.
+func groupByAssignee(issues []*Issue) []*assigneeGroup {
+	byKey := make(map[string]*assigneeGroup)
+	var groups []*assigneeGroup
+	for _, issue := range issues {
+		key := ""
+		if issue.Assignee != nil {
+			key = issue.Assignee.ID
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &assigneeGroup{Assignee: issue.Assignee}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Issues = append(g.Issues, issue)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Assignee, groups[j].Assignee
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.Name < b.Name
+	})
+	return groups
+}
+
+// mention tags the assignee. Linear turns profile URLs into mentions.
+func (g *assigneeGroup) mention() string {
+	switch {
+	case g.Assignee == nil:
+		return unassignedName
+	case g.Assignee.URL != "":
+		return g.Assignee.URL
+	default:
+		return g.Assignee.Name
+	}
+}
+
+// render builds the summary comment of the group under the release comment.
+func (g *assigneeGroup) render(heading string) string {
+	var b strings.Builder
+	if heading != "" {
+		b.WriteString(heading + "\n\n")
+	}
+	fmt.Fprintf(&b, "%s shipped:\n", g.mention())
+	for _, issue := range g.Issues {
+		fmt.Fprintf(&b, "- [%s](%s) %s\n", issue.Identifier, issue.URL, strings.TrimSpace(issue.Title))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// postAssigneeComments posts one summary comment per assignee instead of a
+// comment on every shipped issue. Comments go on the release issue when
+// there is one, otherwise on the first issue of each assignee. It returns the
+// issues summarized per assignee and the errors encountered.
+func postAssigneeComments(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, releaseIssue *Issue, shipped []*Issue) (map[string][]string, []string) {
+	heading, err := renderTemplate(commentTemplate(cfg, releaseCtx), releaseCtx, cfg.TemplatePartials)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("Failed to render comment template: %v", err)}
+	}
+
+	posted := make(map[string][]string)
+	var errs []string
+	for _, g := range groupByAssignee(shipped) {
+		target := g.Issues[0]
+		if releaseIssue != nil {
+			target = releaseIssue
+		}
+
+		if err := client.AddComment(ctx, target.ID, g.render(heading)); err != nil {
+			if !errors.Is(err, errMutationSkipped) {
+				errs = append(errs, fmt.Sprintf("Failed to comment for %s on %s: %v", g.mention(), target.Identifier, err))
+			}
+			continue
+		}
+
+		name := unassignedName
+		if g.Assignee != nil {
+			name = g.Assignee.Name
+		}
+		for _, issue := range g.Issues {
+			posted[name] = append(posted[name], issue.Identifier)
+		}
+	}
+	return posted, errs
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestGroupByAssignee(t *testing.T) {
+	bob := &User{ID: "u-2", Name: "Bob"}
+	alice := &User{ID: "u-1", Name: "Alice"}
+	groups := groupByAssignee([]*Issue{
+		{Identifier: "ENG-1", Assignee: bob},
+		{Identifier: "ENG-2"},
+		{Identifier: "ENG-3", Assignee: alice},
+		{Identifier: "ENG-4", Assignee: bob},
+	})
+
+	var got [][]string
+	for _, g := range groups {
+		var ids []string
+		for _, issue := range g.Issues {
+			ids = append(ids, issue.Identifier)
+		}
+		got = append(got, ids)
+	}
+	want := [][]string{{"ENG-3"}, {"ENG-1", "ENG-4"}, {"ENG-2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByAssignee() = %v, want %v", got, want)
+	}
+}
+
+func TestPostAssigneeComments(t *testing.T) {
+	alice := &User{ID: "u-1", Name: "Alice", URL: "https://linear.app/acme/profiles/alice"}
+	shipped := []*Issue{
+		{ID: "uuid-1", Identifier: "ENG-1", Title: "Add export", URL: "https://linear.app/acme/issue/ENG-1", Assignee: alice},
+		{ID: "uuid-2", Identifier: "ENG-2", Title: "Fix crash", URL: "https://linear.app/acme/issue/ENG-2", Assignee: alice},
+		{ID: "uuid-3", Identifier: "ENG-3", Title: "Docs", URL: "https://linear.app/acme/issue/ENG-3"},
+	}
+
+	tests := []struct {
+		name         string
+		releaseIssue *Issue
+		wantTargets  []string
+	}{
+		{"on release issue", &Issue{ID: "uuid-rel", Identifier: "ENG-100"}, []string{"uuid-rel", "uuid-rel"}},
+		{"on first issue", nil, []string{"uuid-1", "uuid-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinear(t, map[string]func(map[string]any) any{
+				"AddComment": successHandler("commentCreate"),
+			})
+
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(map[string]any{"comment_mode": "per_assignee"})
+			posted, errs := postAssigneeComments(context.Background(), fake.client(), cfg,
+				plugin.ReleaseContext{Version: "1.2.0"}, tt.releaseIssue, shipped)
+			if len(errs) > 0 {
+				t.Fatalf("postAssigneeComments() errors = %v", errs)
+			}
+
+			var targets []string
+			for _, vars := range fake.calls["AddComment"] {
+				targets = append(targets, vars["input"].(map[string]any)["issueId"].(string))
+			}
+			if !reflect.DeepEqual(targets, tt.wantTargets) {
+				t.Errorf("comment targets = %v, want %v", targets, tt.wantTargets)
+			}
+
+			body := fake.calls["AddComment"][0]["input"].(map[string]any)["body"].(string)
+			if !strings.HasPrefix(body, "Released in 1.2.0\n\nhttps://linear.app/acme/profiles/alice shipped:") ||
+				!strings.Contains(body, "- [ENG-2](https://linear.app/acme/issue/ENG-2) Fix crash") {
+				t.Errorf("unexpected comment body:\n%s", body)
+			}
+			if !reflect.DeepEqual(posted, map[string][]string{"Alice": {"ENG-1", "ENG-2"}, "Unassigned": {"ENG-3"}}) {
+				t.Errorf("posted = %v", posted)
+			}
+		})
+	}
+}
+
+func TestProcessLinkedIssuesSkipsPerIssueCommentsPerAssignee(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false, "comment_mode": "per_assignee"})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1"})
+
+	if fake.callCount("AddComment") != 0 || len(res.Shipped) != 1 {
+		t.Errorf("expected no per-issue comment, got %d", fake.callCount("AddComment"))
+	}
+}
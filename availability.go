@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// Reasons a linked issue cannot be processed, reported separately from
+// generic warnings.
+const (
+	issueArchived     = "archived"
+	issueDeleted      = "deleted"
+	issueAccessDenied = "access_denied"
+)
+
+// classifyIssueError maps an issue lookup error to an unavailability reason,
+// or "" when the error is not about the issue itself (e.g. network failures).
+func classifyIssueError(err error) string {
+	if errors.Is(err, ErrIssueNotFound) {
+		return issueDeleted
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+
+	for _, e := range apiErr.Errors {
+		code := strings.ToUpper(e.Extensions.Code)
+		kind := strings.ToLower(e.Extensions.Type)
+		msg := strings.ToLower(e.Message + " " + e.Extensions.UserPresentableMessage)
+
+		switch {
+		case code == "FORBIDDEN" || kind == "forbidden" || strings.Contains(msg, "forbidden") ||
+			strings.Contains(msg, "not authorized") || strings.Contains(msg, "access denied"):
+			return issueAccessDenied
+		case code == "NOT_FOUND" || strings.Contains(msg, "not found") || strings.Contains(msg, "does not exist"):
+			return issueDeleted
+		}
+	}
+	return ""
+}
+
+// issueAvailability returns why a fetched issue cannot be processed, or ""
+// when it is active.
+func issueAvailability(issue *Issue) string {
+	switch {
+	case issue.Trashed:
+		return issueDeleted
+	case issue.ArchivedAt != "":
+		return issueArchived
+	}
+	return ""
+}
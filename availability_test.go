@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestProcessLinkedIssuesUnavailable(t *testing.T) {
+	issues := map[string]map[string]any{
+		"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "archivedAt": "2026-01-01T00:00:00Z"},
+		"ENG-3": {"id": "uuid-3", "identifier": "ENG-3", "trashed": true},
+	}
+
+	newFake := func(t *testing.T) *fakeLinear {
+		return newFakeLinear(t, map[string]func(map[string]any) any{
+			"GetIssue": func(vars map[string]any) any {
+				if vars["id"] == "ENG-5" {
+					return fakeErrors{{"message": "Forbidden", "extensions": map[string]any{"type": "forbidden"}}}
+				}
+				return issueHandler(issues)(vars)
+			},
+			"AddComment":     successHandler("commentCreate"),
+			"UnarchiveIssue": successHandler("issueUnarchive"),
+		})
+	}
+
+	ids := []string{"ENG-1", "ENG-2", "ENG-3", "ENG-4", "ENG-5"}
+	p := &LinearPlugin{}
+
+	t.Run("skip archived", func(t *testing.T) {
+		fake := newFake(t)
+		cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+		res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), ids)
+
+		want := map[string][]string{
+			issueArchived:     {"ENG-2"},
+			issueDeleted:      {"ENG-3", "ENG-4"},
+			issueAccessDenied: {"ENG-5"},
+		}
+		for reason, ids := range want {
+			if got := res.Unavailable[reason]; len(got) != len(ids) || got[0] != ids[0] {
+				t.Errorf("%s = %v, want %v", reason, got, ids)
+			}
+		}
+		if len(res.Errors) != 0 {
+			t.Errorf("expected no generic warnings, got %v", res.Errors)
+		}
+		if res.Commented != 1 {
+			t.Errorf("expected 1 comment, got %d", res.Commented)
+		}
+	})
+
+	t.Run("unarchive", func(t *testing.T) {
+		fake := newFake(t)
+		cfg := p.parseConfig(map[string]any{"update_linked_issues": false, "unarchive_issues": true})
+		res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), ids)
+
+		if len(res.Unarchived) != 1 || res.Unarchived[0] != "ENG-2" {
+			t.Errorf("expected ENG-2 to be unarchived, got %v", res.Unarchived)
+		}
+		if res.Commented != 2 {
+			t.Errorf("expected 2 comments, got %d", res.Commented)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// gitRunner runs a git command and returns its standard output.
+type gitRunner func(ctx context.Context, args ...string) (string, error)
+
+// execGit runs git in the current working directory.
+func execGit(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// historicalRelease is a past release reconstructed from git tags.
+type historicalRelease struct {
+	Tag         string
+	PreviousTag string
+	Commits     []plugin.ConventionalCommit
+}
+
+// runBackfill replays the post-publish hook for every tag in a range so
+// repositories adopting the plugin can link their release history.
+func runBackfill(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	from := fs.String("from", "", "first tag to back-fill (inclusive)")
+	to := fs.String("to", "", "last tag to back-fill (inclusive, defaults to the latest tag)")
+	configPath := fs.String("config", "", "path to a JSON file with the plugin configuration")
+	tagPrefix := fs.String("tag-prefix", "v", "prefix stripped from tags to derive versions")
+	repoURL := fs.String("repository-url", "", "repository URL used for release links")
+	createIssues := fs.Bool("create-release-issues", false, "also create a release issue for each historical release")
+	dryRun := fs.Bool("dry-run", false, "report what would be done without changing Linear")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *from == "" {
+		_, _ = fmt.Fprintln(stderr, "backfill: --from is required")
+		return 2
+	}
+
+	raw, err := loadConfigFile(*configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "backfill: %v\n", err)
+		return 1
+	}
+	raw["create_release_issue"] = *createIssues
+
+	ctx := context.Background()
+	releases, err := collectHistoricalReleases(ctx, execGit, *from, *to)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "backfill: %v\n", err)
+		return 1
+	}
+
+	p := &LinearPlugin{}
+	failed := false
+	for _, rel := range releases {
+		resp, err := p.Execute(ctx, plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  raw,
+			Context: rel.releaseContext(*tagPrefix, *repoURL),
+			DryRun:  *dryRun,
+		})
+		switch {
+		case err != nil:
+			failed = true
+			_, _ = fmt.Fprintf(stdout, "%s: error: %v\n", rel.Tag, err)
+		case !resp.Success:
+			failed = true
+			_, _ = fmt.Fprintf(stdout, "%s: error: %s\n", rel.Tag, resp.Error)
+		default:
+			_, _ = fmt.Fprintf(stdout, "%s: %s\n", rel.Tag, resp.Message)
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// releaseContext builds the release context Relicta would have provided.
+func (r historicalRelease) releaseContext(tagPrefix, repoURL string) plugin.ReleaseContext {
+	ctx := plugin.ReleaseContext{
+		Version:       strings.TrimPrefix(r.Tag, tagPrefix),
+		TagName:       r.Tag,
+		RepositoryURL: repoURL,
+		Changes:       &plugin.CategorizedChanges{Other: r.Commits},
+	}
+	if r.PreviousTag != "" {
+		ctx.PreviousVersion = strings.TrimPrefix(r.PreviousTag, tagPrefix)
+	}
+	return ctx
+}
+
+// collectHistoricalReleases lists tags between from and to (inclusive) in
+// version order along with the commits each one introduced.
+func collectHistoricalReleases(ctx context.Context, git gitRunner, from, to string) ([]historicalRelease, error) {
+	out, err := git(ctx, "tag", "--list")
+	if err != nil {
+		return nil, err
+	}
+
+	tags := strings.Fields(out)
+	sort.Slice(tags, func(i, j int) bool { return compareVersions(tags[i], tags[j]) < 0 })
+
+	start, end := indexOf(tags, from), len(tags)-1
+	if start < 0 {
+		return nil, fmt.Errorf("tag %q not found", from)
+	}
+	if to != "" {
+		if end = indexOf(tags, to); end < 0 {
+			return nil, fmt.Errorf("tag %q not found", to)
+		}
+	}
+	if end < start {
+		return nil, fmt.Errorf("tag %q is older than %q", to, from)
+	}
+
+	var releases []historicalRelease
+	for i := start; i <= end; i++ {
+		rel := historicalRelease{Tag: tags[i]}
+		rangeSpec := tags[i]
+		if i > 0 {
+			rel.PreviousTag = tags[i-1]
+			rangeSpec = tags[i-1] + ".." + tags[i]
+		}
+
+		log, err := git(ctx, "log", "--format=%H%x1f%s%x1f%b%x1e", rangeSpec)
+		if err != nil {
+			return nil, err
+		}
+		rel.Commits = parseGitLog(log)
+		releases = append(releases, rel)
+	}
+
+	return releases, nil
+}
+
+// parseGitLog parses records produced by the backfill log format.
+func parseGitLog(log string) []plugin.ConventionalCommit {
+	var commits []plugin.ConventionalCommit
+	for _, record := range strings.Split(log, "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) < 2 {
+			continue
+		}
+		c := plugin.ConventionalCommit{Hash: fields[0], Description: fields[1]}
+		if len(fields) > 2 {
+			c.Body = strings.TrimSpace(fields[2])
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+func indexOf(items []string, item string) int {
+	for i, v := range items {
+		if v == item {
+			return i
+		}
+	}
+	return -1
+}
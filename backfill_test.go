@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCollectHistoricalReleases(t *testing.T) {
+	git := func(ctx context.Context, args ...string) (string, error) {
+		switch args[0] {
+		case "tag":
+			return "v1.10.0\nv1.2.0\nv1.9.0\nv1.0.0\n", nil
+		case "log":
+			rangeSpec := args[len(args)-1]
+			return "abc\x1ffeat: thing ENG-1 (" + rangeSpec + ")\x1fbody\x1e\n", nil
+		}
+		return "", nil
+	}
+
+	releases, err := collectHistoricalReleases(context.Background(), git, "v1.2.0", "v1.10.0")
+	if err != nil {
+		t.Fatalf("collectHistoricalReleases() error = %v", err)
+	}
+
+	if len(releases) != 3 {
+		t.Fatalf("expected 3 releases, got %d", len(releases))
+	}
+
+	wantRanges := []string{"v1.0.0..v1.2.0", "v1.2.0..v1.9.0", "v1.9.0..v1.10.0"}
+	for i, rel := range releases {
+		if len(rel.Commits) != 1 || !strings.Contains(rel.Commits[0].Description, wantRanges[i]) {
+			t.Errorf("release %s: unexpected commits %v", rel.Tag, rel.Commits)
+		}
+	}
+
+	ctx := releases[2].releaseContext("v", "")
+	if ctx.Version != "1.10.0" || ctx.PreviousVersion != "1.9.0" {
+		t.Errorf("unexpected release context versions: %s (prev %s)", ctx.Version, ctx.PreviousVersion)
+	}
+}
+
+func TestCollectHistoricalReleasesUnknownTag(t *testing.T) {
+	git := func(ctx context.Context, args ...string) (string, error) {
+		return "v1.0.0\n", nil
+	}
+
+	if _, err := collectHistoricalReleases(context.Background(), git, "v9.9.9", ""); err == nil {
+		t.Error("expected error for unknown tag")
+	}
+}
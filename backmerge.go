@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// markerBackMerge tags back-merge reminders with the release version, so a
+// re-publish does not create a second one.
+const markerBackMerge = "back-merge"
+
+// BackMergeConfig controls the follow-up issue reminding the team to merge
+// a release branch back after releasing from it.
+type BackMergeConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Branches  []string `json:"branches"`
+	Target    string   `json:"target"`
+	Title     string   `json:"title"`
+	Captain   string   `json:"captain,omitempty"`
+	DueInDays int      `json:"due_in_days"`
+}
+
+// parseBackMergeConfig parses the back_merge block. The release captain
+// defaults to LINEAR_RELEASE_CAPTAIN, so CI can set it per release.
+func parseBackMergeConfig(raw map[string]any) BackMergeConfig {
+	parser := helpers.NewConfigParser(raw)
+	return BackMergeConfig{
+		Enabled:   parser.GetBool("enabled", false),
+		Branches:  parser.GetStringSlice("branches", []string{"release/*", "release-*"}),
+		Target:    parser.GetString("target", "", "main"),
+		Title:     parser.GetString("title", "", "Back-merge {{.Branch}} after {{.Version}}"),
+		Captain:   parser.GetString("captain", "LINEAR_RELEASE_CAPTAIN", ""),
+		DueInDays: parser.GetInt("due_in_days", 3),
+	}
+}
+
+// appliesTo reports whether a release cut from branch needs a back-merge
+// reminder.
+func (b BackMergeConfig) appliesTo(branch string) bool {
+	if !b.Enabled || branch == "" {
+		return false
+	}
+	for _, pattern := range b.Branches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dueDate returns the reminder's due date counted from now, or "" without
+// a due window.
+func (b BackMergeConfig) dueDate(now time.Time) string {
+	if b.DueInDays <= 0 {
+		return ""
+	}
+	return now.AddDate(0, 0, b.DueInDays).Format("2006-01-02")
+}
+
+// ensureBackMergeReminder creates the back-merge reminder of the release,
+// assigned to the release captain. It returns an existing reminder for the
+// version instead of creating another, and reports whether it created one.
+func ensureBackMergeReminder(ctx context.Context, run *releaseRun) (*Issue, bool, error) {
+	cfg, client := run.cfg, run.client
+	marker := formatMarker(cfg.Naming.marker(markerBackMerge), run.release.Version)
+	existing, err := client.FindIssuesByDescription(ctx, run.team.ID, marker)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up existing back-merge reminder: %w", err)
+	}
+	if len(existing) > 0 {
+		return &existing[0], false, nil
+	}
+
+	title, err := run.render(ctx, cfg.BackMerge.Title)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to render back-merge title: %w", err)
+	}
+
+	input := CreateIssueInput{
+		TeamID:      run.team.ID,
+		Title:       cfg.Naming.title(strings.TrimSpace(title)),
+		Description: appendMarker(backMergeDescription(cfg.BackMerge, run.release.Version, run.release.Branch), cfg.Naming.marker(markerBackMerge), run.release.Version),
+		ProjectID:   cfg.ProjectID,
+		DueDate:     cfg.BackMerge.dueDate(time.Now()),
+	}
+	if cfg.BackMerge.Captain != "" {
+		input.AssigneeID, err = resolveUser(ctx, client, "release captain", cfg.BackMerge.Captain)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	issue, err := client.CreateIssue(ctx, input)
+	if err != nil {
+		return nil, false, err
+	}
+	return issue, true, nil
+}
+
+// backMergeDescription explains what to merge where.
+func backMergeDescription(cfg BackMergeConfig, version, branch string) string {
+	return fmt.Sprintf("Release %s was cut from `%s`. Merge `%s` back into `%s` so its fixes are not lost in the next release.",
+		version, branch, branch, cfg.Target)
+}
+
+// resolveUser returns the user ID of an assignee such as the release
+// captain, given as a user ID or an email address. role names the assignee
+// in errors.
+func resolveUser(ctx context.Context, client *LinearClient, role, assignee string) (string, error) {
+	if !strings.Contains(assignee, "@") {
+		return assignee, nil
+	}
+	user, err := client.FindUserByEmail(ctx, assignee)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s %s: %w", role, assignee, err)
+	}
+	if user == nil {
+		return "", fmt.Errorf("%s %s is not an active member of the workspace", role, assignee)
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestBackMergeAppliesTo(t *testing.T) {
+	cfg := parseBackMergeConfig(map[string]any{"enabled": true})
+
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{"release/1.4", true},
+		{"release-1.4", true},
+		{"main", false},
+		{"feature/release/1.4", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.appliesTo(tt.branch); got != tt.want {
+			t.Errorf("appliesTo(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+
+	if parseBackMergeConfig(nil).appliesTo("release/1.4") {
+		t.Error("expected no reminder unless enabled")
+	}
+}
+
+func TestEnsureBackMergeReminder(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []any{}}}
+		},
+		"FindUserByEmail": func(vars map[string]any) any {
+			return map[string]any{"users": map[string]any{"nodes": []any{
+				map[string]any{"id": "user-captain", "name": "Grace", "email": vars["email"]},
+			}}}
+		},
+		"CreateIssue": func(vars map[string]any) any {
+			return map[string]any{"issueCreate": map[string]any{
+				"success": true,
+				"issue":   map[string]any{"id": "uuid-9", "identifier": "ENG-9"},
+			}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"back_merge": map[string]any{"enabled": true, "captain": "grace@example.com", "due_in_days": 2},
+	})
+	rel := plugin.ReleaseContext{Version: "1.4.0", Branch: "release/1.4"}
+
+	issue, created, err := ensureBackMergeReminder(context.Background(), fake.run(cfg, rel, &Team{ID: "team-1"}))
+	if err != nil || !created || issue.Identifier != "ENG-9" {
+		t.Fatalf("ensureBackMergeReminder() = %v, %v, %v", issue, created, err)
+	}
+
+	input := fake.calls["CreateIssue"][0]["input"].(map[string]any)
+	if input["title"] != "Back-merge release/1.4 after 1.4.0" {
+		t.Errorf("title = %v", input["title"])
+	}
+	if input["assigneeId"] != "user-captain" {
+		t.Errorf("assigneeId = %v, want the release captain", input["assigneeId"])
+	}
+	if want := time.Now().AddDate(0, 0, 2).Format("2006-01-02"); input["dueDate"] != want {
+		t.Errorf("dueDate = %v, want %s", input["dueDate"], want)
+	}
+	desc, _ := input["description"].(string)
+	if !strings.Contains(desc, "back into `main`") || !strings.Contains(desc, formatMarker(markerBackMerge, "1.4.0")) {
+		t.Errorf("unexpected description %q", desc)
+	}
+}
+
+func TestEnsureBackMergeReminderExisting(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []any{
+				map[string]any{"id": "uuid-9", "identifier": "ENG-9", "description": "`relicta:back-merge=1.4.0`"},
+			}}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"back_merge": map[string]any{"enabled": true}})
+	rel := plugin.ReleaseContext{Version: "1.4.0", Branch: "release/1.4"}
+
+	issue, created, err := ensureBackMergeReminder(context.Background(), fake.run(cfg, rel, &Team{ID: "team-1"}))
+	if err != nil || created || issue.Identifier != "ENG-9" {
+		t.Fatalf("ensureBackMergeReminder() = %v, %v, %v", issue, created, err)
+	}
+	if fake.callCount("CreateIssue") != 0 {
+		t.Error("expected no second reminder")
+	}
+}
+
+func TestResolveUserUnknown(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindUserByEmail": func(map[string]any) any {
+			return map[string]any{"users": map[string]any{"nodes": []any{}}}
+		},
+	})
+
+	if _, err := resolveUser(context.Background(), fake.client(), "release captain", "nobody@example.com"); err == nil {
+		t.Error("expected an error for an unknown captain")
+	}
+	if id, err := resolveUser(context.Background(), fake.client(), "release captain", "user-1"); err != nil || id != "user-1" {
+		t.Errorf("resolveUser() = %q, %v, want the user ID as given", id, err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"strings"
+)
+
+// prefetchedIssues holds issues looked up in batches ahead of processing,
+// keyed by upper-case identifier.
+type prefetchedIssues map[string]*Issue
+
+// prefetchIssues looks up the issues with the given identifiers in batched
+// requests. A failed lookup is returned with no issues, so callers fall
+// back to looking issues up one by one.
+func prefetchIssues(ctx context.Context, client *LinearClient, ids []string) (prefetchedIssues, error) {
+	if len(ids) < 2 {
+		return nil, nil
+	}
+	issues, err := client.GetIssuesByIdentifiers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// lookup returns the issue with the given identifier, from the prefetched
+// issues if present and from Linear otherwise. Issues not in the batch
+// include those referenced by an old identifier, and those that are
+// trashed or not accessible, whose errors only the single lookup reports.
+func (p prefetchedIssues) lookup(ctx context.Context, client *LinearClient, id string) (*Issue, error) {
+	if issue, ok := p[strings.ToUpper(id)]; ok {
+		// Copy, since issues referenced twice are processed concurrently
+		c := *issue
+		return &c, nil
+	}
+	return client.GetIssueByIdentifier(ctx, id)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		id     string
+		key    string
+		number int
+		ok     bool
+	}{
+		{"ENG-123", "ENG", 123, true},
+		{"eng-7", "ENG", 7, true},
+		{"ENG", "", 0, false},
+		{"-12", "", 0, false},
+		{"ENG-x", "", 0, false},
+		{"ENG-0", "", 0, false},
+	}
+	for _, tt := range tests {
+		key, number, ok := splitIdentifier(tt.id)
+		if key != tt.key || number != tt.number || ok != tt.ok {
+			t.Errorf("splitIdentifier(%q) = %q, %d, %v", tt.id, key, number, ok)
+		}
+	}
+}
+
+func TestGetIssuesByIdentifiersBatches(t *testing.T) {
+	issues := make(map[string]map[string]any)
+	var ids []string
+	for i := 1; i <= issueBatchSize+10; i++ {
+		id := fmt.Sprintf("ENG-%d", i)
+		issues[id] = map[string]any{"id": fmt.Sprintf("uuid-%d", i), "identifier": id}
+		ids = append(ids, id)
+	}
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(issues),
+	})
+
+	got, err := fake.client().GetIssuesByIdentifiers(context.Background(), append(ids, "ENG-999", "not-an-issue"))
+	if err != nil {
+		t.Fatalf("GetIssuesByIdentifiers() error = %v", err)
+	}
+	if len(got) != len(ids) || got["ENG-7"].ID != "uuid-7" {
+		t.Errorf("got %d issues, want %d", len(got), len(ids))
+	}
+	if n := fake.callCount("GetIssues"); n != 2 {
+		t.Errorf("expected 2 batched requests, got %d", n)
+	}
+	if n := fake.callCount("GetIssue"); n != 0 {
+		t.Errorf("expected no single lookups, got %d", n)
+	}
+}
+
+func TestProcessLinkedIssuesBatchesLookups(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+			"ENG-3": {"id": "uuid-9", "identifier": "OPS-9"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if res.Commented != 3 || len(res.Errors) != 0 {
+		t.Fatalf("Commented = %d, errors = %v", res.Commented, res.Errors)
+	}
+	if fake.callCount("GetIssues") != 1 {
+		t.Errorf("expected one batched lookup, got %d", fake.callCount("GetIssues"))
+	}
+	// Only the moved issue is looked up on its own
+	if calls := fake.calls["GetIssue"]; len(calls) != 1 || calls[0]["id"] != "ENG-3" {
+		t.Errorf("unexpected single lookups %v", calls)
+	}
+	if res.Renamed["ENG-3"] != "OPS-9" {
+		t.Errorf("Renamed = %v", res.Renamed)
+	}
+}
+
+func TestProcessLinkedIssuesBatchFailure(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"GetIssues": func(map[string]any) any {
+			return fakeErrors{{"message": "query too complex"}}
+		},
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+	if res.Commented != 2 {
+		t.Errorf("expected both issues to be processed, Commented = %d", res.Commented)
+	}
+	if fake.callCount("GetIssue") != 2 || len(res.Errors) != 1 {
+		t.Errorf("expected a warning and single lookups, errors = %v", res.Errors)
+	}
+}
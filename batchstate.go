@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// batchesStateUpdates reports whether linked issues moving to the same
+// state are updated with one mutation. Canary runs update issues one by
+// one, so each state change counts against their mutation budget.
+func (c *Config) batchesStateUpdates() bool {
+	return c.UpdateLinkedIssues && !c.Canary.enabled()
+}
+
+// splitMutationOrder returns the mutations ordered before and after kind.
+func splitMutationOrder(order []string, kind string) ([]string, []string) {
+	i := slices.Index(order, kind)
+	if i < 0 {
+		return order, nil
+	}
+	return order[:i], order[i+1:]
+}
+
+// batchUpdateStates moves the prepared issues to their team's released
+// state with one issueBatchUpdate mutation per state, recording each
+// transition in the issue's results. The returned issues tell which state
+// each ended up in, so no re-read is needed to verify them. Issues the bulk
+// update did not cover are updated one by one instead, which also recovers
+// from a released state that was deleted since the run started, unless the
+// bulk update failed in a way the single updates would repeat.
+func batchUpdateStates(ctx context.Context, run *releaseRun, plan *linkedIssuePlan, prepared []*linkedIssue, results []*linkedIssueResults, unprocessed []bool) {
+	cfg, client := run.cfg, run.client
+
+	// Group issues by target state, in issue order
+	var plans []*teamPlan
+	groups := make(map[*teamPlan][]int)
+	for i, li := range prepared {
+		if li == nil || unprocessed[i] || !cfg.MagicWords.allowsMutation(plan.references[li.extractedID], mutationState) {
+			continue
+		}
+		tp := plan.stateTarget(li.issue, li.extractedID)
+		if tp == nil || tp.stateID == "" {
+			continue
+		}
+		if cfg.keepsClosedState(li.issue) {
+			results[i].Closed[li.issueID] = li.issue.State.Name
+			continue
+		}
+		if _, ok := groups[tp]; !ok {
+			plans = append(plans, tp)
+		}
+		groups[tp] = append(groups[tp], i)
+	}
+
+	var fallback []int
+	for _, tp := range plans {
+		ids := make([]string, 0, len(groups[tp]))
+		for _, i := range groups[tp] {
+			ids = append(ids, prepared[i].issue.ID)
+		}
+
+		updated, err := client.UpdateIssueStates(ctx, ids, tp.stateID)
+		after := make(map[string]*Issue, len(updated))
+		for j := range updated {
+			after[updated[j].ID] = &updated[j]
+		}
+		retry := retriesSingly(err)
+		if err != nil && retry && len(updated) < len(ids) {
+			run.results.warn("linked_issues", "Bulk update to %s failed, updating %d issue(s) one by one: %v", tp.state, len(ids)-len(updated), err)
+		}
+		for _, i := range groups[tp] {
+			li := prepared[i]
+			a, ok := after[li.issue.ID]
+			if !ok && retry {
+				fallback = append(fallback, i)
+				continue
+			}
+			if !ok {
+				if !errors.Is(err, errMutationSkipped) {
+					results[i].Transitions = append(results[i].Transitions, newTransition(li.issue, tp.state))
+				}
+				results[i].warn(err, "Failed to update %s", li.issueID)
+				continue
+			}
+			t := newTransition(li.issue, tp.state)
+			t.observe(a, tp.stateID)
+			results[i].recordTransition(t, li.issueID, nil)
+		}
+	}
+
+	slices.Sort(fallback)
+	for _, i := range fallback {
+		if ctx.Err() != nil {
+			unprocessed[i] = true
+			continue
+		}
+		li := prepared[i]
+		if !applyMutation(ctx, run, plan, mutationState, li, results[i]) && ctx.Err() != nil {
+			unprocessed[i] = true
+		}
+	}
+}
+
+// retriesSingly reports whether issues a bulk update failing with err left
+// out are worth updating one by one. Failures the single updates would hit
+// again, such as rejected credentials, a read-only run or an open circuit,
+// are reported instead. Issues left out by a cancelled run still go to the
+// fallback, which marks them unprocessed.
+func retriesSingly(err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case isAuthError(err), errors.Is(err, errReadOnly), errors.Is(err, errCircuitOpen),
+		errors.Is(err, errMutationSkipped):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSplitMutationOrder(t *testing.T) {
+	before, after := splitMutationOrder([]string{"comment", "state", "links"}, mutationState)
+	if !reflect.DeepEqual(before, []string{"comment"}) || !reflect.DeepEqual(after, []string{"links"}) {
+		t.Errorf("splitMutationOrder() = %v, %v", before, after)
+	}
+}
+
+// batchStateHandler answers UpdateIssueStates, moving every issue to the
+// requested state except those listed in diverted.
+func batchStateHandler(diverted map[string]map[string]any) func(map[string]any) any {
+	return func(vars map[string]any) any {
+		stateID := vars["input"].(map[string]any)["stateId"]
+		var issues []any
+		for _, id := range vars["ids"].([]any) {
+			state := map[string]any{"id": stateID, "name": "Done"}
+			if s, ok := diverted[id.(string)]; ok {
+				state = s
+			}
+			issues = append(issues, map[string]any{"id": id, "state": state})
+		}
+		return map[string]any{"issueBatchUpdate": map[string]any{"success": true, "issues": issues}}
+	}
+}
+
+func TestProcessLinkedIssuesBatchesStateUpdates(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+			"ENG-3": {"id": "uuid-3", "identifier": "ENG-3", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+		}),
+		"UpdateIssueStates": batchStateHandler(map[string]map[string]any{
+			"uuid-3": {"id": "state-closed", "name": "Closed"},
+		}),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if fake.callCount("UpdateIssueStates") != 1 || fake.callCount("UpdateIssueState") != 0 {
+		t.Fatalf("expected one bulk update, got %d bulk and %d single", fake.callCount("UpdateIssueStates"), fake.callCount("UpdateIssueState"))
+	}
+	if ids := fake.calls["UpdateIssueStates"][0]["ids"]; !reflect.DeepEqual(ids, []any{"uuid-1", "uuid-2", "uuid-3"}) {
+		t.Errorf("ids = %v", ids)
+	}
+	if res.Updated != 2 || len(res.Errors) != 1 {
+		t.Errorf("Updated = %d, errors = %v", res.Updated, res.Errors)
+	}
+	if len(res.Transitions) != 3 || res.Transitions[0].To != "Done" || res.Transitions[2].Outcome != transitionDiverted {
+		t.Errorf("unexpected transitions %+v", res.Transitions)
+	}
+	// The bulk response tells the new states, so issues are not re-read
+	if n := fake.callCount("GetIssue"); n != 0 {
+		t.Errorf("expected no verification reads, got %d", n)
+	}
+}
+
+func TestProcessLinkedIssuesBatchStateFallback(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-done", "name": "Done"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-done", "name": "Done"}},
+		}),
+		"UpdateIssueStates": func(map[string]any) any {
+			return fakeErrors{{"message": "batch update unavailable"}}
+		},
+		"UpdateIssueState": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	run := fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team)
+	res := p.processLinkedIssues(context.Background(), run, []string{"ENG-1", "ENG-2"})
+
+	if fake.callCount("UpdateIssueState") != 2 || res.Updated != 2 {
+		t.Errorf("expected both issues updated one by one, Updated = %d, errors = %v", res.Updated, res.Errors)
+	}
+	if len(run.results.results) != 1 || !strings.Contains(run.results.results[0].Reason, "batch update unavailable") {
+		t.Errorf("expected the bulk failure to be reported, got %+v", run.results.results)
+	}
+}
+
+func TestProcessLinkedIssuesBatchStateAuthFailure(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+		}),
+		"UpdateIssueStates": func(map[string]any) any {
+			return fakeErrors{{"message": "Authentication required", "extensions": map[string]any{"code": "AUTHENTICATION_ERROR"}}}
+		},
+		"UpdateIssueState": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1", "ENG-2"})
+
+	// Single updates would be rejected the same way, so none are attempted
+	if n := fake.callCount("UpdateIssueState"); n != 0 {
+		t.Errorf("expected no single updates, got %d", n)
+	}
+	if res.Updated != 0 || len(res.Errors) != 2 || !strings.Contains(res.Errors[0], "Authentication required") {
+		t.Errorf("Updated = %d, errors = %v", res.Updated, res.Errors)
+	}
+	if len(res.Transitions) != 2 {
+		t.Errorf("expected the failed transitions in the audit trail, got %+v", res.Transitions)
+	}
+}
+
+func TestProcessLinkedIssuesCanaryUpdatesStatesOneByOne(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-done", "name": "Done"}},
+		}),
+		"UpdateIssueState": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false, "canary": map[string]any{"max_mutations": 5}})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1"})
+
+	if fake.callCount("UpdateIssueStates") != 0 || fake.callCount("UpdateIssueState") != 1 {
+		t.Error("expected canary runs to update states one by one")
+	}
+}
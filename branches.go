@@ -0,0 +1,51 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// mergeSubjectPattern matches the subjects git and GitHub give merge
+// commits, capturing the merged branch name.
+var mergeSubjectPattern = regexp.MustCompile(`^Merge (?:pull request #\d+ from [^/\s]+/(\S+)|(?:remote-tracking )?branch '([^']+)')`)
+
+// mergedBranch returns the branch merged by a commit, or "" if the commit
+// is not a merge.
+func mergedBranch(subject string) string {
+	m := mergeSubjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return m[1]
+	}
+	return m[2]
+}
+
+// branchNames returns the release branch and the branches merged by the
+// release's commits.
+func branchNames(releaseCtx plugin.ReleaseContext) []string {
+	var names []string
+	if releaseCtx.Branch != "" {
+		names = append(names, releaseCtx.Branch)
+	}
+	for _, msg := range commitMessages(releaseCtx) {
+		if name := mergedBranch(msg); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// branchIssues extracts identifiers from branch names. Linear names
+// branches in lower case, like eng-123-add-widget, so names are upper-cased
+// before matching.
+func branchIssues(pattern *regexp.Regexp, names []string) []string {
+	upper := make([]string, len(names))
+	for i, name := range names {
+		upper[i] = strings.ToUpper(name)
+	}
+	return extractIssuesMatching(pattern, upper, "")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMergedBranch(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{"Merge pull request #42 from acme/eng-123-add-widget", "eng-123-add-widget"},
+		{"Merge branch 'eng-7-fix-login'", "eng-7-fix-login"},
+		{"Merge branch 'eng-8-api' into main", "eng-8-api"},
+		{"Merge remote-tracking branch 'origin/eng-9'", "origin/eng-9"},
+		{"add widget ENG-123", ""},
+	}
+
+	for _, tt := range tests {
+		if got := mergedBranch(tt.subject); got != tt.want {
+			t.Errorf("mergedBranch(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestLinkedIssueIDsFromBranches(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{
+		Branch: "eng-5-release-prep",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "add widget ENG-1"}},
+			Other: []plugin.ConventionalCommit{
+				{Description: "Merge pull request #42 from acme/eng-123-add-widget"},
+				{Description: "Merge branch 'ops-4-infra'"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   []string
+	}{
+		{"disabled", map[string]any{"team_key": "ENG"}, []string{"ENG-1"}},
+		{"enabled", map[string]any{"team_key": "ENG", "branch_issues": true}, []string{"ENG-1", "ENG-5", "ENG-123"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(tt.config)
+			if got := linkedIssueIDs(cfg, releaseCtx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkedIssueIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerThreshold is how many requests in a row must fail with a
+	// server or transport error before the circuit opens: a few calls'
+	// worth of retries, so one flaky call does not trip it.
+	breakerThreshold = 10
+
+	// breakerCooldown is how long an open circuit rejects requests before
+	// one is let through to probe whether Linear has recovered.
+	breakerCooldown = 30 * time.Second
+)
+
+// errCircuitOpen is returned without a request while Linear is considered
+// down after repeated failures.
+var errCircuitOpen = errors.New("Linear requests suspended after repeated failures")
+
+// circuitBreaker fails requests fast while Linear is down, instead of every
+// issue of a release spending its retries on an outage. It lives on the
+// client, so hooks and pipelines sharing a client through the registry
+// share it too.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// probing is set while the single request let through after the
+	// cooldown is in flight.
+	probing bool
+}
+
+// allow returns errCircuitOpen while the circuit is open. Once the cooldown
+// has passed the circuit is half-open: one request is let through to probe
+// whether Linear has recovered, and the others are rejected until it
+// returns.
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Before(b.openUntil) {
+		return fmt.Errorf("%w; retrying in %s", errCircuitOpen, b.openUntil.Sub(now).Round(time.Second))
+	}
+	if b.failures < breakerThreshold {
+		return nil
+	}
+	if b.probing {
+		return fmt.Errorf("%w; waiting for a request probing whether Linear has recovered", errCircuitOpen)
+	}
+	b.probing = true
+	return nil
+}
+
+// record counts the outcome of a request. A failed probe opens the circuit
+// again for another cooldown and any other outcome closes it. Cancelled
+// requests say nothing about Linear, so they only end a probe.
+func (b *circuitBreaker) record(err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	if !breaksCircuit(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = now.Add(breakerCooldown)
+	}
+}
+
+// breaksCircuit reports whether err suggests Linear is unavailable: a
+// server error or a failed connection. Rejected requests, rate limits and
+// cancellations say nothing about Linear's health.
+func breaksCircuit(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var b circuitBreaker
+	now := time.Now()
+	serverErr := &StatusError{StatusCode: http.StatusBadGateway}
+
+	for range breakerThreshold - 1 {
+		b.record(serverErr, now)
+	}
+	if err := b.allow(now); err != nil {
+		t.Fatalf("allow() before the threshold = %v", err)
+	}
+
+	// Rejected requests do not count towards the threshold
+	b.record(&StatusError{StatusCode: http.StatusBadRequest}, now)
+	for range breakerThreshold - 1 {
+		b.record(serverErr, now)
+	}
+	if err := b.allow(now); err != nil {
+		t.Fatalf("allow() after a rejected request = %v", err)
+	}
+
+	b.record(serverErr, now)
+	if err := b.allow(now); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("allow() at the threshold = %v, want errCircuitOpen", err)
+	}
+
+	// After the cooldown one probe goes through while the others are still
+	// rejected; its failure reopens the circuit and its success closes it
+	later := now.Add(breakerCooldown)
+	if err := b.allow(later); err != nil {
+		t.Fatalf("allow() after the cooldown = %v", err)
+	}
+	if err := b.allow(later); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("allow() during the probe = %v, want errCircuitOpen", err)
+	}
+	b.record(serverErr, later)
+	if err := b.allow(later); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("allow() after a failed probe = %v, want errCircuitOpen", err)
+	}
+
+	// A cancelled probe lets the next request probe instead
+	later = later.Add(breakerCooldown)
+	if err := b.allow(later); err != nil {
+		t.Fatalf("allow() after the second cooldown = %v", err)
+	}
+	b.record(context.Canceled, later)
+	if err := b.allow(later); err != nil {
+		t.Fatalf("allow() after a cancelled probe = %v", err)
+	}
+
+	b.record(nil, later)
+	if err := b.allow(later); err != nil {
+		t.Errorf("allow() after a successful probe = %v", err)
+	}
+	b.record(serverErr, later)
+	if err := b.allow(later); err != nil {
+		t.Errorf("allow() after one failure in a closed circuit = %v", err)
+	}
+}
+
+func TestExecuteFailsFastWhileCircuitOpen(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	registry := newClientRegistry()
+	client := registry.Get("lin_api_breaker_test")
+	client.endpoint, client.maxAttempts = server.URL, 1
+
+	for range breakerThreshold {
+		if _, err := client.GetViewer(context.Background()); errors.Is(err, errCircuitOpen) {
+			t.Fatalf("GetViewer() = %v before the threshold", err)
+		}
+	}
+
+	// Another hook with the same credentials shares the open circuit
+	_, err := registry.Get("lin_api_breaker_test").GetViewer(context.Background())
+	if !errors.Is(err, errCircuitOpen) {
+		t.Errorf("GetViewer() = %v, want errCircuitOpen", err)
+	}
+	if got := calls.Load(); got != breakerThreshold {
+		t.Errorf("expected %d requests, got %d", breakerThreshold, got)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// errMutationSkipped is returned for mutations withheld by the canary budget.
+var errMutationSkipped = errors.New("mutation skipped by canary limit")
+
+// CanaryConfig limits how many mutations a run may perform, so teams can
+// trial the plugin on a real release with a bounded blast radius.
+type CanaryConfig struct {
+	// MaxMutations caps the number of mutations; negative means unlimited.
+	MaxMutations int `json:"max_mutations"`
+}
+
+// parseCanaryConfig parses the canary block.
+func parseCanaryConfig(raw map[string]any) CanaryConfig {
+	return CanaryConfig{
+		MaxMutations: helpers.NewConfigParser(raw).GetInt("max_mutations", -1),
+	}
+}
+
+// enabled reports whether a mutation limit is configured.
+func (c CanaryConfig) enabled() bool {
+	return c.MaxMutations >= 0
+}
+
+// mutationBudget counts mutations performed during one run and records the
+// ones withheld once the limit is reached.
+type mutationBudget struct {
+	mu        sync.Mutex
+	max       int
+	performed int
+	skipped   []string
+}
+
+type mutationBudgetKey struct{}
+
+// withMutationBudget returns a context whose mutations are limited to max.
+func withMutationBudget(ctx context.Context, max int) (context.Context, *mutationBudget) {
+	b := &mutationBudget{max: max}
+	return context.WithValue(ctx, mutationBudgetKey{}, b), b
+}
+
+// mutationBudgetFrom returns the budget attached to ctx, if any.
+func mutationBudgetFrom(ctx context.Context) *mutationBudget {
+	b, _ := ctx.Value(mutationBudgetKey{}).(*mutationBudget)
+	return b
+}
+
+// allow consumes one mutation from the budget, or records desc as skipped
+// when the budget is exhausted.
+func (b *mutationBudget) allow(desc string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.performed >= b.max {
+		b.skipped = append(b.skipped, desc)
+		return false
+	}
+	b.performed++
+	return true
+}
+
+// summary returns the canary report included in outputs.
+func (b *mutationBudget) summary() map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return map[string]any{
+		"max_mutations": b.max,
+		"performed":     b.performed,
+		"skipped":       append([]string{}, b.skipped...),
+	}
+}
+
+// message describes the canary outcome for the response message.
+func (b *mutationBudget) message() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	msg := fmt.Sprintf("Canary: performed %d of max %d mutation(s)", b.performed, b.max)
+	if len(b.skipped) > 0 {
+		msg += fmt.Sprintf("; %d planned but skipped: %s", len(b.skipped), strings.Join(b.skipped, ", "))
+	}
+	return msg
+}
+
+// operationPattern extracts the operation type and name from a GraphQL
+// document.
+var operationPattern = regexp.MustCompile(`^\s*(query|mutation)\s+(\w+)`)
+
+// describeMutation returns the mutation's operation name and target, if
+// recognizable, or "" when the document is not a mutation.
+func describeMutation(query string, variables map[string]any) string {
+	m := operationPattern.FindStringSubmatch(query)
+	if m == nil || m[1] != "mutation" {
+		return ""
+	}
+
+	target, _ := variables["id"].(string)
+	if input, ok := variables["input"].(map[string]any); ok && target == "" {
+		for _, key := range []string{"issueId", "title", "projectId"} {
+			if v, ok := input[key].(string); ok && v != "" {
+				target = v
+				break
+			}
+		}
+	}
+	if target == "" {
+		return m[2]
+	}
+	return fmt.Sprintf("%s(%s)", m[2], target)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCanaryLimitsMutations(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+			"ENG-3": {"id": "uuid-3", "identifier": "ENG-3"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"canary":               map[string]any{"max_mutations": 1},
+	})
+
+	ctx, budget := withMutationBudget(context.Background(), cfg.Canary.MaxMutations)
+	res := p.processLinkedIssues(ctx, fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if fake.callCount("AddComment") != 1 || res.Commented != 1 {
+		t.Errorf("expected exactly one comment, got %d", fake.callCount("AddComment"))
+	}
+	if len(res.Errors) != 0 {
+		t.Errorf("expected skipped mutations not to be reported as warnings, got %v", res.Errors)
+	}
+
+	summary := budget.summary()
+	skipped, _ := summary["skipped"].([]string)
+	if len(skipped) != 2 || skipped[0] != "AddComment(uuid-2)" {
+		t.Errorf("unexpected skipped mutations: %v", skipped)
+	}
+}
+
+func TestDescribeMutation(t *testing.T) {
+	if got := describeMutation(`query GetIssue($id: String!) { issue(id: $id) { id } }`, nil); got != "" {
+		t.Errorf("expected queries to be ignored, got %q", got)
+	}
+	got := describeMutation(`mutation UpdateIssueState($id: String!) { x }`, map[string]any{"id": "uuid-1"})
+	if got != "UpdateIssueState(uuid-1)" {
+		t.Errorf("describeMutation() = %q", got)
+	}
+}
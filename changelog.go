@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// changeSections are the headings of the formatted changes, in order.
+var changeSections = []struct {
+	heading string
+	commits func(*plugin.CategorizedChanges) []plugin.ConventionalCommit
+	// breaking sections prefer the breaking change description.
+	breaking bool
+}{
+	{"Breaking Changes", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Breaking }, true},
+	{"Features", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Features }, false},
+	{"Bug Fixes", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Fixes }, false},
+	{"Performance", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Performance }, false},
+	{"Refactoring", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Refactor }, false},
+	{"Documentation", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Docs }, false},
+	{"Other Changes", func(c *plugin.CategorizedChanges) []plugin.ConventionalCommit { return c.Other }, false},
+}
+
+// referencePattern matches repository references such as "#42" that are
+// not already part of a link.
+var referencePattern = regexp.MustCompile(`(^|[\s(])#(\d+)\b`)
+
+// formatChanges renders the categorized changes of a release as Markdown
+// that reads well in Linear, independent of the host's changelog: a
+// heading per category and a bullet per commit with its scope, short hash
+// and pull request. With a repository URL, hashes and "#42" references
+// link to the repository. It returns "" without changes.
+func formatChanges(releaseCtx plugin.ReleaseContext) string {
+	changes := releaseCtx.Changes
+	if changes == nil {
+		return ""
+	}
+	repo, sep := repositoryPaths(releaseCtx)
+	pulls := pullRequestURLs(releaseCtx)
+
+	var b strings.Builder
+	for _, section := range changeSections {
+		commits := section.commits(changes)
+		if len(commits) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n\n", section.heading)
+		for _, c := range commits {
+			b.WriteString("- ")
+			if c.Scope != "" {
+				fmt.Fprintf(&b, "**%s:** ", c.Scope)
+			}
+			description := c.Description
+			if section.breaking && c.BreakingDescription != "" {
+				description = c.BreakingDescription
+			}
+			if repo != "" {
+				description = referencePattern.ReplaceAllString(description, "${1}[#${2}]("+repo+sep+"issues/${2})")
+			}
+			b.WriteString(description)
+			if c.Hash != "" {
+				short := trunc(7, c.Hash)
+				if repo != "" {
+					fmt.Fprintf(&b, " ([`%s`](%s%scommit/%s))", short, repo, sep, c.Hash)
+				} else {
+					fmt.Fprintf(&b, " (`%s`)", short)
+				}
+			}
+			if url := commitPullRequest(pulls, c.Hash); url != "" {
+				fmt.Fprintf(&b, " in [%s](%s)", strings.TrimPrefix(pullRequestTitle(url), "Pull request "), url)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
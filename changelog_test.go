@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+var formattedRelease = plugin.ReleaseContext{
+	Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Hash: "abc1234def", Scope: "api", Description: "add export (#42)"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "fix crash"}},
+		Breaking: []plugin.ConventionalCommit{{Hash: "fed9876", Description: "drop v1", BreakingDescription: "the v1 API is gone"}},
+	},
+}
+
+func TestFormatChanges(t *testing.T) {
+	got := formatChanges(formattedRelease)
+	want := "### Breaking Changes\n\n- the v1 API is gone (`fed9876`)\n\n" +
+		"### Features\n\n- **api:** add export (#42) (`abc1234`)\n\n" +
+		"### Bug Fixes\n\n- fix crash"
+	if got != want {
+		t.Errorf("formatChanges() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := formatChanges(plugin.ReleaseContext{}); got != "" {
+		t.Errorf("formatChanges() without changes = %q", got)
+	}
+}
+
+func TestFormatChangesLinks(t *testing.T) {
+	release := formattedRelease
+	release.RepositoryURL = "https://github.com/acme/app.git"
+	release.Environment = map[string]string{pullRequestsEnvVar: "abc1234=https://github.com/acme/app/pull/42"}
+
+	got := formatChanges(release)
+	want := "### Breaking Changes\n\n- the v1 API is gone ([`fed9876`](https://github.com/acme/app/commit/fed9876))\n\n" +
+		"### Features\n\n- **api:** add export ([#42](https://github.com/acme/app/issues/42)) " +
+		"([`abc1234`](https://github.com/acme/app/commit/abc1234def)) in [#42](https://github.com/acme/app/pull/42)\n\n" +
+		"### Bug Fixes\n\n- fix crash"
+	if got != want {
+		t.Errorf("formatChanges() =\n%s\nwant\n%s", got, want)
+	}
+
+	release.RepositoryURL = "https://gitlab.com/acme/app"
+	release.Environment = nil
+	release.Changes = &plugin.CategorizedChanges{Fixes: []plugin.ConventionalCommit{{Hash: "abc1234", Description: "fix #7"}}}
+	want = "### Bug Fixes\n\n- fix [#7](https://gitlab.com/acme/app/-/issues/7) ([`abc1234`](https://gitlab.com/acme/app/-/commit/abc1234))"
+	if got := formatChanges(release); got != want {
+		t.Errorf("formatChanges() on GitLab =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormattedChangesTemplate(t *testing.T) {
+	got, err := renderTemplate("{{.FormattedChanges}}", formattedRelease, nil)
+	if err != nil || got != formatChanges(formattedRelease) {
+		t.Errorf("renderTemplate() = %q, %v", got, err)
+	}
+}
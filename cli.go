@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// cliCommand is a maintenance command available when the binary is run
+// directly rather than by Relicta.
+type cliCommand struct {
+	Summary string
+	Run     func(args []string, stdout, stderr io.Writer) int
+}
+
+// cliCommands lists the available CLI commands by name.
+var cliCommands = map[string]cliCommand{
+	"backfill": {
+		Summary: "Retroactively process historical releases in a tag range",
+		Run:     runBackfill,
+	},
+	"prune-labels": {
+		Summary: "Delete or rename the version labels of old releases",
+		Run:     runPruneLabels,
+	},
+	"render": {
+		Summary: "Render a configured template against a sample or given release",
+		Run:     runRender,
+	},
+}
+
+// runCLI dispatches a CLI command and returns the process exit code.
+func runCLI(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	cmd, ok := cliCommands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+	return cmd.Run(args[1:], stdout, stderr)
+}
+
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: plugin-linear <command> [flags]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for name, cmd := range cliCommands {
+		_, _ = fmt.Fprintf(w, "  %-14s %s\n", name, cmd.Summary)
+	}
+}
+
+// loadConfigFile reads plugin configuration from a JSON file. An empty path
+// yields an empty config so environment variables and defaults apply.
+func loadConfigFile(path string) (map[string]any, error) {
+	if path == "" {
+		return map[string]any{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return raw, nil
+}
@@ -5,9 +5,15 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,24 +22,57 @@ const (
 	defaultTimeout    = 30 * time.Second
 )
 
-// LinearClient wraps the Linear GraphQL API.
+// LinearClient wraps the Linear GraphQL API. It is safe for concurrent use.
 type LinearClient struct {
 	endpoint   string
 	apiKey     string
 	httpClient *http.Client
+
+	// credentials supplies the token when set; otherwise apiKey is sent.
+	credentials CredentialProvider
+
+	// maxAttempts bounds how often a retryable request is sent; values
+	// below one mean a single attempt.
+	maxAttempts int
+	retryDelay  time.Duration
+
+	// rateLimit throttles requests while the API key's budget is low.
+	rateLimit rateLimiter
+
+	// breaker fails requests fast while Linear keeps failing.
+	breaker circuitBreaker
+
+	// metadata caches team and viewer lookups.
+	metadata metadataCache
+
+	// signer adds headers and a signature to requests, when configured.
+	signer *requestSigner
+
+	// networkErr fails every request when the network settings could not
+	// be applied, such as an unreadable CA file.
+	networkErr error
 }
 
 // NewLinearClient creates a new Linear API client.
 func NewLinearClient(apiKey string) *LinearClient {
+	return newCredentialedClient(staticCredentials(apiKey))
+}
+
+// newCredentialedClient creates a Linear API client authenticating with
+// the provider's tokens.
+func newCredentialedClient(credentials CredentialProvider) *LinearClient {
 	return &LinearClient{
-		endpoint: linearAPIEndpoint,
-		apiKey:   apiKey,
+		endpoint:    linearAPIEndpoint,
+		credentials: credentials,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
 				TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
 			},
 		},
+		maxAttempts: defaultMaxAttempts,
+		retryDelay:  defaultRetryDelay,
 	}
 }
 
@@ -47,6 +86,9 @@ type GraphQLRequest struct {
 type GraphQLResponse struct {
 	Data   json.RawMessage `json:"data,omitempty"`
 	Errors []GraphQLError  `json:"errors,omitempty"`
+
+	// complexity is the query complexity from the X-Complexity header.
+	complexity int
 }
 
 // GraphQLError represents a GraphQL error.
@@ -54,17 +96,129 @@ type GraphQLError struct {
 	Message    string   `json:"message"`
 	Path       []string `json:"path,omitempty"`
 	Extensions struct {
-		Code string `json:"code,omitempty"`
+		Code                   string `json:"code,omitempty"`
+		Type                   string `json:"type,omitempty"`
+		UserPresentableMessage string `json:"userPresentableMessage,omitempty"`
 	} `json:"extensions,omitempty"`
 }
 
+// APIError is returned when Linear responds with GraphQL errors.
+type APIError struct {
+	Errors []GraphQLError
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GraphQL error: %s", e.Errors[0].Message)
+}
+
+// ErrIssueNotFound is returned when an issue lookup yields no issue.
+var ErrIssueNotFound = errors.New("issue not found")
+
 // Issue represents a Linear issue.
 type Issue struct {
-	ID         string `json:"id"`
-	Identifier string `json:"identifier"`
-	Title      string `json:"title"`
-	State      State  `json:"state"`
-	URL        string `json:"url"`
+	ID          string       `json:"id"`
+	Identifier  string       `json:"identifier"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	State       State        `json:"state"`
+	URL         string       `json:"url"`
+	CreatedAt   string       `json:"createdAt,omitempty"`
+	ArchivedAt  string       `json:"archivedAt,omitempty"`
+	Trashed     bool         `json:"trashed,omitempty"`
+	Estimate    float64      `json:"estimate,omitempty"`
+	Priority    float64      `json:"priority,omitempty"`
+	Assignee    *User        `json:"assignee,omitempty"`
+	Project     *Project     `json:"project,omitempty"`
+	Labels      *Labels      `json:"labels,omitempty"`
+	Team        *Team        `json:"team,omitempty"`
+	Cycle       *Cycle       `json:"cycle,omitempty"`
+	Attachments *Attachments `json:"attachments,omitempty"`
+}
+
+// Attachment is an external link attached to an issue.
+type Attachment struct {
+	URL string `json:"url"`
+}
+
+// Attachments is the attachment connection of an issue.
+type Attachments struct {
+	Nodes []Attachment `json:"nodes"`
+}
+
+// hasAttachment reports whether a link to url is already attached.
+func (i *Issue) hasAttachment(url string) bool {
+	if i.Attachments == nil {
+		return false
+	}
+	for _, a := range i.Attachments.Nodes {
+		if a.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// Label represents an issue label.
+type Label struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	IsGroup bool   `json:"isGroup,omitempty"`
+	Team    *Team  `json:"team,omitempty"`
+	Parent  *Label `json:"parent,omitempty"`
+}
+
+// Labels is the label connection of an issue.
+type Labels struct {
+	Nodes []Label `json:"nodes"`
+}
+
+// hasLabel reports whether the issue carries the label, ignoring case.
+func (i *Issue) hasLabel(name string) bool {
+	if i.Labels == nil {
+		return false
+	}
+	for _, l := range i.Labels.Nodes {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleID returns the ID of the issue's cycle, or "" if it has none.
+func (i *Issue) cycleID() string {
+	if i.Cycle == nil {
+		return ""
+	}
+	return i.Cycle.ID
+}
+
+// labelIDs returns the IDs of the issue's labels.
+func (i *Issue) labelIDs() []string {
+	if i.Labels == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(i.Labels.Nodes))
+	for _, l := range i.Labels.Nodes {
+		ids = append(ids, l.ID)
+	}
+	return ids
+}
+
+// Project represents a Linear project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// User represents a Linear user.
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	URL   string `json:"url,omitempty"`
 }
 
 // State represents a workflow state.
@@ -72,6 +226,8 @@ type State struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// Position orders the states of a team's workflow.
+	Position float64 `json:"position,omitempty"`
 }
 
 // Team represents a Linear team.
@@ -84,23 +240,34 @@ type Team struct {
 
 // Viewer represents the authenticated user.
 type Viewer struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Email        string        `json:"email"`
+	Organization *Organization `json:"organization,omitempty"`
 }
 
-// CreateIssueInput represents input for creating an issue.
-type CreateIssueInput struct {
-	TeamID      string `json:"teamId"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Priority    int    `json:"priority,omitempty"`
-	ProjectID   string `json:"projectId,omitempty"`
-	AssigneeID  string `json:"assigneeId,omitempty"`
+// Organization represents a Linear workspace.
+type Organization struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URLKey string `json:"urlKey"`
 }
 
 // execute sends a GraphQL request to Linear.
 func (c *LinearClient) execute(ctx context.Context, query string, variables map[string]any) (*GraphQLResponse, error) {
+	variables, err := encodeVariables(variables)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReadOnly(ctx, query, variables); err != nil {
+		return nil, err
+	}
+	if desc := describeMutation(query, variables); desc != "" {
+		if budget := mutationBudgetFrom(ctx); budget != nil && !budget.allow(desc) {
+			return nil, fmt.Errorf("%s: %w", desc, errMutationSkipped)
+		}
+	}
+
 	reqBody := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -111,19 +278,142 @@ func (c *LinearClient) execute(ctx context.Context, query string, variables map[
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	operation := "anonymous"
+	if m := operationPattern.FindStringSubmatch(query); m != nil {
+		operation = m[2]
+	}
+	mutation := strings.HasPrefix(strings.TrimSpace(query), "mutation")
+
+	trace := callTrace{Operation: operation, Type: "query", Start: time.Now()}
+	if mutation {
+		trace.Type = "mutation"
+	}
+	finish := func(resp *GraphQLResponse, attempts int, err error) {
+		trace.Duration = time.Since(trace.Start)
+		trace.Attempts = attempts
+		if resp != nil {
+			trace.Complexity = resp.complexity
+		}
+		if err != nil {
+			trace.Error = err.Error()
+		}
+		traceCall(ctx, trace)
+	}
+
+	// Mutations that carry a client-generated ID are safe to resend
+	retryAsMutation := mutation && !idempotentMutation(variables)
+
+	attempts := max(c.maxAttempts, 1)
+	attempt := 1
+	refreshed := false
+	for ; ; attempt++ {
+		token, err := c.token(ctx)
+		if err != nil {
+			finish(nil, attempt, err)
+			return nil, err
+		}
+
+		throttled, err := c.rateLimit.wait(ctx)
+		trace.Throttled += throttled
+		if err != nil {
+			finish(nil, attempt, err)
+			return nil, err
+		}
+
+		// Checked right before sending, so every request the breaker lets
+		// through is recorded
+		if err := c.breaker.allow(time.Now()); err != nil {
+			finish(nil, attempt, err)
+			return nil, err
+		}
+
+		sent := time.Now()
+		resp, err := c.send(ctx, jsonBody, token)
+		c.breaker.record(err, time.Now())
+		logDebug(ctx, debugRequest{
+			Operation: operation,
+			Attempt:   attempt,
+			Variables: variables,
+			Status:    responseStatus(resp, err),
+			Duration:  time.Since(sent),
+			Err:       err,
+		})
+
+		// Refresh an expired token once and resend without using up an
+		// attempt. Linear rejected the request, so mutations are safe too.
+		if err != nil && !refreshed && isAuthError(err) && c.invalidateToken(token) {
+			refreshed = true
+			attempt--
+			continue
+		}
+
+		// Once the execution's retry budget is spent, failures are final
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !isRetryable(err, retryAsMutation) ||
+			!retryBudgetFrom(ctx).allow() {
+			if attempt > 1 {
+				recordRetry(ctx, operation, attempt, err)
+			}
+			finish(resp, attempt, err)
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			recordRetry(ctx, operation, attempt, ctx.Err())
+			finish(nil, attempt, ctx.Err())
+			return nil, fmt.Errorf("failed to execute request: %w", ctx.Err())
+		case <-time.After(backoff(err, c.retryDelay, attempt)):
+		}
+	}
+}
+
+// token returns the token to authenticate the next request with.
+func (c *LinearClient) token(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		return c.apiKey, nil
+	}
+	token, err := c.credentials.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials: %w", err)
+	}
+	return token, nil
+}
+
+// invalidateToken discards a token Linear rejected, so the next request
+// obtains a new one. It reports whether the credentials can be refreshed.
+func (c *LinearClient) invalidateToken(token string) bool {
+	refreshable, ok := c.credentials.(refreshableCredentials)
+	if !ok {
+		return false
+	}
+	refreshable.Invalidate(token)
+	return true
+}
+
+// send performs a single GraphQL request.
+func (c *LinearClient) send(ctx context.Context, jsonBody []byte, token string) (*GraphQLResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	if c.networkErr != nil {
+		return nil, c.networkErr
+	}
+	if c.signer != nil {
+		if err := c.signer.sign(ctx, req, jsonBody); err != nil {
+			return nil, err
+		}
+	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.apiKey)
+	req.Header.Set("Authorization", authorization(token))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.rateLimit.observe(resp.Header)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -131,24 +421,34 @@ func (c *LinearClient) execute(ctx context.Context, query string, variables map[
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s (status %d)", string(body), resp.StatusCode)
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
 	var gqlResp GraphQLResponse
 	if err := json.Unmarshal(body, &gqlResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	gqlResp.complexity, _ = strconv.Atoi(resp.Header.Get("X-Complexity"))
 
 	if len(gqlResp.Errors) > 0 {
-		return &gqlResp, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+		return &gqlResp, &APIError{Errors: gqlResp.Errors}
 	}
 
 	return &gqlResp, nil
 }
 
-// GetViewer returns the authenticated user.
+// GetViewer returns the authenticated user. The result is cached for
+// metadataTTL.
 func (c *LinearClient) GetViewer(ctx context.Context) (*Viewer, error) {
-	query := `query { viewer { id name email } }`
+	if viewer, ok := c.metadata.cachedViewer(); ok {
+		return viewer, nil
+	}
+
+	query := `query GetViewer { viewer { id name email organization { id name urlKey } } }`
 
 	resp, err := c.execute(ctx, query, nil)
 	if err != nil {
@@ -162,125 +462,276 @@ func (c *LinearClient) GetViewer(ctx context.Context) (*Viewer, error) {
 		return nil, fmt.Errorf("failed to parse viewer: %w", err)
 	}
 
+	c.metadata.storeViewer(&result.Viewer)
 	return &result.Viewer, nil
 }
 
-// GetTeam returns a team by ID or key.
-func (c *LinearClient) GetTeam(ctx context.Context, teamID, teamKey string) (*Team, error) {
-	var query string
-	var variables map[string]any
+// teamFields is the selection of a team shared by the team lookups, with
+// the first page of its workflow states.
+const teamFields = `
+	id
+	key
+	name
+	states(first: 100) {
+		nodes {
+			id
+			name
+			type
+			position
+		}
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+	}
+`
 
-	if teamID != "" {
-		query = `query GetTeam($id: String!) {
-			team(id: $id) {
-				id
-				key
-				name
-				states {
-					nodes {
-						id
-						name
-						type
-					}
-				}
-			}
-		}`
-		variables = map[string]any{"id": teamID}
-	} else if teamKey != "" {
-		// Use teams query and filter by key
-		query = `query GetTeams {
-			teams {
+// teamNode is a team as returned with teamFields.
+type teamNode struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	States struct {
+		Nodes    []State  `json:"nodes"`
+		PageInfo pageInfo `json:"pageInfo"`
+	} `json:"states"`
+}
+
+// team returns the team with all its workflow states, fetching the states
+// beyond the first page.
+func (c *LinearClient) team(ctx context.Context, t teamNode) (*Team, error) {
+	states := t.States.Nodes
+	if t.States.PageInfo.HasNextPage {
+		more, err := c.teamStates(ctx, t.ID, t.States.PageInfo.EndCursor)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, more...)
+	}
+	return &Team{ID: t.ID, Key: t.Key, Name: t.Name, States: states}, nil
+}
+
+// teamStates returns the workflow states of a team after the given cursor.
+func (c *LinearClient) teamStates(ctx context.Context, teamID, after string) ([]State, error) {
+	query := `query GetTeamStates($id: String!, $first: Int!, $after: String) {
+		team(id: $id) {
+			states(first: $first, after: $after) {
 				nodes {
 					id
-					key
 					name
-					states {
-						nodes {
-							id
-							name
-							type
-						}
-					}
+					type
+					position
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
 				}
 			}
-		}`
-	} else {
-		return nil, fmt.Errorf("either team_id or team_key is required")
-	}
+		}
+	}`
 
-	resp, err := c.execute(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
+	var states []State
+	err := paginate(after, func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"id": teamID, "first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
 
-	if teamID != "" {
 		var result struct {
 			Team struct {
-				ID     string `json:"id"`
-				Key    string `json:"key"`
-				Name   string `json:"name"`
 				States struct {
-					Nodes []State `json:"nodes"`
+					Nodes    []State  `json:"nodes"`
+					PageInfo pageInfo `json:"pageInfo"`
 				} `json:"states"`
 			} `json:"team"`
 		}
 		if err := json.Unmarshal(resp.Data, &result); err != nil {
-			return nil, fmt.Errorf("failed to parse team: %w", err)
+			return pageInfo{}, fmt.Errorf("failed to parse team states: %w", err)
 		}
+		states = append(states, result.Team.States.Nodes...)
+		return result.Team.States.PageInfo, nil
+	})
+	return states, err
+}
 
-		return &Team{
-			ID:     result.Team.ID,
-			Key:    result.Team.Key,
-			Name:   result.Team.Name,
-			States: result.Team.States.Nodes,
-		}, nil
+// GetTeam returns a team by ID or key. The result is cached for
+// metadataTTL.
+func (c *LinearClient) GetTeam(ctx context.Context, teamID, teamKey string) (*Team, error) {
+	if team, ok := c.metadata.team(teamID, teamKey); ok {
+		return team, nil
 	}
+	team, err := c.fetchTeam(ctx, teamID, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	c.metadata.storeTeam(team)
+	return team, nil
+}
 
-	// Find team by key
-	var result struct {
-		Teams struct {
-			Nodes []struct {
-				ID     string `json:"id"`
-				Key    string `json:"key"`
-				Name   string `json:"name"`
-				States struct {
-					Nodes []State `json:"nodes"`
-				} `json:"states"`
-			} `json:"nodes"`
-		} `json:"teams"`
+// fetchTeam looks a team up by ID or key.
+func (c *LinearClient) fetchTeam(ctx context.Context, teamID, teamKey string) (*Team, error) {
+	if teamID != "" {
+		query := `query GetTeam($id: String!) {
+			team(id: $id) {` + teamFields + `}
+		}`
+
+		resp, err := c.execute(ctx, query, map[string]any{"id": teamID})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Team teamNode `json:"team"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse team: %w", err)
+		}
+		return c.team(ctx, result.Team)
 	}
-	if err := json.Unmarshal(resp.Data, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse teams: %w", err)
+	if teamKey == "" {
+		return nil, fmt.Errorf("either team_id or team_key is required")
 	}
 
-	for _, t := range result.Teams.Nodes {
-		if t.Key == teamKey {
-			return &Team{
-				ID:     t.ID,
-				Key:    t.Key,
-				Name:   t.Name,
-				States: t.States.Nodes,
-			}, nil
+	// Page through the teams until the one with the key turns up
+	query := `query GetTeams($first: Int!, $after: String) {
+		teams(first: $first, after: $after) {
+			nodes {` + teamFields + `}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`
+
+	var found *teamNode
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
+
+		var result struct {
+			Teams struct {
+				Nodes    []teamNode `json:"nodes"`
+				PageInfo pageInfo   `json:"pageInfo"`
+			} `json:"teams"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse teams: %w", err)
 		}
+		for i, t := range result.Teams.Nodes {
+			if t.Key == teamKey {
+				found = &result.Teams.Nodes[i]
+				return pageInfo{}, nil
+			}
+		}
+		return result.Teams.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("team with key '%s' not found", teamKey)
 	}
+	return c.team(ctx, *found)
+}
+
+// ListTeamKeys returns the keys of the workspace's teams.
+func (c *LinearClient) ListTeamKeys(ctx context.Context) ([]string, error) {
+	query := `query ListTeamKeys($first: Int!, $after: String) {
+		teams(first: $first, after: $after) {
+			nodes {
+				key
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`
+
+	var keys []string
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
 
-	return nil, fmt.Errorf("team with key '%s' not found", teamKey)
+		var result struct {
+			Teams struct {
+				Nodes    []Team   `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"teams"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse teams: %w", err)
+		}
+		for _, t := range result.Teams.Nodes {
+			keys = append(keys, t.Key)
+		}
+		return result.Teams.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
 }
 
-// GetIssueByIdentifier returns an issue by its identifier (e.g., ENG-123).
-func (c *LinearClient) GetIssueByIdentifier(ctx context.Context, identifier string) (*Issue, error) {
-	query := `query GetIssue($id: String!) {
-		issue(id: $id) {
+// issueFields is the selection of an issue shared by the issue lookups.
+const issueFields = `
+		id
+		identifier
+		title
+		description
+		url
+		createdAt
+		estimate
+		priority
+		archivedAt
+		trashed
+		state {
 			id
-			identifier
-			title
+			name
+			type
+		}
+		assignee {
+			id
+			name
+			email
+			url
+		}
+		project {
+			id
+			name
 			url
-			state {
+		}
+		team {
+			id
+			key
+			name
+		}
+		cycle {
+			id
+			number
+			name
+		}
+		attachments {
+			nodes {
+				url
+			}
+		}
+		labels {
+			nodes {
 				id
 				name
-				type
 			}
 		}
+	`
+
+// GetIssueByIdentifier returns an issue by its identifier (e.g., ENG-123).
+func (c *LinearClient) GetIssueByIdentifier(ctx context.Context, identifier string) (*Issue, error) {
+	query := `query GetIssue($id: String!) {
+		issue(id: $id) {` + issueFields + `}
 	}`
 
 	resp, err := c.execute(ctx, query, map[string]any{"id": identifier})
@@ -296,13 +747,83 @@ func (c *LinearClient) GetIssueByIdentifier(ctx context.Context, identifier stri
 	}
 
 	if result.Issue.ID == "" {
-		return nil, fmt.Errorf("issue %s not found", identifier)
+		return nil, fmt.Errorf("issue %s: %w", identifier, ErrIssueNotFound)
 	}
 
 	return &result.Issue, nil
 }
 
-// CreateIssue creates a new issue.
+// issueBatchSize is how many issues one GetIssuesByIdentifiers request
+// looks up, keeping each query well within the complexity limit.
+const issueBatchSize = 50
+
+// GetIssuesByIdentifiers looks up many issues by identifier in as few
+// requests as possible, keyed by their upper-case identifier. Identifiers
+// without a match are missing from the result rather than failing the
+// lookup; issues referenced by an old identifier after a move are not
+// matched either, so callers look those up one by one.
+func (c *LinearClient) GetIssuesByIdentifiers(ctx context.Context, identifiers []string) (map[string]*Issue, error) {
+	query := `query GetIssues($filter: IssueFilter!, $first: Int!) {
+		issues(filter: $filter, first: $first, includeArchived: true) {
+			nodes {` + issueFields + `}
+		}
+	}`
+
+	var filters []any
+	for _, id := range identifiers {
+		key, number, ok := splitIdentifier(id)
+		if !ok {
+			continue
+		}
+		filters = append(filters, map[string]any{
+			"team":   map[string]any{"key": map[string]any{"eq": key}},
+			"number": map[string]any{"eq": number},
+		})
+	}
+
+	issues := make(map[string]*Issue, len(filters))
+	for batch := range slices.Chunk(filters, issueBatchSize) {
+		resp, err := c.execute(ctx, query, map[string]any{
+			"filter": map[string]any{"or": batch},
+			"first":  len(batch),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Issues struct {
+				Nodes []Issue `json:"nodes"`
+			} `json:"issues"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse issues: %w", err)
+		}
+		for i := range result.Issues.Nodes {
+			issue := &result.Issues.Nodes[i]
+			issues[strings.ToUpper(issue.Identifier)] = issue
+		}
+	}
+	return issues, nil
+}
+
+// splitIdentifier splits an identifier such as ENG-123 into its team key
+// and issue number.
+func splitIdentifier(identifier string) (string, int, bool) {
+	key, number, ok := strings.Cut(identifier, "-")
+	if !ok || key == "" {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return strings.ToUpper(key), n, true
+}
+
+// CreateIssue creates a new issue. The issue ID is chosen by the client, so a
+// create that is retried after its response was lost cannot produce a
+// duplicate, and a create that went through is recovered by looking it up.
 func (c *LinearClient) CreateIssue(ctx context.Context, input CreateIssueInput) (*Issue, error) {
 	query := `mutation CreateIssue($input: IssueCreateInput!) {
 		issueCreate(input: $input) {
@@ -321,25 +842,23 @@ func (c *LinearClient) CreateIssue(ctx context.Context, input CreateIssueInput)
 		}
 	}`
 
-	gqlInput := map[string]any{
-		"teamId": input.TeamID,
-		"title":  input.Title,
-	}
-	if input.Description != "" {
-		gqlInput["description"] = input.Description
-	}
-	if input.Priority > 0 {
-		gqlInput["priority"] = input.Priority
-	}
-	if input.ProjectID != "" {
-		gqlInput["projectId"] = input.ProjectID
-	}
-	if input.AssigneeID != "" {
-		gqlInput["assigneeId"] = input.AssigneeID
+	if input.ID == "" {
+		id, err := newUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate issue ID: %w", err)
+		}
+		input.ID = id
 	}
 
-	resp, err := c.execute(ctx, query, map[string]any{"input": gqlInput})
+	resp, err := c.execute(ctx, query, map[string]any{"input": input})
 	if err != nil {
+		if errors.Is(err, errMutationSkipped) || errors.Is(err, errReadOnly) {
+			return nil, err
+		}
+		// An earlier attempt may have created the issue before failing
+		if issue, lookupErr := c.GetIssueByIdentifier(ctx, input.ID); lookupErr == nil {
+			return issue, nil
+		}
 		return nil, err
 	}
 
@@ -362,15 +881,32 @@ func (c *LinearClient) CreateIssue(ctx context.Context, input CreateIssueInput)
 
 // UpdateIssueState updates the state of an issue.
 func (c *LinearClient) UpdateIssueState(ctx context.Context, issueID, stateID string) error {
-	query := `mutation UpdateIssueState($id: String!, $input: IssueUpdateInput!) {
+	return c.updateIssue(ctx, "UpdateIssueState", issueID, IssueUpdateInput{StateID: stateID}, "state")
+}
+
+// UpdateIssue changes several fields of an issue in one mutation: labels,
+// assignee, project, estimate, due date, or any other field of the input.
+// Only the fields set in input are changed; an empty input sends nothing.
+func (c *LinearClient) UpdateIssue(ctx context.Context, issueID string, input IssueUpdateInput) error {
+	if reflect.ValueOf(input).IsZero() {
+		return nil
+	}
+	return c.updateIssue(ctx, "UpdateIssue", issueID, input, "")
+}
+
+// updateIssue runs an issueUpdate mutation under the given operation name,
+// so each kind of change keeps its own name in logs, traces and guards.
+// what names the change in the error when Linear reports no success.
+func (c *LinearClient) updateIssue(ctx context.Context, operation, issueID string, input IssueUpdateInput, what string) error {
+	query := fmt.Sprintf(`mutation %s($id: String!, $input: IssueUpdateInput!) {
 		issueUpdate(id: $id, input: $input) {
 			success
 		}
-	}`
+	}`, operation)
 
 	resp, err := c.execute(ctx, query, map[string]any{
 		"id":    issueID,
-		"input": map[string]any{"stateId": stateID},
+		"input": input,
 	})
 	if err != nil {
 		return err
@@ -386,41 +922,1205 @@ func (c *LinearClient) UpdateIssueState(ctx context.Context, issueID, stateID st
 	}
 
 	if !result.IssueUpdate.Success {
-		return fmt.Errorf("failed to update issue state")
+		if what == "" {
+			return fmt.Errorf("failed to update issue")
+		}
+		return fmt.Errorf("failed to update issue %s", what)
 	}
 
 	return nil
 }
 
-// AddComment adds a comment to an issue.
-func (c *LinearClient) AddComment(ctx context.Context, issueID, body string) error {
-	query := `mutation AddComment($input: CommentCreateInput!) {
-		commentCreate(input: $input) {
-			success
-		}
-	}`
+// issueBatchUpdateSize is the most issues one issueBatchUpdate mutation
+// accepts.
+const issueBatchUpdateSize = 50
 
-	resp, err := c.execute(ctx, query, map[string]any{
-		"input": map[string]any{
-			"issueId": issueID,
-			"body":    body,
-		},
-	})
-	if err != nil {
-		return err
+// UpdateIssueStates moves issues to the same state with one mutation per
+// batch of issues, returning the updated issues with the state each ended
+// up in.
+func (c *LinearClient) UpdateIssueStates(ctx context.Context, issueIDs []string, stateID string) ([]Issue, error) {
+	query := `mutation UpdateIssueStates($ids: [UUID!]!, $input: IssueUpdateInput!) {
+		issueBatchUpdate(ids: $ids, input: $input) {
+			success
+			issues {
+				id
+				identifier
+				state {
+					id
+					name
+					type
+				}
+			}
+		}
+	}`
+
+	var updated []Issue
+	for batch := range slices.Chunk(issueIDs, issueBatchUpdateSize) {
+		resp, err := c.execute(ctx, query, map[string]any{
+			"ids":   batch,
+			"input": IssueUpdateInput{StateID: stateID},
+		})
+		if err != nil {
+			return updated, err
+		}
+
+		var result struct {
+			IssueBatchUpdate struct {
+				Success bool    `json:"success"`
+				Issues  []Issue `json:"issues"`
+			} `json:"issueBatchUpdate"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return updated, fmt.Errorf("failed to parse batch update response: %w", err)
+		}
+		if !result.IssueBatchUpdate.Success {
+			return updated, fmt.Errorf("failed to update issue states")
+		}
+		updated = append(updated, result.IssueBatchUpdate.Issues...)
+	}
+	return updated, nil
+}
+
+// UpdateIssueDescription replaces the description of an issue.
+func (c *LinearClient) UpdateIssueDescription(ctx context.Context, issueID, description string) error {
+	return c.updateIssue(ctx, "UpdateIssueDescription", issueID, IssueUpdateInput{Description: Set(description)}, "description")
+}
+
+// AddComment adds a comment to an issue.
+func (c *LinearClient) AddComment(ctx context.Context, issueID, body string) error {
+	_, err := c.createComment(ctx, "AddComment", CommentCreateInput{IssueID: issueID, Body: body})
+	return err
+}
+
+// AddReply adds a comment to an issue as a reply to a parent comment.
+func (c *LinearClient) AddReply(ctx context.Context, issueID, parentID, body string) error {
+	_, err := c.createComment(ctx, "AddReply", CommentCreateInput{IssueID: issueID, Body: body, ParentID: parentID})
+	return err
+}
+
+// CreateComment adds a comment and returns it, for comments that are
+// referred to later, such as the parent of a thread.
+func (c *LinearClient) CreateComment(ctx context.Context, input CommentCreateInput) (*Comment, error) {
+	return c.createComment(ctx, "CreateComment", input)
+}
+
+// createComment runs a commentCreate mutation under the given operation
+// name.
+func (c *LinearClient) createComment(ctx context.Context, operation string, input CommentCreateInput) (*Comment, error) {
+	query := fmt.Sprintf(`mutation %s($input: CommentCreateInput!) {
+		commentCreate(input: $input) {
+			success
+			comment {
+				id
+				body
+				createdAt
+			}
+		}
+	}`, operation)
+
+	resp, err := c.execute(ctx, query, map[string]any{"input": input})
+	if err != nil {
+		return nil, err
 	}
 
 	var result struct {
 		CommentCreate struct {
-			Success bool `json:"success"`
+			Success bool    `json:"success"`
+			Comment Comment `json:"comment"`
 		} `json:"commentCreate"`
 	}
 	if err := json.Unmarshal(resp.Data, &result); err != nil {
-		return fmt.Errorf("failed to parse comment response: %w", err)
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
 	}
 
 	if !result.CommentCreate.Success {
-		return fmt.Errorf("failed to add comment")
+		return nil, fmt.Errorf("failed to add comment")
+	}
+
+	return &result.CommentCreate.Comment, nil
+}
+
+// SubscribeToIssue subscribes a user to an issue's notifications.
+func (c *LinearClient) SubscribeToIssue(ctx context.Context, issueID, userID string) error {
+	query := `mutation SubscribeToIssue($id: String!, $userId: String) {
+		issueSubscribe(id: $id, userId: $userId) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"id":     issueID,
+		"userId": userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueSubscribe struct {
+			Success bool `json:"success"`
+		} `json:"issueSubscribe"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse subscribe response: %w", err)
+	}
+
+	if !result.IssueSubscribe.Success {
+		return fmt.Errorf("failed to subscribe to issue")
+	}
+
+	return nil
+}
+
+// UpdateComment replaces the body of an existing comment.
+func (c *LinearClient) UpdateComment(ctx context.Context, commentID, body string) error {
+	query := `mutation UpdateComment($id: String!, $input: CommentUpdateInput!) {
+		commentUpdate(id: $id, input: $input) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"id":    commentID,
+		"input": CommentUpdateInput{Body: body},
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		CommentUpdate struct {
+			Success bool `json:"success"`
+		} `json:"commentUpdate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	if !result.CommentUpdate.Success {
+		return fmt.Errorf("failed to update comment")
+	}
+
+	return nil
+}
+
+// CreateAttachment attaches an external link to an issue. Linear deduplicates
+// attachments by URL per issue, so repeating the call updates the existing one.
+func (c *LinearClient) CreateAttachment(ctx context.Context, input AttachmentInput) error {
+	query := `mutation CreateAttachment($input: AttachmentCreateInput!) {
+		attachmentCreate(input: $input) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"input": input})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		AttachmentCreate struct {
+			Success bool `json:"success"`
+		} `json:"attachmentCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse attachment response: %w", err)
+	}
+
+	if !result.AttachmentCreate.Success {
+		return fmt.Errorf("failed to create attachment")
+	}
+
+	return nil
+}
+
+// Comment represents a comment on an issue.
+type Comment struct {
+	ID        string `json:"id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// GetIssueComments returns the most recent comments on an issue.
+func (c *LinearClient) GetIssueComments(ctx context.Context, issueID string) ([]Comment, error) {
+	query := `query GetIssueComments($id: String!) {
+		issue(id: $id) {
+			comments(first: 100) {
+				nodes {
+					id
+					body
+					createdAt
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": issueID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issue struct {
+			Comments struct {
+				Nodes []Comment `json:"nodes"`
+			} `json:"comments"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comments: %w", err)
+	}
+
+	return result.Issue.Comments.Nodes, nil
+}
+
+// FindIssuesByDescription returns a team's issues whose description contains
+// the given text.
+func (c *LinearClient) FindIssuesByDescription(ctx context.Context, teamID, text string) ([]Issue, error) {
+	query := `query FindIssuesByDescription($teamId: ID!, $text: String!) {
+		issues(filter: { team: { id: { eq: $teamId } }, description: { contains: $text } }, first: 10) {
+			nodes {
+				id
+				identifier
+				title
+				description
+				url
+				state {
+					id
+					name
+					type
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"teamId": teamID, "text": text})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issues struct {
+			Nodes []Issue `json:"nodes"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issues: %w", err)
+	}
+
+	return result.Issues.Nodes, nil
+}
+
+// SearchIssuesByDescription returns issues across all teams whose
+// description contains the given text, including their labels.
+func (c *LinearClient) SearchIssuesByDescription(ctx context.Context, text string) ([]Issue, error) {
+	query := `query SearchIssuesByDescription($text: String!) {
+		issues(filter: { description: { contains: $text } }, first: 25) {
+			nodes {
+				id
+				identifier
+				title
+				url
+				labels {
+					nodes {
+						id
+						name
+					}
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"text": text})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issues struct {
+			Nodes []Issue `json:"nodes"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issues: %w", err)
+	}
+
+	return result.Issues.Nodes, nil
+}
+
+// CreateIssueRelation relates two issues, e.g. with relationType "blocks"
+// meaning issueID blocks relatedIssueID.
+func (c *LinearClient) CreateIssueRelation(ctx context.Context, issueID, relatedIssueID, relationType string) error {
+	query := `mutation CreateIssueRelation($input: IssueRelationCreateInput!) {
+		issueRelationCreate(input: $input) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"input": IssueRelationCreateInput{IssueID: issueID, RelatedIssueID: relatedIssueID, Type: relationType},
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueRelationCreate struct {
+			Success bool `json:"success"`
+		} `json:"issueRelationCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse relation response: %w", err)
+	}
+
+	if !result.IssueRelationCreate.Success {
+		return fmt.Errorf("failed to create issue relation")
+	}
+
+	return nil
+}
+
+// ProjectUpdate represents a project status update.
+type ProjectUpdate struct {
+	ID        string `json:"id"`
+	Body      string `json:"body"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// Document represents a Linear document.
+type Document struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// ListIssuesCreatedSince returns a team's issues created after since whose
+// description contains the given text, oldest first.
+func (c *LinearClient) ListIssuesCreatedSince(ctx context.Context, teamID, text string, since time.Time) ([]Issue, error) {
+	query := `query ListIssuesCreatedSince($teamId: ID!, $text: String!, $since: DateTimeOrDuration!) {
+		issues(filter: { team: { id: { eq: $teamId } }, description: { contains: $text }, createdAt: { gt: $since } }, first: 250, orderBy: createdAt) {
+			nodes {
+				id
+				identifier
+				title
+				url
+				createdAt
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"teamId": teamID,
+		"text":   text,
+		"since":  since.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issues struct {
+			Nodes []Issue `json:"nodes"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issues: %w", err)
+	}
+
+	issues := result.Issues.Nodes
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].CreatedAt < issues[j].CreatedAt })
+	return issues, nil
+}
+
+// GetProject returns a project by ID.
+func (c *LinearClient) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	query := `query GetProject($id: String!) {
+		project(id: $id) {
+			id
+			name
+			url
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Project *Project `json:"project"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+	if result.Project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+
+	return result.Project, nil
+}
+
+// GetProjectUpdates returns the most recent status updates of a project.
+func (c *LinearClient) GetProjectUpdates(ctx context.Context, projectID string) ([]ProjectUpdate, error) {
+	query := `query GetProjectUpdates($id: String!) {
+		project(id: $id) {
+			projectUpdates(first: 50) {
+				nodes {
+					id
+					body
+					url
+					createdAt
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Project struct {
+			ProjectUpdates struct {
+				Nodes []ProjectUpdate `json:"nodes"`
+			} `json:"projectUpdates"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project updates: %w", err)
+	}
+
+	return result.Project.ProjectUpdates.Nodes, nil
+}
+
+// GetProjectIssueStates returns the state types of all of a project's
+// issues, page by page.
+func (c *LinearClient) GetProjectIssueStates(ctx context.Context, projectID string) ([]string, error) {
+	query := `query GetProjectIssueStates($id: String!, $first: Int!, $after: String) {
+		project(id: $id) {
+			issues(first: $first, after: $after) {
+				nodes {
+					state {
+						type
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`
+
+	var types []string
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"id": projectID, "first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
+
+		var result struct {
+			Project struct {
+				Issues struct {
+					Nodes []struct {
+						State State `json:"state"`
+					} `json:"nodes"`
+					PageInfo pageInfo `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"project"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse project issues: %w", err)
+		}
+		for _, n := range result.Project.Issues.Nodes {
+			types = append(types, n.State.Type)
+		}
+		return result.Project.Issues.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// GetProjectDocuments returns the documents of a project.
+func (c *LinearClient) GetProjectDocuments(ctx context.Context, projectID string) ([]Document, error) {
+	query := `query GetProjectDocuments($id: String!) {
+		project(id: $id) {
+			documents(first: 50) {
+				nodes {
+					id
+					title
+					content
+					url
+					createdAt
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Project struct {
+			Documents struct {
+				Nodes []Document `json:"nodes"`
+			} `json:"documents"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project documents: %w", err)
+	}
+
+	return result.Project.Documents.Nodes, nil
+}
+
+// GetCustomViewIssues returns all issues matching a custom view's filters,
+// page by page.
+func (c *LinearClient) GetCustomViewIssues(ctx context.Context, viewID string) ([]Issue, error) {
+	query := `query GetCustomViewIssues($id: String!, $first: Int!, $after: String) {
+		customView(id: $id) {
+			issues(first: $first, after: $after) {
+				nodes {
+					id
+					identifier
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`
+
+	var issues []Issue
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"id": viewID, "first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
+
+		var result struct {
+			CustomView struct {
+				Issues struct {
+					Nodes    []Issue  `json:"nodes"`
+					PageInfo pageInfo `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"customView"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse custom view issues: %w", err)
+		}
+		issues = append(issues, result.CustomView.Issues.Nodes...)
+		return result.CustomView.Issues.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// Cycle represents a team's cycle.
+type Cycle struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Name   string `json:"name,omitempty"`
+}
+
+// ProjectMilestone represents a milestone of a project.
+type ProjectMilestone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FindIssueLabel returns the label with the given name, ignoring case, that
+// is available to a team: a label of the team itself or a workspace label.
+// It returns nil if there is none.
+func (c *LinearClient) FindIssueLabel(ctx context.Context, teamID, name string) (*Label, error) {
+	query := `query FindIssueLabel($name: String!, $first: Int!, $after: String) {
+		issueLabels(filter: { name: { eqIgnoreCase: $name } }, first: $first, after: $after) {
+			nodes {
+				id
+				name
+				isGroup
+				team {
+					id
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`
+
+	// Prefer the team's own label over a workspace label of the same name,
+	// which may be on any page
+	var own, workspace *Label
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"name": name, "first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
+
+		var result struct {
+			IssueLabels struct {
+				Nodes    []Label  `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"issueLabels"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse labels: %w", err)
+		}
+		for i, l := range result.IssueLabels.Nodes {
+			switch {
+			case l.Team != nil && l.Team.ID == teamID:
+				own = &result.IssueLabels.Nodes[i]
+				return pageInfo{}, nil
+			case l.Team == nil && workspace == nil:
+				workspace = &result.IssueLabels.Nodes[i]
+			}
+		}
+		return result.IssueLabels.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if own != nil {
+		return own, nil
+	}
+	return workspace, nil
+}
+
+// FindUserByEmail returns the active user with the given email address, or
+// nil if there is none.
+func (c *LinearClient) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	query := `query FindUserByEmail($email: String!) {
+		users(filter: { email: { eqIgnoreCase: $email }, active: { eq: true } }, first: 1) {
+			nodes {
+				id
+				name
+				email
+				url
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"email": email})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Users struct {
+			Nodes []User `json:"nodes"`
+		} `json:"users"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse users: %w", err)
+	}
+	if len(result.Users.Nodes) == 0 {
+		return nil, nil
+	}
+	return &result.Users.Nodes[0], nil
+}
+
+// CreateIssueLabel creates a team label or label group.
+func (c *LinearClient) CreateIssueLabel(ctx context.Context, input LabelInput) (*Label, error) {
+	query := `mutation CreateIssueLabel($input: IssueLabelCreateInput!) {
+		issueLabelCreate(input: $input) {
+			success
+			issueLabel {
+				id
+				name
+				isGroup
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		IssueLabelCreate struct {
+			Success    bool  `json:"success"`
+			IssueLabel Label `json:"issueLabel"`
+		} `json:"issueLabelCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse label response: %w", err)
+	}
+
+	if !result.IssueLabelCreate.Success {
+		return nil, fmt.Errorf("failed to create label")
+	}
+
+	return &result.IssueLabelCreate.IssueLabel, nil
+}
+
+// ListTeamLabels returns the labels and label groups of a team, excluding
+// workspace labels.
+func (c *LinearClient) ListTeamLabels(ctx context.Context, teamID string) ([]Label, error) {
+	query := `query ListTeamLabels($teamId: ID!, $first: Int!, $after: String) {
+		issueLabels(filter: { team: { id: { eq: $teamId } } }, first: $first, after: $after) {
+			nodes {
+				id
+				name
+				isGroup
+				parent {
+					id
+					name
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`
+
+	var labels []Label
+	err := paginate("", func(after string) (pageInfo, error) {
+		resp, err := c.execute(ctx, query, map[string]any{"teamId": teamID, "first": pageSize, "after": cursor(after)})
+		if err != nil {
+			return pageInfo{}, err
+		}
+
+		var result struct {
+			IssueLabels struct {
+				Nodes    []Label  `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"issueLabels"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return pageInfo{}, fmt.Errorf("failed to parse labels: %w", err)
+		}
+		labels = append(labels, result.IssueLabels.Nodes...)
+		return result.IssueLabels.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// RenameIssueLabel changes the name of a label.
+func (c *LinearClient) RenameIssueLabel(ctx context.Context, labelID, name string) error {
+	query := `mutation RenameIssueLabel($id: String!, $input: IssueLabelUpdateInput!) {
+		issueLabelUpdate(id: $id, input: $input) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": labelID, "input": IssueLabelUpdateInput{Name: name}})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueLabelUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueLabelUpdate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse label response: %w", err)
+	}
+
+	if !result.IssueLabelUpdate.Success {
+		return fmt.Errorf("failed to rename label")
+	}
+
+	return nil
+}
+
+// DeleteIssueLabel deletes a label, removing it from the issues that have
+// it.
+func (c *LinearClient) DeleteIssueLabel(ctx context.Context, labelID string) error {
+	query := `mutation DeleteIssueLabel($id: String!) {
+		issueLabelDelete(id: $id) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": labelID})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueLabelDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueLabelDelete"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse delete response: %w", err)
+	}
+
+	if !result.IssueLabelDelete.Success {
+		return fmt.Errorf("failed to delete label")
+	}
+
+	return nil
+}
+
+// UpdateIssueLabels replaces the labels of an issue.
+func (c *LinearClient) UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error {
+	// An empty list is sent to remove all labels
+	if labelIDs == nil {
+		labelIDs = []string{}
+	}
+	return c.updateIssue(ctx, "UpdateIssueLabels", issueID, IssueUpdateInput{LabelIDs: labelIDs}, "labels")
+}
+
+// GetProjectMilestones returns the milestones of a project.
+func (c *LinearClient) GetProjectMilestones(ctx context.Context, projectID string) ([]ProjectMilestone, error) {
+	query := `query GetProjectMilestones($id: String!) {
+		project(id: $id) {
+			projectMilestones(first: 100) {
+				nodes {
+					id
+					name
+				}
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Project struct {
+			ProjectMilestones struct {
+				Nodes []ProjectMilestone `json:"nodes"`
+			} `json:"projectMilestones"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project milestones: %w", err)
+	}
+
+	return result.Project.ProjectMilestones.Nodes, nil
+}
+
+// CreateProjectMilestone creates a milestone in a project.
+func (c *LinearClient) CreateProjectMilestone(ctx context.Context, projectID, name string) (*ProjectMilestone, error) {
+	query := `mutation CreateProjectMilestone($input: ProjectMilestoneCreateInput!) {
+		projectMilestoneCreate(input: $input) {
+			success
+			projectMilestone {
+				id
+				name
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"input": ProjectMilestoneCreateInput{ProjectID: projectID, Name: name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ProjectMilestoneCreate struct {
+			Success          bool             `json:"success"`
+			ProjectMilestone ProjectMilestone `json:"projectMilestone"`
+		} `json:"projectMilestoneCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse milestone response: %w", err)
+	}
+
+	if !result.ProjectMilestoneCreate.Success {
+		return nil, fmt.Errorf("failed to create project milestone")
+	}
+
+	return &result.ProjectMilestoneCreate.ProjectMilestone, nil
+}
+
+// UpdateIssueMilestone moves an issue into a project and its milestone.
+// Linear only accepts milestones of the issue's project, so both are set.
+func (c *LinearClient) UpdateIssueMilestone(ctx context.Context, issueID, projectID, milestoneID string) error {
+	return c.updateIssue(ctx, "UpdateIssueMilestone", issueID, IssueUpdateInput{ProjectID: Set(projectID), ProjectMilestoneID: Set(milestoneID)}, "milestone")
+}
+
+// GetActiveCycle returns the team's active cycle, or nil if the team has
+// none, for instance because cycles are turned off.
+func (c *LinearClient) GetActiveCycle(ctx context.Context, teamID string) (*Cycle, error) {
+	query := `query GetActiveCycle($id: String!) {
+		team(id: $id) {
+			activeCycle {
+				id
+				number
+				name
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Team struct {
+			ActiveCycle *Cycle `json:"activeCycle"`
+		} `json:"team"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse active cycle: %w", err)
+	}
+
+	return result.Team.ActiveCycle, nil
+}
+
+// IssueTemplate is an issue template and the defaults it applies.
+type IssueTemplate struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Priority is the priority the template sets, if any.
+	Priority *int `json:"priority,omitempty"`
+}
+
+// GetTeamIssueTemplate returns the team's default issue template for
+// members, or the one for non-members, or nil if the team has neither.
+func (c *LinearClient) GetTeamIssueTemplate(ctx context.Context, teamID string) (*IssueTemplate, error) {
+	query := `query GetTeamIssueTemplate($id: String!) {
+		team(id: $id) {
+			defaultTemplateForMembers {
+				id
+				name
+				templateData
+			}
+			defaultTemplateForNonMembers {
+				id
+				name
+				templateData
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	type template struct {
+		ID           string          `json:"id"`
+		Name         string          `json:"name"`
+		TemplateData json.RawMessage `json:"templateData"`
+	}
+	var result struct {
+		Team struct {
+			Members    *template `json:"defaultTemplateForMembers"`
+			NonMembers *template `json:"defaultTemplateForNonMembers"`
+		} `json:"team"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse team templates: %w", err)
+	}
+
+	t := result.Team.Members
+	if t == nil {
+		t = result.Team.NonMembers
+	}
+	if t == nil {
+		return nil, nil
+	}
+
+	// templateData is a JSON object, which may arrive encoded as a string
+	data := t.TemplateData
+	var encoded string
+	if json.Unmarshal(data, &encoded) == nil {
+		data = json.RawMessage(encoded)
+	}
+	var fields struct {
+		Priority *int `json:"priority"`
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", t.Name, err)
+		}
+	}
+	return &IssueTemplate{ID: t.ID, Name: t.Name, Priority: fields.Priority}, nil
+}
+
+// UpdateIssueCycle moves an issue into a cycle.
+func (c *LinearClient) UpdateIssueCycle(ctx context.Context, issueID, cycleID string) error {
+	return c.updateIssue(ctx, "UpdateIssueCycle", issueID, IssueUpdateInput{CycleID: Set(cycleID)}, "cycle")
+}
+
+// GetDocument returns a document by ID.
+func (c *LinearClient) GetDocument(ctx context.Context, id string) (*Document, error) {
+	query := `query GetDocument($id: String!) {
+		document(id: $id) {
+			id
+			title
+			content
+			url
+			createdAt
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Document *Document `json:"document"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	if result.Document == nil {
+		return nil, fmt.Errorf("document %s not found", id)
+	}
+
+	return result.Document, nil
+}
+
+// CreateProjectUpdate posts a status update to a project.
+func (c *LinearClient) CreateProjectUpdate(ctx context.Context, projectID, body string) (*ProjectUpdate, error) {
+	query := `mutation CreateProjectUpdate($input: ProjectUpdateCreateInput!) {
+		projectUpdateCreate(input: $input) {
+			success
+			projectUpdate {
+				id
+				body
+				url
+				createdAt
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"input": ProjectUpdateCreateInput{ProjectID: projectID, Body: body},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ProjectUpdateCreate struct {
+			Success       bool          `json:"success"`
+			ProjectUpdate ProjectUpdate `json:"projectUpdate"`
+		} `json:"projectUpdateCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project update response: %w", err)
+	}
+
+	if !result.ProjectUpdateCreate.Success {
+		return nil, fmt.Errorf("failed to create project update")
+	}
+
+	return &result.ProjectUpdateCreate.ProjectUpdate, nil
+}
+
+// CreateDocument creates a document in a project.
+func (c *LinearClient) CreateDocument(ctx context.Context, projectID, title, content string) (*Document, error) {
+	query := `mutation CreateDocument($input: DocumentCreateInput!) {
+		documentCreate(input: $input) {
+			success
+			document {
+				id
+				title
+				content
+				url
+				createdAt
+			}
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{
+		"input": DocumentCreateInput{ProjectID: projectID, Title: title, Content: content},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		DocumentCreate struct {
+			Success  bool     `json:"success"`
+			Document Document `json:"document"`
+		} `json:"documentCreate"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse document response: %w", err)
+	}
+
+	if !result.DocumentCreate.Success {
+		return nil, fmt.Errorf("failed to create document")
+	}
+
+	return &result.DocumentCreate.Document, nil
+}
+
+// UnarchiveIssue restores an archived issue.
+func (c *LinearClient) UnarchiveIssue(ctx context.Context, issueID string) error {
+	query := `mutation UnarchiveIssue($id: String!) {
+		issueUnarchive(id: $id) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": issueID})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"issueUnarchive"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse unarchive response: %w", err)
+	}
+
+	if !result.IssueUnarchive.Success {
+		return fmt.Errorf("failed to unarchive issue")
+	}
+
+	return nil
+}
+
+// DeleteIssue moves an issue to the trash.
+func (c *LinearClient) DeleteIssue(ctx context.Context, issueID string) error {
+	query := `mutation DeleteIssue($id: String!) {
+		issueDelete(id: $id) {
+			success
+		}
+	}`
+
+	resp, err := c.execute(ctx, query, map[string]any{"id": issueID})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		IssueDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueDelete"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse delete response: %w", err)
+	}
+
+	if !result.IssueDelete.Success {
+		return fmt.Errorf("failed to delete issue")
 	}
 
 	return nil
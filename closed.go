@@ -0,0 +1,22 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// closedStateTypes are the workflow state types of finished issues.
+var closedStateTypes = []string{"completed", "canceled"}
+
+// keepsClosedState reports whether an issue stays in its state because it
+// is already completed or canceled, so re-releases and cherry-picks do not
+// re-transition finished issues. transition_closed_issues turns the check
+// off, and issues in prerelease_state still move on when the stable
+// version ships.
+func (c *Config) keepsClosedState(issue *Issue) bool {
+	if c.TransitionClosedIssues || !slices.Contains(closedStateTypes, issue.State.Type) {
+		return false
+	}
+	return c.PrereleaseState == "" || !strings.EqualFold(issue.State.Name, c.PrereleaseState) ||
+		strings.EqualFold(c.ReleasedState, c.PrereleaseState)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestKeepsClosedState(t *testing.T) {
+	issue := func(name, typ string) *Issue { return &Issue{State: State{Name: name, Type: typ}} }
+	p := &LinearPlugin{}
+
+	cfg := p.parseConfig(map[string]any{"prerelease_state": "Released (beta)"})
+	tests := []struct {
+		issue *Issue
+		want  bool
+	}{
+		{issue("Done", "completed"), true},
+		{issue("Canceled", "canceled"), true},
+		{issue("In Review", "started"), false},
+		{issue("Backlog", ""), false},
+		// Issues a prerelease shipped move on with the stable release
+		{issue("Released (beta)", "completed"), false},
+	}
+	for _, tt := range tests {
+		if got := cfg.keepsClosedState(tt.issue); got != tt.want {
+			t.Errorf("keepsClosedState(%s) = %v, want %v", tt.issue.State.Name, got, tt.want)
+		}
+	}
+
+	// A prerelease leaves issues already in its state alone
+	applyPrereleaseState(cfg, plugin.ReleaseContext{Version: "1.0.0-rc.2"})
+	if !cfg.keepsClosedState(issue("Released (beta)", "completed")) {
+		t.Error("expected a prerelease to keep issues in the prerelease state")
+	}
+
+	cfg = p.parseConfig(map[string]any{"transition_closed_issues": true})
+	if cfg.keepsClosedState(issue("Canceled", "canceled")) {
+		t.Error("expected transition_closed_issues to transition closed issues")
+	}
+}
+
+func TestProcessLinkedIssuesSkipsClosedIssues(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-review", "name": "In Review", "type": "started"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-canceled", "name": "Canceled", "type": "canceled"}},
+			"ENG-3": {"id": "uuid-3", "identifier": "ENG-3", "state": map[string]any{"id": "state-done", "name": "Done", "type": "completed"}},
+		}),
+		"UpdateIssueStates": batchStateHandler(nil),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.1"}, team), []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if ids := fake.calls["UpdateIssueStates"][0]["ids"].([]any); len(ids) != 1 || ids[0] != "uuid-1" {
+		t.Errorf("expected only ENG-1 to transition, got %v", ids)
+	}
+	if len(res.Closed) != 2 || res.Closed["ENG-2"] != "Canceled" || res.Closed["ENG-3"] != "Done" {
+		t.Errorf("Closed = %v", res.Closed)
+	}
+
+	rc := newResultCollector()
+	res.report(rc, cfg)
+	if _, ok := rc.outputs["closed_issues"]; !ok {
+		t.Error("expected the closed issues in the outputs")
+	}
+}
+
+func TestPlanTransitionClosedIssue(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(nil)
+	tp := &teamPlan{state: "Released", stateID: "state-released"}
+
+	outcome, reason := planTransition(cfg, tp, &Issue{State: State{Name: "Canceled", Type: "canceled"}})
+	if outcome != outcomeUnchanged || reason != "already canceled in Canceled" {
+		t.Errorf("planTransition() = %q, %q", outcome, reason)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultMaxCommentLength keeps release comments well under the body size
+// Linear accepts for a comment.
+const defaultMaxCommentLength = 50000
+
+// Targets of the link appended to truncated release comments.
+const (
+	commentOverflowLink     = "link"
+	commentOverflowDocument = "document"
+)
+
+// isValidCommentOverflow reports whether mode is a supported comment_overflow.
+func isValidCommentOverflow(mode string) bool {
+	return mode == commentOverflowLink || mode == commentOverflowDocument
+}
+
+// truncatedCommentNotice ends a truncated release comment, with a link to
+// the full release notes when one is available.
+func truncatedCommentNotice(url string) string {
+	if url == "" {
+		return "\n\n… (truncated)"
+	}
+	return fmt.Sprintf("\n\n… (truncated, see the [full release notes](%s))", url)
+}
+
+// truncateComment shortens comment to at most limit characters, cutting at
+// the last line break that leaves room for the notice linking to url.
+func truncateComment(comment string, limit int, url string) string {
+	notice := truncatedCommentNotice(url)
+	room := limit - utf8.RuneCountInString(notice)
+	if room <= 0 {
+		return string([]rune(notice)[:max(limit, 0)])
+	}
+
+	kept := string([]rune(comment)[:room])
+	if i := strings.LastIndex(kept, "\n"); i > 0 {
+		kept = kept[:i]
+	}
+	return strings.TrimRight(kept, " \t\n") + notice
+}
+
+// fitComment returns the rendered release comment unchanged when it fits
+// max_comment_length once reserve characters (the release marker) are
+// added, and otherwise truncates it with a link to the full release notes
+// instead of letting the mutation fail for every issue.
+func fitComment(ctx context.Context, run *releaseRun, comment string, reserve int, res *linkedIssueResults) string {
+	cfg := run.cfg
+	limit := cfg.MaxCommentLength - reserve
+	if cfg.MaxCommentLength <= 0 || utf8.RuneCountInString(comment) <= limit {
+		return comment
+	}
+
+	url, err := fullNotesURL(ctx, run, comment)
+	if err != nil {
+		res.warn(err, "Full release notes link skipped")
+	}
+	res.CommentTruncated = true
+	res.CommentLinkout = url
+	return truncateComment(comment, limit, url)
+}
+
+// fullNotesURL locates the full release notes for a truncated comment: a
+// Linear document holding the rendered comment with comment_overflow
+// "document", otherwise (or when the document cannot be created) the
+// release page.
+func fullNotesURL(ctx context.Context, run *releaseRun, comment string) (string, error) {
+	cfg := run.cfg
+	if cfg.CommentOverflow == commentOverflowDocument {
+		title := cfg.Naming.title(fmt.Sprintf("Release notes %s", releaseLabel(run.release)))
+		doc, err := run.client.CreateDocument(ctx, cfg.ProjectID, title, comment)
+		if err == nil {
+			return doc.URL, nil
+		}
+		url, _ := releasePageURL(cfg, run)
+		return url, err
+	}
+	return releasePageURL(cfg, run)
+}
+
+// releasePageURL returns the first release link: release_link_url, or the
+// release page derived from the repository.
+func releasePageURL(cfg *Config, run *releaseRun) (string, error) {
+	links, err := releaseLinks(cfg, run.release)
+	if err != nil || len(links) == 0 {
+		return "", err
+	}
+	return links[0].URL, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestTruncateComment(t *testing.T) {
+	comment := "Released in 1.0.0\n\n- ENG-1 first change\n- ENG-2 second change\n- ENG-3 third change"
+	url := "https://github.com/acme/app/releases/tag/v1.0.0"
+	limit := 140
+
+	got := truncateComment(comment, limit, url)
+	if n := utf8.RuneCountInString(got); n > limit {
+		t.Fatalf("truncated comment has %d characters, want at most %d", n, limit)
+	}
+	if !strings.HasSuffix(got, "[full release notes]("+url+"))") {
+		t.Errorf("expected a link to the full notes, got %q", got)
+	}
+	if !strings.HasPrefix(got, "Released in 1.0.0\n\n- ENG-1 first change\n\n…") {
+		t.Errorf("expected a cut at a line break, got %q", got)
+	}
+
+	if got := truncateComment(comment, 10, ""); utf8.RuneCountInString(got) > 10 {
+		t.Errorf("expected at most 10 characters, got %q", got)
+	}
+}
+
+func TestProcessLinkedIssuesTruncatesLongComment(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":     false,
+		"skip_previously_released": true,
+		"max_comment_length":       200,
+		"comment_template":         strings.Repeat("A long line of release notes\n", 20),
+	})
+	rel := plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0", RepositoryURL: "https://github.com/acme/app"}
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, rel, &Team{}), []string{"ENG-1"})
+
+	if res.Commented != 1 || !res.CommentTruncated {
+		t.Fatalf("expected one truncated comment, got %+v", res)
+	}
+	if want := "https://github.com/acme/app/releases/tag/v1.0.0"; res.CommentLinkout != want {
+		t.Errorf("CommentLinkout = %q, want %q", res.CommentLinkout, want)
+	}
+	body := fake.calls["AddComment"][0]["input"].(map[string]any)["body"].(string)
+	if n := utf8.RuneCountInString(body); n > 200 {
+		t.Errorf("comment has %d characters, want at most 200", n)
+	}
+	if got := findMarkers(body, cfg.Naming.marker(markerReleased)); len(got) != 1 || got[0] != "1.0.0" {
+		t.Errorf("expected the release marker to survive truncation, got %v in %q", got, body)
+	}
+}
+
+func TestProcessLinkedIssuesLinksOverflowDocument(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+		"CreateDocument": func(map[string]any) any {
+			return map[string]any{"documentCreate": map[string]any{
+				"success":  true,
+				"document": map[string]any{"id": "doc-1", "url": "https://linear.app/acme/document/notes-1"},
+			}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"project_id":           "project-1",
+		"max_comment_length":   100,
+		"comment_overflow":     "document",
+		"comment_template":     strings.Repeat("Notes ", 50),
+	})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1"})
+
+	if res.CommentLinkout != "https://linear.app/acme/document/notes-1" {
+		t.Errorf("CommentLinkout = %q", res.CommentLinkout)
+	}
+	doc := fake.calls["CreateDocument"][0]["input"].(map[string]any)
+	if doc["projectId"] != "project-1" || doc["content"] != strings.Repeat("Notes ", 50) {
+		t.Errorf("unexpected document input %v", doc)
+	}
+	body := fake.calls["AddComment"][0]["input"].(map[string]any)["body"].(string)
+	if !strings.Contains(body, "(https://linear.app/acme/document/notes-1)") {
+		t.Errorf("expected the comment to link the document, got %q", body)
+	}
+}
+
+func TestValidateCommentOverflow(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"api_key":          "lin_api_test",
+		"team_id":          "team-1",
+		"comment_overflow": "document",
+	})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, e := range resp.Errors {
+		if e.Field == "comment_overflow" {
+			return
+		}
+	}
+	t.Errorf("expected a comment_overflow error without project_id, got %v", resp.Errors)
+}
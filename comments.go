@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// findReleaseComment returns the plugin's earlier comment for the version
+// on the issue, identified by its released marker, or nil if there is none.
+func findReleaseComment(ctx context.Context, client *LinearClient, issueID, kind, version string) (*Comment, error) {
+	comments, err := client.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		for _, v := range findMarkers(comments[i].Body, kind) {
+			if v == version {
+				return &comments[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// postReleaseComment adds the release comment to an issue or, with
+// edit_release_comments, edits the comment an earlier run posted for the
+// same version. Unchanged comments are left alone. With
+// thread_release_comments, new comments are replies in the release thread.
+func postReleaseComment(ctx context.Context, client *LinearClient, cfg *Config, plan *linkedIssuePlan, issue *Issue, issueID, comment string, res *linkedIssueResults) bool {
+	if cfg.EditReleaseComments {
+		prior, err := findReleaseComment(ctx, client, issue.ID, cfg.Naming.marker(markerReleased), plan.version)
+		if err != nil {
+			res.warn(err, "Failed to look up the release comment on %s", issueID)
+			return false
+		}
+		if prior != nil {
+			if strings.TrimSpace(prior.Body) == strings.TrimSpace(comment) {
+				return true
+			}
+			if err := client.UpdateComment(ctx, prior.ID, comment); err != nil {
+				res.warn(err, "Failed to update comment on %s", issueID)
+				return false
+			}
+			res.EditedComments = append(res.EditedComments, issueID)
+			return true
+		}
+	}
+
+	if cfg.ThreadReleaseComments {
+		parentID, err := releaseThread(ctx, client, cfg, issue.ID)
+		if err != nil {
+			res.warn(err, "Failed to open the release thread on %s", issueID)
+			return false
+		}
+		if err := client.AddReply(ctx, issue.ID, parentID, comment); err != nil {
+			res.warn(err, "Failed to add comment to %s", issueID)
+			return false
+		}
+		res.Commented++
+		return true
+	}
+
+	if err := client.AddComment(ctx, issue.ID, comment); err != nil {
+		res.warn(err, "Failed to add comment to %s", issueID)
+		return false
+	}
+	res.Commented++
+	return true
+}
+
+// releaseThreadValue is the value of the marker on release threads.
+const releaseThreadValue = "releases"
+
+// releaseThread returns the ID of the issue's release thread, the comment
+// release comments reply to with thread_release_comments. The thread is
+// created on the first release and found again by its marker.
+func releaseThread(ctx context.Context, client *LinearClient, cfg *Config, issueID string) (string, error) {
+	kind := cfg.Naming.marker(markerThread)
+	comments, err := client.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range comments {
+		if slices.Contains(findMarkers(c.Body, kind), releaseThreadValue) {
+			return c.ID, nil
+		}
+	}
+
+	thread, err := client.CreateComment(ctx, CommentCreateInput{
+		IssueID: issueID,
+		Body:    appendMarker("**"+cfg.ReleaseThreadTitle+"**", kind, releaseThreadValue),
+	})
+	if err != nil {
+		return "", err
+	}
+	return thread.ID, nil
+}
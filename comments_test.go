@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestEditReleaseCommentsInPlace(t *testing.T) {
+	var mu sync.Mutex
+	var updated []string
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+			"ENG-3": {"id": "uuid-3", "identifier": "ENG-3"},
+		}),
+		"GetIssueComments": func(vars map[string]any) any {
+			bodies := map[string][]any{
+				"uuid-1": {map[string]any{"id": "c-1", "body": "Released in 1.0.0 (old notes)\n\n`relicta:released=1.0.0`"}},
+				"uuid-2": {map[string]any{"id": "c-2", "body": "Released in 1.0.0\n\n`relicta:released=1.0.0`"}},
+				"uuid-3": {map[string]any{"id": "c-3", "body": "Released in 0.9.0\n\n`relicta:released=0.9.0`"}},
+			}
+			return map[string]any{"issue": map[string]any{"comments": map[string]any{"nodes": bodies[vars["id"].(string)]}}}
+		},
+		"UpdateComment": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			updated = append(updated, vars["id"].(string))
+			return map[string]any{"commentUpdate": map[string]any{"success": true}}
+		},
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":  false,
+		"edit_release_comments": true,
+	})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if len(updated) != 1 || updated[0] != "c-1" {
+		t.Errorf("updated = %v, want only the outdated comment edited", updated)
+	}
+	if len(res.EditedComments) != 1 || res.EditedComments[0] != "ENG-1" {
+		t.Errorf("EditedComments = %v", res.EditedComments)
+	}
+	if res.Commented != 1 || fake.callCount("AddComment") != 1 {
+		t.Errorf("expected a new comment only on ENG-3, got %d", fake.callCount("AddComment"))
+	}
+	if len(res.Errors) != 0 {
+		t.Errorf("unexpected errors %v", res.Errors)
+	}
+}
+
+func TestThreadReleaseComments(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"GetIssueComments": func(vars map[string]any) any {
+			// ENG-1 already has a release thread from an earlier release
+			bodies := map[string][]any{
+				"uuid-1": {
+					map[string]any{"id": "c-0", "body": "Looks good"},
+					map[string]any{"id": "thread-1", "body": "**Release history**\n\n`relicta:thread=releases`"},
+				},
+			}
+			return map[string]any{"issue": map[string]any{"comments": map[string]any{"nodes": bodies[vars["id"].(string)]}}}
+		},
+		"CreateComment": func(vars map[string]any) any {
+			return map[string]any{"commentCreate": map[string]any{"success": true, "comment": map[string]any{"id": "thread-2"}}}
+		},
+		"AddReply": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":    false,
+		"thread_release_comments": true,
+	})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.1.0"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+	if len(res.Errors) != 0 || res.Commented != 2 {
+		t.Fatalf("Commented = %d, errors = %v", res.Commented, res.Errors)
+	}
+	created := fake.calls["CreateComment"]
+	if len(created) != 1 {
+		t.Fatalf("expected one new thread, got %d", len(created))
+	}
+	thread := created[0]["input"].(map[string]any)
+	if thread["issueId"] != "uuid-2" || len(findMarkers(thread["body"].(string), markerThread)) != 1 {
+		t.Errorf("unexpected thread %v", thread)
+	}
+
+	parents := map[string]any{}
+	for _, call := range fake.calls["AddReply"] {
+		input := call["input"].(map[string]any)
+		parents[input["issueId"].(string)] = input["parentId"]
+	}
+	if parents["uuid-1"] != "thread-1" || parents["uuid-2"] != "thread-2" {
+		t.Errorf("replies went to %v", parents)
+	}
+}
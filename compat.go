@@ -0,0 +1,78 @@
+package main
+
+import (
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// featureRequirement ties a feature to the release context fields it needs.
+// The SDK does not tell plugins which host or protocol version invoked them,
+// and older hosts leave newer fields empty, so features are checked against
+// the fields the host actually sent.
+type featureRequirement struct {
+	Option    string
+	Enabled   func(*Config) bool
+	Disable   func(*Config)
+	Available func(plugin.ReleaseContext) bool
+	// Needs names the missing context data in warnings.
+	Needs string
+}
+
+// featureRequirements lists the features that depend on optional context.
+var featureRequirements = []featureRequirement{
+	{
+		// A configured release_link_url works without repository data
+		Option:    "add_release_links",
+		Enabled:   func(c *Config) bool { return c.AddReleaseLinks && c.ReleaseLinkURL == "" },
+		Disable:   func(c *Config) { c.AddReleaseLinks = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.RepositoryURL != "" && r.TagName != "" },
+		Needs:     "repository URL and tag name",
+	},
+	{
+		Option:    "link_upstream_releases",
+		Enabled:   func(c *Config) bool { return c.LinkUpstreamReleases },
+		Disable:   func(c *Config) { c.LinkUpstreamReleases = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.Environment != nil },
+		Needs:     "release environment",
+	},
+	{
+		Option:    "link_pull_requests",
+		Enabled:   func(c *Config) bool { return c.LinkPullRequests },
+		Disable:   func(c *Config) { c.LinkPullRequests = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.Environment != nil },
+		Needs:     "release environment",
+	},
+	{
+		Option:    "enrich_release_notes",
+		Enabled:   func(c *Config) bool { return c.EnrichReleaseNotes },
+		Disable:   func(c *Config) { c.EnrichReleaseNotes = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.ReleaseNotes != "" },
+		Needs:     "release notes",
+	},
+	{
+		Option:    "skip_previously_released",
+		Enabled:   func(c *Config) bool { return c.SkipPreviouslyReleased },
+		Disable:   func(c *Config) { c.SkipPreviouslyReleased = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.Version != "" },
+		Needs:     "release version",
+	},
+	{
+		Option:    "promotion_comments",
+		Enabled:   func(c *Config) bool { return c.PromotionComments },
+		Disable:   func(c *Config) { c.PromotionComments = false },
+		Available: func(r plugin.ReleaseContext) bool { return r.Version != "" },
+		Needs:     "release version",
+	},
+}
+
+// disableMissingContextFeatures disables enabled features whose context
+// fields the host left empty, returning the missing data keyed by option.
+func disableMissingContextFeatures(cfg *Config, releaseCtx plugin.ReleaseContext) map[string]string {
+	disabled := make(map[string]string)
+	for _, req := range featureRequirements {
+		if req.Enabled(cfg) && !req.Available(releaseCtx) {
+			req.Disable(cfg)
+			disabled[req.Option] = req.Needs
+		}
+	}
+	return disabled
+}
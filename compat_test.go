@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestDisableMissingContextFeatures(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"add_release_links":      true,
+		"link_upstream_releases": true,
+		"enrich_release_notes":   true,
+	})
+
+	// An older host sending only the version and notes
+	disabled := disableMissingContextFeatures(cfg, plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: "notes"})
+
+	if _, ok := disabled["add_release_links"]; !ok || cfg.AddReleaseLinks {
+		t.Errorf("expected release links disabled, got %v", disabled)
+	}
+	if _, ok := disabled["link_upstream_releases"]; !ok || cfg.LinkUpstreamReleases {
+		t.Errorf("expected upstream linking disabled, got %v", disabled)
+	}
+	if _, ok := disabled["enrich_release_notes"]; ok || !cfg.EnrichReleaseNotes {
+		t.Errorf("expected enrichment kept, got %v", disabled)
+	}
+}
+
+func TestExecuteReportsDisabledFeatures(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:   plugin.HookPostPublish,
+		DryRun: true,
+		Config: map[string]any{
+			"api_key":              "lin_api_test",
+			"team_key":             "ENG",
+			"add_release_links":    true,
+			"update_linked_issues": false,
+			"add_release_comment":  false,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disabled, _ := resp.Outputs["disabled_features"].(map[string]string)
+	if disabled["add_release_links"] == "" {
+		t.Errorf("expected add_release_links in disabled_features, got %v", resp.Outputs)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"maps"
+	"sync"
+)
+
+const (
+	// defaultConcurrency is how many linked issues are processed at once.
+	defaultConcurrency = 4
+
+	// maxConcurrency bounds concurrency, since every worker spends the
+	// same hourly rate limit budget.
+	maxConcurrency = 16
+)
+
+// issueClaims assigns every issue to the first reference to it in issue
+// order, so an issue referenced under old and new identifiers is processed
+// once, and by the same reference whichever lookup finishes first.
+type issueClaims map[string]int
+
+// claimIssues assigns the looked up issues, indexed like the references,
+// to their first reference. Missing issues are skipped.
+func claimIssues(issues []*Issue) issueClaims {
+	claims := make(issueClaims)
+	for i, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		if _, ok := claims[issue.ID]; !ok {
+			claims[issue.ID] = i
+		}
+	}
+	return claims
+}
+
+// owns reports whether reference i handles the issue with the given ID.
+func (c issueClaims) owns(id string, i int) bool {
+	owner, ok := c[id]
+	return ok && owner == i
+}
+
+// linkedIssueWorkers returns how many linked issues to process at once.
+// Canary runs stay sequential so their mutation budget is spent on the
+// first issues, as in a serial run.
+func (c *Config) linkedIssueWorkers() int {
+	if c.Canary.enabled() {
+		return 1
+	}
+	return min(max(c.Concurrency, 1), maxConcurrency)
+}
+
+// forEachIssue calls fn with the index of every identifier on at most
+// workers goroutines and waits for all calls to return.
+func forEachIssue(issueIDs []string, workers int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(workers, 1), len(issueIDs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := range issueIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// merge adds the outcomes recorded for one issue to r.
+func (r *linkedIssueResults) merge(o *linkedIssueResults) {
+	r.Updated += o.Updated
+	r.Commented += o.Commented
+	r.Attached += o.Attached
+	r.Milestoned += o.Milestoned
+	r.Labelled += o.Labelled
+	r.Cycled += o.Cycled
+
+	maps.Copy(r.PreviouslyReleased, o.PreviouslyReleased)
+	maps.Copy(r.Promoted, o.Promoted)
+	maps.Copy(r.ReReleased, o.ReReleased)
+	maps.Copy(r.Renamed, o.Renamed)
+	maps.Copy(r.Closed, o.Closed)
+	for id, urls := range o.PullRequests {
+		r.PullRequests[id] = append(r.PullRequests[id], urls...)
+	}
+	for reason, ids := range o.Unavailable {
+		r.Unavailable[reason] = append(r.Unavailable[reason], ids...)
+	}
+
+	r.Unarchived = append(r.Unarchived, o.Unarchived...)
+	r.Excluded = append(r.Excluded, o.Excluded...)
+	r.Protected = append(r.Protected, o.Protected...)
+	r.Transitions = append(r.Transitions, o.Transitions...)
+	r.EditedComments = append(r.EditedComments, o.EditedComments...)
+	for _, issue := range o.Shipped {
+		r.Workload.add(issue)
+	}
+	r.Shipped = append(r.Shipped, o.Shipped...)
+	r.Errors = append(r.Errors, o.Errors...)
+}
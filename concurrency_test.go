@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestForEachIssueBoundsWorkers(t *testing.T) {
+	ids := make([]string, 20)
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	visited := make(map[int]int)
+
+	forEachIssue(ids, 4, func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+
+		mu.Lock()
+		visited[i]++
+		mu.Unlock()
+	})
+
+	if len(visited) != len(ids) {
+		t.Errorf("visited %d of %d issues", len(visited), len(ids))
+	}
+	for i, n := range visited {
+		if n != 1 {
+			t.Errorf("issue %d visited %d times", i, n)
+		}
+	}
+	if p := peak.Load(); p < 2 || p > 4 {
+		t.Errorf("expected between 2 and 4 issues in flight, got %d", p)
+	}
+}
+
+func TestLinkedIssueWorkers(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   int
+	}{
+		{"default", nil, defaultConcurrency},
+		{"configured", map[string]any{"concurrency": 8}, 8},
+		{"capped", map[string]any{"concurrency": 100}, maxConcurrency},
+		{"canary", map[string]any{"concurrency": 8, "canary": map[string]any{"max_mutations": 5}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LinearPlugin{}
+			if got := p.parseConfig(tt.config).linkedIssueWorkers(); got != tt.want {
+				t.Errorf("linkedIssueWorkers() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkedIssueResultsMerge(t *testing.T) {
+	res := newLinkedIssueResults()
+
+	first := newLinkedIssueResults()
+	first.Updated, first.Commented = 1, 1
+	first.Shipped = []*Issue{{Identifier: "ENG-1", Assignee: &User{ID: "user-1", Name: "Ada"}}}
+	first.Unavailable[issueArchived] = []string{"ENG-3"}
+
+	second := newLinkedIssueResults()
+	second.Updated = 1
+	second.Shipped = []*Issue{{Identifier: "ENG-2", Assignee: &User{ID: "user-1", Name: "Ada"}}}
+	second.Unavailable[issueArchived] = []string{"ENG-4"}
+	second.Errors = []string{"Failed to label ENG-2"}
+
+	res.merge(first)
+	res.merge(second)
+
+	if res.Updated != 2 || res.Commented != 1 || len(res.Shipped) != 2 || len(res.Errors) != 1 {
+		t.Errorf("unexpected merged counts %+v", res)
+	}
+	if got := res.Unavailable[issueArchived]; len(got) != 2 || got[0] != "ENG-3" {
+		t.Errorf("Unavailable = %v", got)
+	}
+	if w := res.Workload.summary(); len(w) != 1 || w[0].Count != 2 {
+		t.Errorf("Workload = %+v", w)
+	}
+}
+
+func TestClaimIssues(t *testing.T) {
+	moved := &Issue{ID: "uuid-9", Identifier: "OPS-9"}
+	claims := claimIssues([]*Issue{{ID: "uuid-1"}, nil, moved, moved})
+
+	if !claims.owns("uuid-1", 0) || !claims.owns("uuid-9", 2) {
+		t.Errorf("expected issues to be owned by their first reference, got %v", claims)
+	}
+	if claims.owns("uuid-9", 3) || claims.owns("uuid-2", 1) {
+		t.Errorf("unexpected owners %v", claims)
+	}
+}
+
+func TestProcessLinkedIssuesFirstReferenceOwnsIssue(t *testing.T) {
+	lookup := issueHandler(map[string]map[string]any{
+		"ENG-2":  {"id": "uuid-40", "identifier": "ENG-40", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+		"ENG-40": {"id": "uuid-40", "identifier": "ENG-40", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+	})
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		// The old identifier resolves last, after the batched lookup
+		"GetIssue": func(vars map[string]any) any {
+			if vars["id"] == "ENG-2" {
+				time.Sleep(20 * time.Millisecond)
+			}
+			return lookup(vars)
+		},
+		"UpdateIssueStates": batchStateHandler(nil),
+		"AddComment":        successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"magic_words": map[string]any{"enabled": true}})
+	rel := plugin.ReleaseContext{Version: "1.0.0", Changes: &plugin.CategorizedChanges{Other: []plugin.ConventionalCommit{
+		{Description: "fixes ENG-2"},
+		{Description: "refs ENG-40"},
+	}}}
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, rel, team), []string{"ENG-40", "ENG-2"})
+
+	// ENG-2 comes first in issue order, so its closing reference applies
+	if fake.callCount("UpdateIssueStates") != 1 || res.Updated != 1 {
+		t.Errorf("expected the closing reference to move the issue, Updated = %d, errors = %v", res.Updated, res.Errors)
+	}
+	if fake.callCount("AddComment") != 1 || res.Renamed["ENG-2"] != "ENG-40" {
+		t.Errorf("expected the issue to be commented once, comments = %d, renamed = %v", fake.callCount("AddComment"), res.Renamed)
+	}
+}
+
+func TestProcessLinkedIssuesOutputIsDeterministic(t *testing.T) {
+	issues := make(map[string]map[string]any)
+	var ids []string
+	for i := 1; i <= 24; i++ {
+		id := fmt.Sprintf("ENG-%d", i)
+		issue := map[string]any{
+			"id": fmt.Sprintf("uuid-%d", i), "identifier": id,
+			"state":    map[string]any{"id": "state-review", "name": "In Review"},
+			"assignee": map[string]any{"id": fmt.Sprintf("user-%d", i%3), "name": fmt.Sprintf("User %d", i%3)},
+		}
+		switch i % 6 {
+		case 1:
+			issue["archivedAt"] = "2024-01-01T00:00:00Z"
+		case 2:
+			issue["priority"] = 1
+		}
+		issues[id] = issue
+		ids = append(ids, id)
+	}
+	// References under an old identifier, and to a missing issue
+	issues["OPS-3"] = issues["ENG-3"]
+	ids = append(ids, "OPS-3", "ENG-99")
+	lookup := issueHandler(issues)
+
+	render := func(concurrency int) string {
+		fake := newFakeLinear(t, map[string]func(map[string]any) any{
+			"GetIssue": func(vars map[string]any) any {
+				// Vary timing so issues finish out of order
+				time.Sleep(time.Duration(len(fmt.Sprint(vars["id"]))%3) * time.Millisecond)
+				return lookup(vars)
+			},
+			"UpdateIssueStates": batchStateHandler(nil),
+			"AddComment":        successHandler("commentCreate"),
+		})
+		p := &LinearPlugin{}
+		cfg := p.parseConfig(map[string]any{
+			"concurrency":        concurrency,
+			"priority_guardrail": map[string]any{"threshold": 1},
+		})
+		run := fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{States: []State{{ID: "state-done", Name: "Done"}}})
+		p.processLinkedIssues(context.Background(), run, ids).report(run.results, cfg)
+
+		resp := run.results.response()
+		out, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(out)
+	}
+
+	want := render(1)
+	for range 5 {
+		if got := render(maxConcurrency); got != want {
+			t.Fatalf("concurrent output differs from serial output:\n got %s\nwant %s", got, want)
+		}
+	}
+}
+
+func TestResultCollectorConcurrentUse(t *testing.T) {
+	rc := newResultCollector()
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rc.success("worker", "Worker %d done", i)
+			rc.output(fmt.Sprintf("worker_%d", i), i)
+			rc.audit(auditEntry{Issue: fmt.Sprintf("ENG-%d", i), Field: "state"})
+			_ = rc.response()
+		}()
+	}
+	wg.Wait()
+
+	resp := rc.response()
+	if counts := resp.Outputs["result_counts"].(map[resultStatus]int); counts[statusSuccess] != 16 {
+		t.Errorf("result_counts = %v", counts)
+	}
+	if !reflect.DeepEqual(resp.Outputs["worker_3"], 3) {
+		t.Errorf("worker_3 = %v", resp.Outputs["worker_3"])
+	}
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// Built-in credential providers.
+const (
+	credentialStatic = "static"
+	credentialEnv    = "env"
+	credentialFile   = "file"
+	credentialExec   = "exec"
+	credentialOAuth  = "oauth"
+)
+
+// linearOAuthTokenURL is Linear's OAuth token endpoint.
+const linearOAuthTokenURL = "https://api.linear.app/oauth/token"
+
+// CredentialProvider supplies the token the client authenticates with.
+// Providers are resolved when the client is constructed, so new secret
+// backends only need a provider and a case in CredentialsConfig.provider.
+type CredentialProvider interface {
+	// ID identifies the credentials, so clients using the same ones are
+	// shared. It must not change over the provider's lifetime.
+	ID() string
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+}
+
+// refreshableCredentials is implemented by providers whose tokens may
+// expire during a run.
+type refreshableCredentials interface {
+	CredentialProvider
+	// Invalidate discards token, if it is still the current one, so the
+	// next Token call obtains a new one. Comparing tokens lets concurrent
+	// requests rejected with the same token trigger a single refresh.
+	Invalidate(token string)
+}
+
+// CredentialsConfig selects where the Linear token comes from. The default
+// static provider uses api_key.
+type CredentialsConfig struct {
+	Provider string      `json:"provider"`
+	Env      string      `json:"env,omitempty"`
+	File     string      `json:"file,omitempty"`
+	Command  []string    `json:"command,omitempty"`
+	OAuth    OAuthConfig `json:"oauth"`
+}
+
+// OAuthConfig holds the OAuth application credentials and refresh token
+// access tokens are obtained with.
+type OAuthConfig struct {
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenURL     string `json:"token_url,omitempty"`
+}
+
+// parseCredentialsConfig parses the credentials block.
+func parseCredentialsConfig(raw map[string]any) CredentialsConfig {
+	parser := helpers.NewConfigParser(raw)
+	oauth := helpers.NewConfigParser(parser.GetMap("oauth"))
+	return CredentialsConfig{
+		Provider: strings.ToLower(parser.GetString("provider", "", credentialStatic)),
+		Env:      parser.GetString("env", "", "LINEAR_API_KEY"),
+		File:     parser.GetString("file", "", ""),
+		Command:  parser.GetStringSlice("command", nil),
+		OAuth: OAuthConfig{
+			ClientID:     oauth.GetString("client_id", "LINEAR_OAUTH_CLIENT_ID", ""),
+			ClientSecret: oauth.GetString("client_secret", "LINEAR_OAUTH_CLIENT_SECRET", ""),
+			RefreshToken: oauth.GetString("refresh_token", "LINEAR_OAUTH_REFRESH_TOKEN", ""),
+			TokenURL:     oauth.GetString("token_url", "", linearOAuthTokenURL),
+		},
+	}
+}
+
+// validate reports missing settings of the selected provider. It reports
+// whether the provider is usable.
+func (c CredentialsConfig) validate(vb *helpers.ValidationBuilder) bool {
+	switch c.Provider {
+	case credentialStatic, credentialEnv:
+		return true
+	case credentialFile:
+		if c.File == "" {
+			vb.AddError("credentials.file", "The file provider requires a file")
+			return false
+		}
+	case credentialExec:
+		if len(c.Command) == 0 {
+			vb.AddError("credentials.command", "The exec provider requires a command")
+			return false
+		}
+	case credentialOAuth:
+		if c.OAuth.ClientID == "" || c.OAuth.ClientSecret == "" || c.OAuth.RefreshToken == "" {
+			vb.AddError("credentials.oauth", "The oauth provider requires client_id, client_secret and refresh_token")
+			return false
+		}
+	default:
+		vb.AddError("credentials.provider", fmt.Sprintf("Unknown credential provider %q (must be one of static, env, file, exec, oauth)", c.Provider))
+		return false
+	}
+	return true
+}
+
+// provider returns the configured credential provider; apiKey backs the
+// static provider.
+func (c CredentialsConfig) provider(apiKey string) CredentialProvider {
+	switch c.Provider {
+	case credentialEnv:
+		return envCredentials(c.Env)
+	case credentialFile:
+		return fileCredentials(c.File)
+	case credentialExec:
+		return &execCredentials{command: c.Command}
+	case credentialOAuth:
+		return &oauthCredentials{
+			id:         "oauth:" + c.OAuth.ClientID + ":" + c.OAuth.RefreshToken,
+			config:     c.OAuth,
+			httpClient: &http.Client{Timeout: defaultTimeout},
+		}
+	default:
+		return staticCredentials(apiKey)
+	}
+}
+
+// hasCredentials reports whether the configuration can authenticate:
+// api_key is set, or another provider is selected.
+func (c *Config) hasCredentials() bool {
+	return c.APIKey != "" || c.Credentials.Provider != credentialStatic
+}
+
+// client returns the shared client for the configured credentials and
+// request signing.
+func (c *Config) client() *LinearClient {
+	return defaultRegistry.configured(c.Credentials.provider(c.APIKey), c.RequestSigning.signer(), c.Network)
+}
+
+// authorization returns the Authorization header value for a token. API
+// keys are sent as they are; OAuth access tokens as bearer tokens.
+func authorization(token string) string {
+	if strings.HasPrefix(token, "lin_oauth_") && !strings.HasPrefix(token, "Bearer ") {
+		return "Bearer " + token
+	}
+	return token
+}
+
+// staticCredentials is a fixed API key.
+type staticCredentials string
+
+// ID implements CredentialProvider.
+func (s staticCredentials) ID() string { return string(s) }
+
+// Token implements CredentialProvider.
+func (s staticCredentials) Token(context.Context) (string, error) { return string(s), nil }
+
+// envCredentials reads the token from an environment variable on every
+// request, so a rotated value is picked up.
+type envCredentials string
+
+// ID implements CredentialProvider.
+func (e envCredentials) ID() string { return "env:" + string(e) }
+
+// Token implements CredentialProvider.
+func (e envCredentials) Token(context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(e)))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is empty", string(e))
+	}
+	return token, nil
+}
+
+// fileCredentials reads the token from a file, such as a mounted secret,
+// on every request.
+type fileCredentials string
+
+// ID implements CredentialProvider.
+func (f fileCredentials) ID() string { return "file:" + string(f) }
+
+// Token implements CredentialProvider.
+func (f fileCredentials) Token(context.Context) (string, error) {
+	data, err := os.ReadFile(string(f))
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("credentials file %s is empty", string(f))
+	}
+	return token, nil
+}
+
+// execCredentials runs a command, such as a secret manager CLI, and uses
+// its output as the token. The command runs once per client, and again
+// when Linear rejects the token.
+type execCredentials struct {
+	command []string
+
+	mu    sync.Mutex
+	token string
+}
+
+// ID implements CredentialProvider.
+func (e *execCredentials) ID() string { return "exec:" + strings.Join(e.command, " ") }
+
+// Token implements CredentialProvider.
+func (e *execCredentials) Token(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token != "" {
+		return e.token, nil
+	}
+	out, err := exec.CommandContext(ctx, e.command[0], e.command[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("credentials command failed: %w", err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("credentials command printed no token")
+	}
+	e.token = token
+	return token, nil
+}
+
+// Invalidate implements refreshableCredentials.
+func (e *execCredentials) Invalidate(token string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.token == token {
+		e.token = ""
+	}
+}
+
+// oauthCredentials exchanges a refresh token for access tokens, keeping
+// the current one until shortly before it expires or Linear rejects it.
+type oauthCredentials struct {
+	id         string
+	config     OAuthConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// oauthExpiryMargin renews access tokens this long before they expire.
+const oauthExpiryMargin = time.Minute
+
+// ID implements CredentialProvider.
+func (o *oauthCredentials) ID() string { return o.id }
+
+// Token implements CredentialProvider. Access tokens are bearer tokens.
+func (o *oauthCredentials) Token(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token == "" || (!o.expiresAt.IsZero() && !time.Now().Add(oauthExpiryMargin).Before(o.expiresAt)) {
+		if err := o.refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+	return "Bearer " + o.token, nil
+}
+
+// Invalidate implements refreshableCredentials.
+func (o *oauthCredentials) Invalidate(token string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if "Bearer "+o.token == token {
+		o.token = ""
+	}
+}
+
+// refresh obtains a new access token. Linear may rotate the refresh token,
+// in which case the new one is used from then on.
+func (o *oauthCredentials) refresh(ctx context.Context) error {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {o.config.RefreshToken},
+		"client_id":     {o.config.ClientID},
+		"client_secret": {o.config.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to refresh OAuth token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to refresh OAuth token: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("token response carried no access token")
+	}
+
+	o.token = result.AccessToken
+	o.expiresAt = time.Time{}
+	if result.ExpiresIn > 0 {
+		o.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	if result.RefreshToken != "" {
+		o.config.RefreshToken = result.RefreshToken
+	}
+	return nil
+}
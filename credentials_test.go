@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialProviders(t *testing.T) {
+	t.Setenv("LINEAR_TEST_TOKEN", "lin_api_env")
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("lin_api_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{"static", map[string]any{}, "lin_api_static"},
+		{"env", map[string]any{"provider": "env", "env": "LINEAR_TEST_TOKEN"}, "lin_api_env"},
+		{"file", map[string]any{"provider": "file", "file": file}, "lin_api_file"},
+		{"exec", map[string]any{"provider": "exec", "command": []any{"echo", "lin_api_exec"}}, "lin_api_exec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := parseCredentialsConfig(tt.config).provider("lin_api_static")
+			got, err := provider.Token(context.Background())
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Token() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthCredentialsRefresh(t *testing.T) {
+	var refreshTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		refreshTokens = append(refreshTokens, r.PostForm.Get("refresh_token"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "lin_oauth_access",
+			"refresh_token": "refresh-2",
+			"expires_in":    30,
+		})
+	}))
+	defer server.Close()
+
+	provider := parseCredentialsConfig(map[string]any{
+		"provider": "oauth",
+		"oauth": map[string]any{
+			"client_id":     "client",
+			"client_secret": "secret",
+			"refresh_token": "refresh-1",
+			"token_url":     server.URL,
+		},
+	}).provider("")
+
+	for range 2 {
+		token, err := provider.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+		if token != "Bearer lin_oauth_access" {
+			t.Errorf("Token() = %q", token)
+		}
+	}
+
+	// The token expires within the renewal margin, so each call refreshes
+	// with the rotated refresh token
+	if len(refreshTokens) != 2 || refreshTokens[0] != "refresh-1" || refreshTokens[1] != "refresh-2" {
+		t.Errorf("refresh tokens sent = %v", refreshTokens)
+	}
+	if provider.ID() != "oauth:client:refresh-1" {
+		t.Errorf("ID() changed after rotation: %s", provider.ID())
+	}
+}
+
+func TestClientSendsProviderToken(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("LINEAR_TEST_TOKEN", "lin_oauth_from_env")
+	client := newCredentialedClient(envCredentials("LINEAR_TEST_TOKEN"))
+	client.endpoint = server.URL
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+	if got != "Bearer lin_oauth_from_env" {
+		t.Errorf("Authorization = %q", got)
+	}
+}
+
+func TestValidateCredentialsProvider(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"team_id":     "team-1",
+		"credentials": map[string]any{"provider": "vault"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range resp.Errors {
+		if e.Field == "credentials.provider" {
+			return
+		}
+	}
+	t.Errorf("expected a credentials.provider error, got %v", resp.Errors)
+}
+
+func TestExecuteRefreshesRejectedToken(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "runs")
+	tests := []struct {
+		name        string
+		credentials CredentialProvider
+		wantCalls   int
+		wantErr     bool
+	}{
+		{"exec", &execCredentials{command: []string{"sh", "-c", "echo run >> " + counter + "; echo token-$(wc -l < " + counter + ")"}}, 2, false},
+		{"static", staticCredentials("token-1"), 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if r.Header.Get("Authorization") != "token-2" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+			}))
+			defer server.Close()
+
+			client := newCredentialedClient(tt.credentials)
+			client.endpoint = server.URL
+			_, err := client.GetViewer(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetViewer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestOAuthCredentialsRefreshOnRejection(t *testing.T) {
+	issued := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("lin_oauth_%d", issued),
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first token was revoked mid-run
+		if r.Header.Get("Authorization") == "Bearer lin_oauth_1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer api.Close()
+
+	client := newCredentialedClient(parseCredentialsConfig(map[string]any{
+		"provider": "oauth",
+		"oauth": map[string]any{
+			"client_id":     "client",
+			"client_secret": "secret",
+			"refresh_token": "refresh-1",
+			"token_url":     tokenServer.URL,
+		},
+	}).provider(""))
+	client.endpoint = api.URL
+
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+	if issued != 2 {
+		t.Errorf("access tokens issued = %d, want 2", issued)
+	}
+}
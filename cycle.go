@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// CycleConfig associates the release with the team's active cycle.
+type CycleConfig struct {
+	Enabled      bool `json:"enabled"`
+	ReleaseIssue bool `json:"release_issue"`
+	LinkedIssues bool `json:"linked_issues"`
+}
+
+// parseCycleConfig parses the cycle block. When enabled, the release issue
+// joins the cycle by default; moving linked issues is opt-in since they may
+// have been planned into another cycle.
+func parseCycleConfig(raw map[string]any) CycleConfig {
+	parser := helpers.NewConfigParser(raw)
+	return CycleConfig{
+		Enabled:      parser.GetBool("enabled", false),
+		ReleaseIssue: parser.GetBool("release_issue", true),
+		LinkedIssues: parser.GetBool("linked_issues", false),
+	}
+}
+
+// movesLinkedIssues reports whether linked issues join the active cycle.
+func (c CycleConfig) movesLinkedIssues() bool {
+	return c.Enabled && c.LinkedIssues
+}
+
+// String returns the cycle's name, or its number for unnamed cycles.
+func (c *Cycle) String() string {
+	if c.Name != "" {
+		return fmt.Sprintf("%s (#%d)", c.Name, c.Number)
+	}
+	return fmt.Sprintf("Cycle %d", c.Number)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func activeCycleHandler(cycle map[string]any) func(map[string]any) any {
+	return func(map[string]any) any {
+		return map[string]any{"team": map[string]any{"activeCycle": cycle}}
+	}
+}
+
+func TestProcessLinkedIssuesAddsToCycle(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetActiveCycle": activeCycleHandler(map[string]any{"id": "cycle-7", "number": 7, "name": "Sprint 7"}),
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "cycle": map[string]any{"id": "cycle-7", "number": 7}},
+		}),
+		"UpdateIssueCycle": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"add_release_comment":  false,
+		"cycle":                map[string]any{"enabled": true, "linked_issues": true},
+	})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{ID: "team-1"}), []string{"ENG-1", "ENG-2"})
+
+	if res.Cycled != 1 {
+		t.Errorf("Cycled = %d, want 1 (ENG-2 is already in the cycle)", res.Cycled)
+	}
+	if res.Cycle == nil || res.Cycle.String() != "Sprint 7 (#7)" {
+		t.Errorf("Cycle = %v", res.Cycle)
+	}
+	calls := fake.calls["UpdateIssueCycle"]
+	if len(calls) != 1 || calls[0]["id"] != "uuid-1" || calls[0]["input"].(map[string]any)["cycleId"] != "cycle-7" {
+		t.Errorf("unexpected cycle updates %v", calls)
+	}
+}
+
+func TestProcessLinkedIssuesWithoutActiveCycle(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetActiveCycle": activeCycleHandler(nil),
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+		"UpdateIssueCycle": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"add_release_comment":  false,
+		"cycle":                map[string]any{"enabled": true, "linked_issues": true},
+	})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{ID: "team-1"}), []string{"ENG-1"})
+
+	if fake.callCount("UpdateIssueCycle") != 0 || res.Cycle != nil {
+		t.Errorf("expected no cycle changes without an active cycle, got %d", fake.callCount("UpdateIssueCycle"))
+	}
+}
+
+func TestPostPublishAddsReleaseIssueToCycle(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{"id": "user-1", "name": "Jane Doe"}}
+		},
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG"}}
+		},
+		"FindIssuesByDescription": func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []any{}}}
+		},
+		"CreateIssue": func(map[string]any) any {
+			return map[string]any{"issueCreate": map[string]any{
+				"success": true,
+				"issue":   map[string]any{"id": "uuid-100", "identifier": "ENG-100"},
+			}}
+		},
+		"GetActiveCycle":   activeCycleHandler(map[string]any{"id": "cycle-7", "number": 7}),
+		"UpdateIssueCycle": successHandler("issueUpdate"),
+	})
+	fake.register(t, "lin_api_cycle_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"api_key":              "lin_api_cycle_test",
+			"team_id":              "team-1",
+			"update_linked_issues": false,
+			"add_release_comment":  false,
+			"cycle":                map[string]any{"enabled": true},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	calls := fake.calls["UpdateIssueCycle"]
+	if len(calls) != 1 || calls[0]["id"] != "uuid-100" {
+		t.Errorf("expected the release issue to join the cycle, got %v (%s)", calls, resp.Message)
+	}
+	if cycle, _ := resp.Outputs["cycle"].(*Cycle); cycle == nil || cycle.Number != 7 {
+		t.Errorf("cycle output = %v", resp.Outputs["cycle"])
+	}
+}
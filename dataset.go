@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// datasetSchemaVersion is bumped on incompatible changes to releaseDataset.
+const datasetSchemaVersion = 1
+
+// releaseDataset is a normalized, stable export of a release and the issues
+// it shipped, for BI dashboards charting throughput per release. Issues
+// reference teams and projects by ID.
+type releaseDataset struct {
+	SchemaVersion int              `json:"schema_version"`
+	Release       datasetRelease   `json:"release"`
+	Issues        []datasetIssue   `json:"issues"`
+	Teams         []datasetTeam    `json:"teams"`
+	Projects      []datasetProject `json:"projects"`
+}
+
+type datasetRelease struct {
+	Version         string `json:"version"`
+	TagName         string `json:"tag_name"`
+	PreviousVersion string `json:"previous_version"`
+	ReleaseType     string `json:"release_type"`
+	Branch          string `json:"branch"`
+	CommitSHA       string `json:"commit_sha"`
+	Repository      string `json:"repository"`
+	PublishedAt     string `json:"published_at"`
+}
+
+type datasetIssue struct {
+	ID         string  `json:"id"`
+	Identifier string  `json:"identifier"`
+	Title      string  `json:"title"`
+	TeamID     string  `json:"team_id"`
+	ProjectID  string  `json:"project_id"`
+	AssigneeID string  `json:"assignee_id"`
+	Estimate   float64 `json:"estimate"`
+	Priority   float64 `json:"priority"`
+	State      string  `json:"state"`
+	StateType  string  `json:"state_type"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type datasetTeam struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type datasetProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// buildReleaseDataset assembles the dataset for a release. Entries are
+// sorted so repeated exports of the same release are identical.
+func buildReleaseDataset(releaseCtx plugin.ReleaseContext, issues []*Issue, now time.Time) releaseDataset {
+	ds := releaseDataset{
+		SchemaVersion: datasetSchemaVersion,
+		Release: datasetRelease{
+			Version:         releaseCtx.Version,
+			TagName:         releaseCtx.TagName,
+			PreviousVersion: releaseCtx.PreviousVersion,
+			ReleaseType:     releaseCtx.ReleaseType,
+			Branch:          releaseCtx.Branch,
+			CommitSHA:       releaseCtx.CommitSHA,
+			Repository:      releaseCtx.RepositoryURL,
+			PublishedAt:     now.UTC().Format(time.RFC3339),
+		},
+		Issues:   []datasetIssue{},
+		Teams:    []datasetTeam{},
+		Projects: []datasetProject{},
+	}
+
+	teams := make(map[string]bool)
+	projects := make(map[string]bool)
+	for _, issue := range issues {
+		row := datasetIssue{
+			ID:         issue.ID,
+			Identifier: issue.Identifier,
+			Title:      issue.Title,
+			Estimate:   issue.Estimate,
+			Priority:   issue.Priority,
+			State:      issue.State.Name,
+			StateType:  issue.State.Type,
+			CreatedAt:  issue.CreatedAt,
+		}
+		if issue.Team != nil {
+			row.TeamID = issue.Team.ID
+			if !teams[issue.Team.ID] {
+				teams[issue.Team.ID] = true
+				ds.Teams = append(ds.Teams, datasetTeam{ID: issue.Team.ID, Key: issue.Team.Key, Name: issue.Team.Name})
+			}
+		}
+		if issue.Project != nil {
+			row.ProjectID = issue.Project.ID
+			if !projects[issue.Project.ID] {
+				projects[issue.Project.ID] = true
+				ds.Projects = append(ds.Projects, datasetProject{ID: issue.Project.ID, Name: issue.Project.Name, URL: issue.Project.URL})
+			}
+		}
+		if issue.Assignee != nil {
+			row.AssigneeID = issue.Assignee.ID
+		}
+		ds.Issues = append(ds.Issues, row)
+	}
+
+	sort.Slice(ds.Issues, func(i, j int) bool { return ds.Issues[i].Identifier < ds.Issues[j].Identifier })
+	sort.Slice(ds.Teams, func(i, j int) bool { return ds.Teams[i].ID < ds.Teams[j].ID })
+	sort.Slice(ds.Projects, func(i, j int) bool { return ds.Projects[i].ID < ds.Projects[j].ID })
+	return ds
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestBuildReleaseDataset(t *testing.T) {
+	team := &Team{ID: "team-1", Key: "ENG", Name: "Engineering"}
+	project := &Project{ID: "proj-1", Name: "Billing"}
+	issues := []*Issue{
+		{ID: "uuid-2", Identifier: "ENG-2", Team: team, Project: project, Estimate: 3, State: State{Name: "Done", Type: "completed"}},
+		{ID: "uuid-1", Identifier: "ENG-1", Team: team, Assignee: &User{ID: "user-1"}, Estimate: 2},
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ds := buildReleaseDataset(plugin.ReleaseContext{Version: "1.2.0", PreviousVersion: "1.1.0"}, issues, now)
+
+	if ds.SchemaVersion != datasetSchemaVersion || ds.Release.Version != "1.2.0" || ds.Release.PublishedAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("unexpected release metadata %+v", ds.Release)
+	}
+	if len(ds.Issues) != 2 || ds.Issues[0].Identifier != "ENG-1" {
+		t.Fatalf("expected issues sorted by identifier, got %+v", ds.Issues)
+	}
+	if ds.Issues[0].AssigneeID != "user-1" || ds.Issues[1].ProjectID != "proj-1" || ds.Issues[1].StateType != "completed" {
+		t.Errorf("unexpected issue rows %+v", ds.Issues)
+	}
+	if len(ds.Teams) != 1 || len(ds.Projects) != 1 {
+		t.Errorf("expected deduplicated teams and projects, got %+v %+v", ds.Teams, ds.Projects)
+	}
+}
+
+func TestBuildReleaseDatasetEmpty(t *testing.T) {
+	ds := buildReleaseDataset(plugin.ReleaseContext{Version: "1.0.0"}, nil, time.Now())
+
+	data, err := json.Marshal(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	_ = json.Unmarshal(data, &decoded)
+	for _, key := range []string{"issues", "teams", "projects"} {
+		if _, ok := decoded[key].([]any); !ok {
+			t.Errorf("expected %s to be an empty list, got %v", key, decoded[key])
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets in debug logs.
+const redacted = "[REDACTED]"
+
+// secretNames match variable names whose values are never logged.
+var secretNames = []string{"token", "secret", "password", "apikey", "api_key", "authorization", "credential", "signature"}
+
+// secretPattern matches values that look like secrets wherever they appear:
+// Linear API and OAuth tokens, bearer tokens, JWTs, and GitHub and Slack
+// tokens.
+var secretPattern = regexp.MustCompile(`lin_(?:api|oauth|wh)_[A-Za-z0-9]+|(?i:bearer)\s+[A-Za-z0-9._~+/=-]+|eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+|(?:ghp|gho|ghs|ghu|github_pat)_[A-Za-z0-9_]+|xox[abpr]-[A-Za-z0-9-]+`)
+
+// debugLogger logs every Linear API request with its variables, response
+// status and timing, redacting secrets.
+type debugLogger struct {
+	logger *slog.Logger
+	// secrets are configured values redacted wherever they appear, such
+	// as the API key.
+	secrets []string
+}
+
+// newDebugLogger creates a debug logger writing to w. Empty secrets are
+// ignored.
+func newDebugLogger(w io.Writer, secrets ...string) *debugLogger {
+	l := &debugLogger{logger: slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+	for _, s := range secrets {
+		if s != "" {
+			l.secrets = append(l.secrets, s)
+		}
+	}
+	return l
+}
+
+// debugLogger returns the debug logger of the configuration, writing to
+// stderr and redacting the configured API keys and secrets.
+func (c *Config) debugLogger() *debugLogger {
+	return newDebugLogger(os.Stderr,
+		c.APIKey, c.Sandbox.APIKey, c.RequestSigning.HMAC.Secret,
+		c.Credentials.OAuth.ClientSecret, c.Credentials.OAuth.RefreshToken)
+}
+
+// debugRequest describes one attempt of a Linear API request.
+type debugRequest struct {
+	Operation string
+	Attempt   int
+	Variables map[string]any
+	// Status is the HTTP status of the response, 0 when none arrived.
+	Status   int
+	Duration time.Duration
+	Err      error
+}
+
+// log writes the request.
+func (l *debugLogger) log(ctx context.Context, req debugRequest) {
+	variables, err := json.Marshal(redactVariables(req.Variables))
+	if err != nil {
+		variables = []byte(err.Error())
+	}
+	attrs := []slog.Attr{
+		slog.String("operation", req.Operation),
+		slog.Int("attempt", req.Attempt),
+		slog.String("variables", l.redact(string(variables))),
+		slog.Int("status", req.Status),
+		slog.Float64("duration_ms", float64(req.Duration)/float64(time.Millisecond)),
+	}
+	if req.Err != nil {
+		attrs = append(attrs, slog.String("error", l.redact(req.Err.Error())))
+	}
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "linear.request", attrs...)
+}
+
+// redact replaces configured secrets and secret-looking values in s.
+func (l *debugLogger) redact(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return secretPattern.ReplaceAllString(s, redacted)
+}
+
+// redactVariables returns a copy of the variables with the values of
+// secret-named keys replaced.
+func redactVariables(v map[string]any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	out := make(map[string]any, len(v))
+	for name, value := range v {
+		if secretName(name) {
+			out[name] = redacted
+			continue
+		}
+		out[name] = redactValue(value)
+	}
+	return out
+}
+
+// redactValue redacts the maps nested in value.
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactVariables(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue(item)
+		}
+		return out
+	}
+	return value
+}
+
+// secretName reports whether a variable of that name holds a secret.
+func secretName(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseStatus returns the HTTP status of a request's outcome: 200 when
+// Linear answered, even with GraphQL errors, the status of a StatusError,
+// and 0 when no response arrived.
+func responseStatus(resp *GraphQLResponse, err error) int {
+	var statusErr *StatusError
+	switch {
+	case resp != nil:
+		return http.StatusOK
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+type debugLoggerKey struct{}
+
+// withDebugLogger returns a context whose Linear requests are logged.
+func withDebugLogger(ctx context.Context, l *debugLogger) context.Context {
+	return context.WithValue(ctx, debugLoggerKey{}, l)
+}
+
+// logDebug hands a request to the debug logger attached to ctx, if any.
+func logDebug(ctx context.Context, req debugRequest) {
+	if l, _ := ctx.Value(debugLoggerKey{}).(*debugLogger); l != nil {
+		l.log(ctx, req)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// builtinDenylist lists tokens that look like issue identifiers but are
+// standards, encodings and algorithms commonly mentioned in commits.
+var builtinDenylist = []string{
+	"UTF-*", "UCS-*", "ISO-*", "RFC-*", "CVE-*", "CWE-*", "GHSA-*",
+	"SHA-*", "MD-*", "AES-*", "RSA-*", "HMAC-*", "TLS-*", "SSL-*",
+	"HTTP-*", "ECMA-*", "COVID-*",
+}
+
+// denylist returns the patterns of identifiers never treated as issues.
+func (c *Config) denylist() []string {
+	if !c.BuiltinDenylist {
+		return c.Denylist
+	}
+	return append(append([]string(nil), builtinDenylist...), c.Denylist...)
+}
+
+// denied reports whether an identifier matches a denylist pattern. Keys the
+// configuration names explicitly (issue_prefix, team keys) are never denied,
+// so a team keyed ISO keeps its issues.
+func denied(cfg *Config, id string) bool {
+	id = strings.ToUpper(id)
+	key, _, _ := strings.Cut(id, "-")
+	if strings.EqualFold(key, cfg.IssuePrefix) {
+		return false
+	}
+	for _, t := range cfg.Teams {
+		if strings.EqualFold(key, t.Key) {
+			return false
+		}
+	}
+
+	for _, pattern := range cfg.denylist() {
+		if ok, _ := path.Match(strings.ToUpper(pattern), id); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLinkedIssueIDsDenylist(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "ENG-1 parse ISO-8601 dates as UTF-8, per RFC-3339"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "LEGACY-7 verify SHA-256 sums"}},
+	}}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   []string
+	}{
+		{"builtin", map[string]any{"team_id": "team-1"}, []string{"ENG-1", "LEGACY-7"}},
+		{"extended", map[string]any{"team_id": "team-1", "denylist": []any{"legacy-*"}}, []string{"ENG-1"}},
+		{"builtin disabled", map[string]any{"team_id": "team-1", "builtin_denylist": false},
+			[]string{"ENG-1", "ISO-8601", "LEGACY-7", "RFC-3339", "SHA-256", "UTF-8"}},
+		{"configured key kept", map[string]any{"team_key": "ISO"}, []string{"ISO-8601"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(tt.config)
+			if got := linkedIssueIDs(cfg, releaseCtx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkedIssueIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Sources identifiers are matched in.
+const (
+	sourceSubject = "subject"
+	sourceBody    = "body"
+	sourceBranch  = "branch"
+)
+
+// notScanned marks tokens found in sources issues are not linked from.
+const notScanned = "not_scanned"
+
+// extractionDiagnostic describes one token matched by the issue pattern.
+type extractionDiagnostic struct {
+	Token  string `json:"token"`
+	Source string `json:"source"`
+	Commit string `json:"commit,omitempty"`
+	Linked bool   `json:"linked"`
+	// FilteredBy names the option that kept the token from being linked,
+	// or "not_scanned" for sources issues are not linked from.
+	FilteredBy string `json:"filtered_by,omitempty"`
+}
+
+// extractionDiagnostics lists every token the issue pattern matches in the
+// release's commit subjects and bodies and in the branch names, and whether
+// it is linked. Commit subjects are linked from, and branch names with
+// branch_issues; tokens elsewhere are listed to explain why an expected
+// issue is missing.
+func extractionDiagnostics(cfg *Config, releaseCtx plugin.ReleaseContext) []extractionDiagnostic {
+	pattern := cfg.issueRegexp()
+	var diags []extractionDiagnostic
+	add := func(text, source, commit string) {
+		tokens := extractIssuesMatching(pattern, []string{text}, "")
+		if source == sourceBranch {
+			tokens = branchIssues(pattern, []string{text})
+		}
+		for _, token := range tokens {
+			d := extractionDiagnostic{Token: token, Source: source, Commit: commit, FilteredBy: notScanned}
+			if source == sourceSubject || (source == sourceBranch && cfg.BranchIssues) {
+				d.FilteredBy = issueFilterReason(cfg, token)
+				d.Linked = d.FilteredBy == ""
+			}
+			diags = append(diags, d)
+		}
+	}
+
+	if changes := releaseCtx.Changes; changes != nil {
+		for _, group := range [][]plugin.ConventionalCommit{changes.Features, changes.Fixes, changes.Breaking, changes.Other} {
+			for _, c := range group {
+				add(c.Description, sourceSubject, c.Hash)
+				add(c.Body, sourceBody, c.Hash)
+				if name := mergedBranch(c.Description); name != "" {
+					add(name, sourceBranch, c.Hash)
+				}
+			}
+		}
+	}
+	add(releaseCtx.Branch, sourceBranch, "")
+	return diags
+}
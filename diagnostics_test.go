@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExtractionDiagnostics(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_key": "ENG"})
+	releaseCtx := plugin.ReleaseContext{
+		Branch: "feature/ENG-9-export",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Hash: "abc1234", Description: "add export ENG-1 OPS-2", Body: "Closes ENG-3"}},
+			Fixes:    []plugin.ConventionalCommit{{Hash: "def5678", Description: "handle UTF-8 names"}},
+		},
+	}
+
+	got := extractionDiagnostics(cfg, releaseCtx)
+	want := []extractionDiagnostic{
+		{Token: "ENG-1", Source: "subject", Commit: "abc1234", Linked: true},
+		{Token: "OPS-2", Source: "subject", Commit: "abc1234", FilteredBy: "issue_prefix"},
+		{Token: "ENG-3", Source: "body", Commit: "abc1234", FilteredBy: "not_scanned"},
+		{Token: "UTF-8", Source: "subject", Commit: "def5678", FilteredBy: "issue_prefix"},
+		{Token: "ENG-9", Source: "branch", FilteredBy: "not_scanned"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractionDiagnostics() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestPostPlanOutputsDiagnostics(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:   plugin.HookPostPlan,
+		Config: map[string]any{"team_id": "team-1"},
+		Context: plugin.ReleaseContext{Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "verify SHA-256 sums"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	diags, _ := resp.Outputs["diagnostics"].([]extractionDiagnostic)
+	if len(diags) != 1 || diags[0].FilteredBy != "denylist" {
+		t.Errorf("diagnostics = %+v", resp.Outputs["diagnostics"])
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const (
+	markerDigest = "digest"
+
+	digestTargetProjectUpdate = "project_update"
+	digestTargetDocument      = "document"
+)
+
+// DigestConfig controls periodic release digests, which summarize all
+// releases since the previous digest in one project update or document.
+type DigestConfig struct {
+	Enabled bool          `json:"enabled"`
+	Hook    string        `json:"hook"`
+	Cadence time.Duration `json:"cadence"`
+	Target  string        `json:"target"`
+	Title   string        `json:"title"`
+}
+
+// parseDigestConfig parses the digest block.
+func parseDigestConfig(raw map[string]any) DigestConfig {
+	parser := helpers.NewConfigParser(raw)
+	cfg := DigestConfig{
+		Enabled: parser.GetBool("enabled", false),
+		Hook:    parser.GetString("hook", "", string(plugin.HookPostPublish)),
+		Cadence: 7 * 24 * time.Hour,
+		Target:  parser.GetString("target", "", digestTargetProjectUpdate),
+		Title:   parser.GetString("title", "", "Release digest {{.Date}}"),
+	}
+	if d, err := time.ParseDuration(parser.GetString("cadence", "", "")); err == nil {
+		cfg.Cadence = d
+	}
+	return cfg
+}
+
+// runsOn reports whether the digest is produced by the given hook.
+func (d DigestConfig) runsOn(hook plugin.Hook) bool {
+	return d.Enabled && plugin.Hook(d.Hook) == hook
+}
+
+// publishDigest posts a digest of the release issues created since the last
+// digest, provided the cadence has elapsed. A non-empty appendix is added to
+// project updates. It returns a summary message.
+func publishDigest(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, team *Team, now time.Time, appendix string) (string, error) {
+	last, err := lastDigestTime(ctx, client, cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up previous digest: %w", err)
+	}
+
+	if !last.IsZero() && now.Sub(last) < cfg.Digest.Cadence {
+		return fmt.Sprintf("Release digest not due until %s", last.Add(cfg.Digest.Cadence).Format(time.RFC3339)), nil
+	}
+
+	since := last
+	if since.IsZero() {
+		since = now.Add(-cfg.Digest.Cadence)
+	}
+
+	releases, err := client.ListIssuesCreatedSince(ctx, team.ID, markerPrefix(cfg.Naming.marker(markerRelease)), since)
+	if err != nil {
+		return "", fmt.Errorf("failed to list releases since last digest: %w", err)
+	}
+	if len(releases) == 0 {
+		return "No releases since the last digest", nil
+	}
+
+	title, err := renderTemplate(cfg.Digest.Title, releaseCtx, cfg.TemplatePartials)
+	if err != nil {
+		return "", fmt.Errorf("failed to render digest title: %w", err)
+	}
+	title = cfg.Naming.title(title)
+	content := renderDigest(releases, since)
+	if appendix != "" && cfg.Digest.Target != digestTargetDocument {
+		content += "\n\n" + appendix
+	}
+	body := appendMarker(content, cfg.Naming.marker(markerDigest), now.UTC().Format(time.RFC3339))
+
+	var url string
+	if cfg.Digest.Target == digestTargetDocument {
+		doc, err := client.CreateDocument(ctx, cfg.ProjectID, title, body)
+		if err != nil {
+			return "", fmt.Errorf("failed to create digest document: %w", err)
+		}
+		url = doc.URL
+	} else {
+		update, err := client.CreateProjectUpdate(ctx, cfg.ProjectID, "# "+title+"\n\n"+body)
+		if err != nil {
+			return "", fmt.Errorf("failed to create digest project update: %w", err)
+		}
+		url = update.URL
+	}
+
+	return fmt.Sprintf("Published release digest of %d release(s) (%s)", len(releases), url), nil
+}
+
+// lastDigestTime returns when the most recent digest was published, or the
+// zero time if there is none.
+func lastDigestTime(ctx context.Context, client *LinearClient, cfg *Config) (time.Time, error) {
+	var bodies []string
+	if cfg.Digest.Target == digestTargetDocument {
+		docs, err := client.GetProjectDocuments(ctx, cfg.ProjectID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, d := range docs {
+			bodies = append(bodies, d.Content)
+		}
+	} else {
+		updates, err := client.GetProjectUpdates(ctx, cfg.ProjectID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, u := range updates {
+			bodies = append(bodies, u.Body)
+		}
+	}
+
+	var last time.Time
+	for _, body := range bodies {
+		for _, v := range findMarkers(body, cfg.Naming.marker(markerDigest)) {
+			if t, err := time.Parse(time.RFC3339, v); err == nil && t.After(last) {
+				last = t
+			}
+		}
+	}
+	return last, nil
+}
+
+// renderDigest lists the releases included in a digest.
+func renderDigest(releases []Issue, since time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d release(s) since %s:\n\n", len(releases), since.UTC().Format("2006-01-02"))
+	for _, r := range releases {
+		date := r.CreatedAt
+		if t, err := time.Parse(time.RFC3339, r.CreatedAt); err == nil {
+			date = t.UTC().Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "- [%s](%s) (%s) — %s\n", r.Title, r.URL, r.Identifier, date)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
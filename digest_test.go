@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPublishDigest(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		lastDigest  time.Time
+		wantPublish bool
+	}{
+		{"due", now.Add(-8 * 24 * time.Hour), true},
+		{"not due", now.Add(-2 * 24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted string
+			fake := newFakeLinear(t, map[string]func(map[string]any) any{
+				"GetProjectUpdates": func(map[string]any) any {
+					body := appendMarker("previous", markerDigest, tt.lastDigest.Format(time.RFC3339))
+					return map[string]any{"project": map[string]any{"projectUpdates": map[string]any{
+						"nodes": []map[string]any{{"id": "pu-1", "body": body}},
+					}}}
+				},
+				"ListIssuesCreatedSince": func(map[string]any) any {
+					return map[string]any{"issues": map[string]any{"nodes": []map[string]any{
+						{"id": "r1", "identifier": "ENG-10", "title": "Release 1.1.0", "url": "https://linear.app/i/ENG-10", "createdAt": "2026-10-12T09:00:00Z"},
+						{"id": "r2", "identifier": "ENG-11", "title": "Release 1.1.1", "url": "https://linear.app/i/ENG-11", "createdAt": "2026-10-14T09:00:00Z"},
+					}}}
+				},
+				"CreateProjectUpdate": func(vars map[string]any) any {
+					input, _ := vars["input"].(map[string]any)
+					posted, _ = input["body"].(string)
+					return map[string]any{"projectUpdateCreate": map[string]any{
+						"success":       true,
+						"projectUpdate": map[string]any{"id": "pu-2", "url": "https://linear.app/pu-2"},
+					}}
+				},
+			})
+
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(map[string]any{
+				"project_id": "proj-1",
+				"digest":     map[string]any{"enabled": true},
+			})
+
+			msg, err := publishDigest(context.Background(), fake.client(), cfg, plugin.ReleaseContext{}, &Team{ID: "team-1"}, now, "")
+			if err != nil {
+				t.Fatalf("publishDigest() error = %v", err)
+			}
+
+			published := fake.callCount("CreateProjectUpdate") == 1
+			if published != tt.wantPublish {
+				t.Fatalf("published = %v, want %v (%s)", published, tt.wantPublish, msg)
+			}
+			if !tt.wantPublish {
+				return
+			}
+			if !strings.Contains(posted, "[Release 1.1.0](https://linear.app/i/ENG-10)") {
+				t.Errorf("digest missing release entry: %s", posted)
+			}
+			if got := findMarkers(posted, markerDigest); len(got) != 1 || got[0] != now.Format(time.RFC3339) {
+				t.Errorf("expected digest marker for %s, got %v", now.Format(time.RFC3339), got)
+			}
+		})
+	}
+}
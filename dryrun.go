@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// plannedIssue describes what a real run would do to one linked issue.
+type plannedIssue struct {
+	Issue        string `json:"issue"`
+	CurrentState string `json:"current_state"`
+	PlannedState string `json:"planned_state"`
+	// Outcome is transition, unchanged, fail, or unknown when the teams
+	// could not be resolved; Reason explains all but transitions.
+	Outcome string `json:"outcome"`
+	Reason  string `json:"reason,omitempty"`
+	Comment bool   `json:"comment"`
+	Links   bool   `json:"links"`
+}
+
+// unknownState is shown when an issue's current state cannot be fetched.
+const unknownState = "unknown"
+
+// Planned outcomes of a linked issue.
+const (
+	outcomeTransition = "transition"
+	outcomeUnchanged  = "unchanged"
+	outcomeFail       = "fail"
+	outcomeUnknown    = "unknown"
+)
+
+// planLinkedIssues looks up the linked issues read-only and describes the
+// changes a real run would make. Issues that cannot be fetched, and issues
+// whose team lacks the released state, are planned to fail. Without a plan
+// of the teams the outcome is unknown.
+func planLinkedIssues(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, plan *linkedIssuePlan, issueIDs []string) []plannedIssue {
+	planned, _ := releaseLinks(cfg, releaseCtx)
+	links := cfg.AddReleaseLinks && len(planned) > 0
+
+	// A failed batch leaves the issues to be looked up one by one
+	issues, _ := client.GetIssuesByIdentifiers(ctx, issueIDs)
+
+	rows := make([]plannedIssue, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		row := plannedIssue{
+			Issue:        id,
+			CurrentState: unknownState,
+			Comment:      cfg.AddReleaseComment && cfg.CommentMode != commentModeAssignee,
+			Links:        links,
+		}
+		issue, ok := issues[strings.ToUpper(id)]
+		var err error
+		if !ok {
+			issue, err = client.GetIssueByIdentifier(ctx, id)
+		}
+		if err == nil {
+			row.CurrentState = issue.State.Name
+		}
+		row.PlannedState = row.CurrentState
+		if cfg.UpdateLinkedIssues {
+			row.PlannedState = plannedState(cfg, id)
+		}
+
+		switch {
+		case err != nil:
+			row.Outcome, row.Reason = outcomeFail, fmt.Sprintf("cannot fetch issue: %v", err)
+		case plan == nil:
+			row.Outcome = outcomeUnknown
+		default:
+			tp := plan.stateTarget(issue, id)
+			row.Outcome, row.Reason = planTransition(cfg, tp, issue)
+			if tp != nil && tp.state != "" && cfg.UpdateLinkedIssues {
+				row.PlannedState = tp.state
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// planTransition returns the outcome of moving an issue to the released
+// state of its team plan, and why it is not a transition.
+func planTransition(cfg *Config, tp *teamPlan, issue *Issue) (string, string) {
+	switch {
+	case !cfg.UpdateLinkedIssues:
+		return outcomeUnchanged, "update_linked_issues is off"
+	case tp == nil:
+		return outcomeUnchanged, fmt.Sprintf("team %s is not configured", issueKey(issue))
+	case tp.state == "":
+		return outcomeUnchanged, "no released state configured"
+	case tp.stateID == "":
+		return outcomeFail, fmt.Sprintf("state '%s' not found in the workflow of team %s", tp.state, issueKey(issue))
+	case strings.EqualFold(issue.State.Name, tp.state):
+		return outcomeUnchanged, fmt.Sprintf("already in %s", issue.State.Name)
+	case cfg.keepsClosedState(issue):
+		return outcomeUnchanged, fmt.Sprintf("already %s in %s", issue.State.Type, issue.State.Name)
+	}
+	return outcomeTransition, ""
+}
+
+// planOutcomes counts the planned outcomes of the rows.
+func planOutcomes(rows []plannedIssue) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.Outcome]++
+	}
+	return counts
+}
+
+// renderPlanTable renders planned issue changes as a markdown table.
+func renderPlanTable(rows []plannedIssue) string {
+	var b strings.Builder
+	b.WriteString("| Issue | Current state | Planned state | Outcome | Comment | Links |\n")
+	b.WriteString("|-------|---------------|---------------|---------|---------|-------|\n")
+	for _, r := range rows {
+		outcome := r.Outcome
+		if r.Reason != "" {
+			outcome += ": " + r.Reason
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			r.Issue, r.CurrentState, r.PlannedState, outcome, yesNo(r.Comment), yesNo(r.Links))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// yesNo formats a boolean for plan tables.
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// plannedState names the released state of an issue's team.
+func plannedState(cfg *Config, issueID string) string {
+	key, _, _ := strings.Cut(issueID, "-")
+	for _, t := range cfg.Teams {
+		if strings.EqualFold(t.Key, key) && t.ReleasedState != "" {
+			return t.ReleasedState
+		}
+	}
+	return cfg.ReleasedState
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPlanLinkedIssues(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"name": "In Review"}},
+			"ENG-3": {"id": "uuid-3", "identifier": "ENG-3", "state": map[string]any{"name": "done"}},
+		}),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"released_state": "Done"})
+	plan := &linkedIssuePlan{primary: &teamPlan{teamKey: "ENG", state: "Done", stateID: "state-done"}}
+
+	rows := planLinkedIssues(context.Background(), fake.client().ReadOnly(), cfg,
+		plugin.ReleaseContext{Version: "1.0.0"}, plan, []string{"ENG-1", "ENG-2", "ENG-3"})
+
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	want := plannedIssue{Issue: "ENG-1", CurrentState: "In Review", PlannedState: "Done", Outcome: outcomeTransition, Comment: true}
+	if rows[0] != want {
+		t.Errorf("rows[0] = %+v, want %+v", rows[0], want)
+	}
+	if rows[1].CurrentState != unknownState || rows[1].Outcome != outcomeFail {
+		t.Errorf("expected a missing issue to fail, got %+v", rows[1])
+	}
+	if rows[2].Outcome != outcomeUnchanged || rows[2].Reason != "already in done" {
+		t.Errorf("expected an issue in the released state to stay unchanged, got %+v", rows[2])
+	}
+	if fake.callCount("UpdateIssueState") != 0 || fake.callCount("AddComment") != 0 {
+		t.Error("expected dry run planning to make no mutations")
+	}
+
+	// Without a plan of the teams, the outcome is not known
+	rows = planLinkedIssues(context.Background(), fake.client().ReadOnly(), cfg,
+		plugin.ReleaseContext{Version: "1.0.0"}, nil, []string{"ENG-1"})
+	if rows[0].Outcome != outcomeUnknown || rows[0].PlannedState != "Done" {
+		t.Errorf("rows[0] = %+v", rows[0])
+	}
+}
+
+func TestPlanTransition(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"released_state": "Done"})
+	issue := &Issue{Identifier: "OPS-1", State: State{Name: "Todo"}}
+
+	tests := []struct {
+		name string
+		tp   *teamPlan
+		want string
+	}{
+		{"transition", &teamPlan{state: "Done", stateID: "state-done"}, outcomeTransition},
+		{"state missing from the workflow", &teamPlan{state: "Done"}, outcomeFail},
+		{"team not configured", nil, outcomeUnchanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, reason := planTransition(cfg, tt.tp, issue); got != tt.want {
+				t.Errorf("planTransition() = %s (%s), want %s", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRunPostPublishVerifiesTransitions(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{
+				"id": "team-1", "key": "ENG",
+				"states": map[string]any{"nodes": []any{map[string]any{"id": "state-1", "name": "In Review"}}},
+			}}
+		},
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"name": "In Review"}},
+		}),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_id": "team-1", "released_state": "Done", "add_release_comment": false})
+	run := fake.run(cfg, plugin.ReleaseContext{
+		Version: "1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Fixes: []plugin.ConventionalCommit{{Hash: "abc", Type: "fix", Description: "Fix ENG-1 crash"}},
+		},
+	}, nil)
+	run.dryRun = true
+	if err := p.handlePostPublish(context.Background(), run); err != nil {
+		t.Fatalf("handlePostPublish() error = %v", err)
+	}
+
+	rows, _ := run.results.outputs["plan"].([]plannedIssue)
+	if len(rows) != 1 || rows[0].Outcome != outcomeFail || !strings.Contains(rows[0].Reason, "'Done' not found") {
+		t.Errorf("expected the missing released state to fail the issue, got %+v", rows)
+	}
+	var warned []string
+	for _, r := range run.results.results {
+		if r.Status == statusWarn {
+			warned = append(warned, r.Action)
+		}
+	}
+	if !slices.Contains(warned, "released_state") || !slices.Contains(warned, "linked_issues") {
+		t.Errorf("expected released_state and linked_issues warnings, got %v", warned)
+	}
+}
+
+func TestRenderPlanTable(t *testing.T) {
+	table := renderPlanTable([]plannedIssue{
+		{Issue: "ENG-1", CurrentState: "In Review", PlannedState: "Done", Outcome: outcomeTransition, Comment: true},
+		{Issue: "ENG-2", CurrentState: "Done", PlannedState: "Done", Outcome: outcomeUnchanged, Reason: "already in Done"},
+	})
+
+	lines := strings.Split(table, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header, separator and two rows, got %q", table)
+	}
+	if lines[2] != "| ENG-1 | In Review | Done | transition | yes | no |" {
+		t.Errorf("unexpected row %q", lines[2])
+	}
+	if lines[3] != "| ENG-2 | Done | Done | unchanged: already in Done | no | no |" {
+		t.Errorf("unexpected row %q", lines[3])
+	}
+}
+
+func TestDryRunEveryHook(t *testing.T) {
+	p := &LinearPlugin{}
+	config := map[string]any{"api_key": "lin_api_dry_run", "team_key": "ENG"}
+
+	for _, hook := range []plugin.Hook{plugin.HookPostPlan, plugin.HookOnSuccess, plugin.HookOnError, plugin.HookPreVersion} {
+		t.Run(string(hook), func(t *testing.T) {
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    hook,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !resp.Success {
+				t.Errorf("expected dry run to succeed, got %q", resp.Error)
+			}
+			if resp.Outputs["dry_run"] != true {
+				t.Error("expected the dry_run output")
+			}
+		})
+	}
+}
+
+func TestDryRunOnErrorDescribesPlan(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:   plugin.HookOnError,
+		Config: map[string]any{"api_key": "lin_api_dry_run", "team_key": "ENG"},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(resp.Message, "Would take no Linear action") {
+		t.Errorf("Message = %q", resp.Message)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// enrichReleaseNotes appends Linear issue titles to bare identifiers in the
+// release notes, turning "ENG-123" into "ENG-123: Fix pagination bug".
+// Identifiers are extracted and filtered as for linked issues, and looked
+// up in batches. Identifiers that are already followed by a colon are left
+// untouched.
+func enrichReleaseNotes(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, notes string) (string, []string) {
+	if notes == "" {
+		return notes, nil
+	}
+
+	pattern := cfg.issueRegexp()
+	var ids []string
+	for _, id := range extractIssuesMatching(pattern, []string{notes}, "") {
+		if issueFilterReason(cfg, id) == "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return notes, nil
+	}
+
+	issues, err := client.GetIssuesByIdentifiers(ctx, ids)
+	if err != nil {
+		return notes, []string{fmt.Sprintf("Could not fetch issue titles: %v", err)}
+	}
+
+	var errs []string
+	titles := make(map[string]string)
+	for _, id := range ids {
+		issue, ok := issues[id]
+		if !ok {
+			// Moved issues are only found, and missing ones only
+			// explained, by a single lookup
+			if issue, err = client.GetIssueByIdentifier(ctx, id); err != nil {
+				errs = append(errs, fmt.Sprintf("Could not fetch title for %s: %v", id, err))
+				continue
+			}
+		}
+		titles[id] = issue.Title
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range pattern.FindAllStringSubmatchIndex(notes, -1) {
+		start, end := loc[0], loc[1]
+		if len(loc) >= 4 && loc[2] >= 0 {
+			start, end = loc[2], loc[3]
+		}
+		title := titles[strings.ToUpper(notes[start:end])]
+		if title == "" || isLabelled(notes[end:]) {
+			continue
+		}
+		b.WriteString(notes[last:end])
+		b.WriteString(": ")
+		b.WriteString(title)
+		last = end
+	}
+	b.WriteString(notes[last:])
+
+	return b.String(), errs
+}
+
+// isLabelled reports whether the text following an identifier already
+// carries a label (e.g. "ENG-123: Some title").
+func isLabelled(rest string) bool {
+	return strings.HasPrefix(strings.TrimLeft(rest, " \t"), ":")
+}
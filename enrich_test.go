@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEnrichReleaseNotes(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-123": {"id": "uuid-123", "identifier": "ENG-123", "title": "Fix pagination bug in exports"},
+			"ENG-456": {"id": "uuid-456", "identifier": "ENG-456", "title": "Add CSV download"},
+		}),
+	})
+
+	cfg := (&LinearPlugin{}).parseConfig(map[string]any{"issue_prefix": "ENG"})
+	notes := "- Pagination fix (ENG-123)\n- ENG-456: Add CSV download\n- Unknown ENG-999"
+	got, errs := enrichReleaseNotes(context.Background(), fake.client().ReadOnly(), cfg, notes)
+
+	want := "- Pagination fix (ENG-123: Fix pagination bug in exports)\n- ENG-456: Add CSV download\n- Unknown ENG-999"
+	if got != want {
+		t.Errorf("enrichReleaseNotes() = %q, want %q", got, want)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected 1 warning for unknown issue, got %d: %v", len(errs), errs)
+	}
+	if n := fake.callCount("GetIssues"); n != 1 {
+		t.Errorf("expected one batched lookup, got %d", n)
+	}
+}
+
+func TestEnrichReleaseNotesFiltersIdentifiers(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "title": "Add export"},
+			"OPS-2": {"id": "uuid-2", "identifier": "OPS-2", "title": "Rotate keys"},
+		}),
+	})
+
+	// Several teams and no issue_prefix: standards such as UTF-8 are
+	// denylisted and issues of other teams are not linked
+	cfg := (&LinearPlugin{}).parseConfig(map[string]any{
+		"teams": []any{map[string]any{"key": "ENG"}},
+	})
+	notes := "- Add export ENG-1 with UTF-8 and SHA-256 checksums\n- OPS-2"
+	got, errs := enrichReleaseNotes(context.Background(), fake.client().ReadOnly(), cfg, notes)
+
+	want := "- Add export ENG-1: Add export with UTF-8 and SHA-256 checksums\n- OPS-2"
+	if got != want || len(errs) != 0 {
+		t.Errorf("enrichReleaseNotes() = %q, %v, want %q", got, errs, want)
+	}
+	if n := fake.callCount("GetIssue"); n != 0 {
+		t.Errorf("expected no single lookups, got %d", n)
+	}
+}
+
+func TestEnrichReleaseNotesIssuePattern(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-7": {"id": "uuid-7", "identifier": "ENG-7", "title": "Fix login"},
+		}),
+	})
+
+	cfg := (&LinearPlugin{}).parseConfig(map[string]any{"issue_pattern": `\[(ENG-\d+)\]`})
+	got, errs := enrichReleaseNotes(context.Background(), fake.client().ReadOnly(), cfg, "- Login fix [ENG-7], see ENG-8")
+
+	if want := "- Login fix [ENG-7: Fix login], see ENG-8"; got != want || len(errs) != 0 {
+		t.Errorf("enrichReleaseNotes() = %q, %v, want %q", got, errs, want)
+	}
+}
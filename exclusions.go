@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// issueExclusions holds the issues of the exclude_views custom views, which
+// no plugin action may touch.
+type issueExclusions struct {
+	ids         map[string]bool
+	identifiers map[string]bool
+}
+
+// loadExclusions collects the issues of the given custom views. A view that
+// cannot be read in full is an error, never a shorter list: callers must
+// not touch any issue without the complete exclusions.
+func loadExclusions(ctx context.Context, client *ReadOnlyLinearClient, viewIDs []string) (*issueExclusions, error) {
+	ex := &issueExclusions{ids: make(map[string]bool), identifiers: make(map[string]bool)}
+	for _, id := range viewIDs {
+		issues, err := client.GetCustomViewIssues(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load excluded issues from view %s: %w", id, err)
+		}
+		for _, issue := range issues {
+			ex.ids[issue.ID] = true
+			ex.identifiers[strings.ToUpper(issue.Identifier)] = true
+		}
+	}
+	return ex, nil
+}
+
+// excludes reports whether the issue, by ID or identifier, is excluded.
+func (ex *issueExclusions) excludes(id, identifier string) bool {
+	if ex == nil {
+		return false
+	}
+	return ex.ids[id] || ex.identifiers[strings.ToUpper(identifier)]
+}
+
+// filter splits identifiers into included and excluded ones.
+func (ex *issueExclusions) filter(identifiers []string) (included, excluded []string) {
+	for _, id := range identifiers {
+		if ex.excludes("", id) {
+			excluded = append(excluded, id)
+		} else {
+			included = append(included, id)
+		}
+	}
+	return included, excluded
+}
+
+type exclusionsKey struct{}
+
+// withExclusions attaches the exclusions of a run to ctx.
+func withExclusions(ctx context.Context, ex *issueExclusions) context.Context {
+	return context.WithValue(ctx, exclusionsKey{}, ex)
+}
+
+// exclusionsFrom returns the exclusions attached to ctx, if any.
+func exclusionsFrom(ctx context.Context) *issueExclusions {
+	ex, _ := ctx.Value(exclusionsKey{}).(*issueExclusions)
+	return ex
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLoadExclusions(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetCustomViewIssues": func(vars map[string]any) any {
+			nodes := map[string][]any{
+				"view-incidents": {map[string]any{"id": "uuid-2", "identifier": "ENG-2"}},
+				"view-security":  {map[string]any{"id": "uuid-9", "identifier": "SEC-9"}},
+			}
+			return map[string]any{"customView": map[string]any{"issues": map[string]any{"nodes": nodes[vars["id"].(string)]}}}
+		},
+	})
+
+	ex, err := loadExclusions(context.Background(), fake.client().ReadOnly(), []string{"view-incidents", "view-security"})
+	if err != nil {
+		t.Fatalf("loadExclusions() error = %v", err)
+	}
+
+	included, excluded := ex.filter([]string{"ENG-1", "eng-2", "SEC-9"})
+	if !reflect.DeepEqual(included, []string{"ENG-1"}) || !reflect.DeepEqual(excluded, []string{"eng-2", "SEC-9"}) {
+		t.Errorf("filter() = %v, %v", included, excluded)
+	}
+}
+
+func TestLoadExclusionsPaginates(t *testing.T) {
+	pages := [][]any{
+		{map[string]any{"id": "uuid-1", "identifier": "ENG-1"}},
+		{map[string]any{"id": "uuid-2", "identifier": "ENG-2"}},
+	}
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetCustomViewIssues": func(vars map[string]any) any {
+			return map[string]any{"customView": pagedHandler("issues", pages...)(vars)}
+		},
+	})
+
+	ex, err := loadExclusions(context.Background(), fake.client().ReadOnly(), []string{"view-incidents"})
+	if err != nil {
+		t.Fatalf("loadExclusions() error = %v", err)
+	}
+	if _, excluded := ex.filter([]string{"ENG-1", "ENG-2"}); len(excluded) != 2 {
+		t.Errorf("expected issues of every page excluded, got %v", excluded)
+	}
+	if n := fake.callCount("GetCustomViewIssues"); n != 2 {
+		t.Errorf("expected 2 page requests, got %d", n)
+	}
+}
+
+func TestLoadExclusionsFailsOnIncompleteView(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetCustomViewIssues": func(vars map[string]any) any {
+			if vars["after"] != nil {
+				return fakeErrors{{"message": "Internal error"}}
+			}
+			return map[string]any{"customView": pagedHandler("issues", []any{}, []any{})(vars)}
+		},
+	})
+
+	ex, err := loadExclusions(context.Background(), fake.client().ReadOnly(), []string{"view-incidents"})
+	if err == nil || ex != nil {
+		t.Fatalf("loadExclusions() = %v, %v, want an error for a partly read view", ex, err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("expected the page error, got %v", err)
+	}
+}
+
+func TestProcessLinkedIssuesSkipsExcludedIssues(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		// OLD-2 was moved to ENG-2, which is in an exclusion view
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"OLD-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+	ex := &issueExclusions{ids: map[string]bool{"uuid-2": true}, identifiers: map[string]bool{"ENG-2": true}}
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+	res := p.processLinkedIssues(withExclusions(context.Background(), ex), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"OLD-2"})
+
+	if !reflect.DeepEqual(res.Excluded, []string{"ENG-2"}) {
+		t.Errorf("Excluded = %v", res.Excluded)
+	}
+	if fake.callCount("AddComment") != 0 {
+		t.Error("expected excluded issues not to be commented on")
+	}
+}
+
+func TestPostPublishStopsOnIncompleteExclusions(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{"id": "user-1", "name": "Jane Doe"}}
+		},
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG"}}
+		},
+		"GetCustomViewIssues": func(vars map[string]any) any {
+			if vars["after"] != nil {
+				return fakeErrors{{"message": "Internal error"}}
+			}
+			return map[string]any{"customView": pagedHandler("issues", []any{}, []any{})(vars)}
+		},
+		"GetIssue":         issueHandler(map[string]map[string]any{"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"}}),
+		"UpdateIssueState": successHandler("issueUpdate"),
+		"AddComment":       successHandler("commentCreate"),
+	})
+	fake.register(t, "lin_api_exclusions_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"api_key":              "lin_api_exclusions_test",
+			"team_id":              "team-1",
+			"create_release_issue": false,
+			"exclude_views":        []any{"view-incidents"},
+		},
+		Context: plugin.ReleaseContext{
+			Version: "1.0.0",
+			Changes: &plugin.CategorizedChanges{
+				Fixes: []plugin.ConventionalCommit{{Hash: "abc1234", Description: "fix crash ENG-1"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Success {
+		t.Error("expected the hook to fail without the complete exclusions")
+	}
+	if n := fake.callCount("UpdateIssueState") + fake.callCount("AddComment"); n != 0 {
+		t.Errorf("expected no issue to be touched, got %d mutation(s)", n)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fakeLinear is an in-memory Linear API that dispatches requests to
+// handlers keyed by GraphQL operation name.
+type fakeLinear struct {
+	mu       sync.Mutex
+	handlers map[string]func(vars map[string]any) any
+	// handling serializes handler calls, so handlers may keep state
+	// while linked issues are processed concurrently.
+	handling sync.Mutex
+	calls    map[string][]map[string]any
+	server   *httptest.Server
+}
+
+// newFakeLinear starts a fake Linear server. Handlers return the "data"
+// payload for an operation; unknown operations produce a GraphQL error.
+// Batched issue lookups are answered from the GetIssue handler unless a
+// GetIssues handler is given.
+func newFakeLinear(t *testing.T, handlers map[string]func(vars map[string]any) any) *fakeLinear {
+	t.Helper()
+
+	if handlers["GetIssue"] != nil && handlers["GetIssues"] == nil {
+		handlers["GetIssues"] = batchIssueHandler(handlers["GetIssue"])
+	}
+
+	f := &fakeLinear{
+		handlers: handlers,
+		calls:    make(map[string][]map[string]any),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeLinear) serve(w http.ResponseWriter, r *http.Request) {
+	var req GraphQLRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	name := "anonymous"
+	if m := operationPattern.FindStringSubmatch(req.Query); m != nil {
+		name = m[2]
+	}
+
+	f.mu.Lock()
+	f.calls[name] = append(f.calls[name], req.Variables)
+	handler := f.handlers[name]
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if handler == nil {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"errors": []map[string]any{{"message": "unexpected operation " + name}},
+		})
+		return
+	}
+	f.handling.Lock()
+	data := handler(req.Variables)
+	f.handling.Unlock()
+	if errs, ok := data.(fakeErrors); ok {
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": errs})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+// batchIssueHandler answers GetIssues by looking up every issue of the
+// filter with a GetIssue handler. Like Linear, it only matches issues by
+// their current identifier and leaves out issues that fail to resolve.
+func batchIssueHandler(getIssue func(map[string]any) any) func(map[string]any) any {
+	return func(vars map[string]any) any {
+		filter, _ := vars["filter"].(map[string]any)
+		filters, _ := filter["or"].([]any)
+
+		nodes := []any{}
+		for _, f := range filters {
+			f, _ := f.(map[string]any)
+			key := f["team"].(map[string]any)["key"].(map[string]any)["eq"]
+			number := f["number"].(map[string]any)["eq"]
+			id := fmt.Sprintf("%v-%v", key, number)
+
+			data, ok := getIssue(map[string]any{"id": id}).(map[string]any)
+			if !ok {
+				continue
+			}
+			issue, ok := data["issue"].(map[string]any)
+			if !ok || !strings.EqualFold(fmt.Sprint(issue["identifier"]), id) {
+				continue
+			}
+			nodes = append(nodes, issue)
+		}
+		return map[string]any{"issues": map[string]any{"nodes": nodes}}
+	}
+}
+
+// fakeErrors can be returned by a handler to respond with GraphQL errors.
+type fakeErrors []map[string]any
+
+// client returns a LinearClient pointed at the fake server.
+func (f *fakeLinear) client() *LinearClient {
+	return &LinearClient{
+		endpoint:   f.server.URL,
+		apiKey:     "lin_api_test",
+		httpClient: http.DefaultClient,
+	}
+}
+
+// run returns a release run of the configuration against the fake server.
+func (f *fakeLinear) run(cfg *Config, release plugin.ReleaseContext, team *Team) *releaseRun {
+	return &releaseRun{cfg: cfg, release: release, client: f.client(), results: newResultCollector(), team: team}
+}
+
+// callCount returns how many times an operation was invoked.
+func (f *fakeLinear) callCount(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls[name])
+}
+
+// issueHandler serves GetIssue from a set of known issues.
+func issueHandler(issues map[string]map[string]any) func(map[string]any) any {
+	return func(vars map[string]any) any {
+		id, _ := vars["id"].(string)
+		issue, ok := issues[id]
+		if !ok {
+			return map[string]any{"issue": nil}
+		}
+		return map[string]any{"issue": issue}
+	}
+}
+
+// successHandler returns a handler reporting success for a mutation.
+func successHandler(field string) func(map[string]any) any {
+	return func(map[string]any) any {
+		return map[string]any{field: map[string]any{"success": true}}
+	}
+}
+
+// register makes the shared client for apiKey talk to the fake server, so
+// hooks run through Execute reach it.
+func (f *fakeLinear) register(t *testing.T, apiKey string) {
+	t.Helper()
+
+	client := f.client()
+	client.apiKey = apiKey
+	defaultRegistry.mu.Lock()
+	defaultRegistry.clients[clientKey{endpoint: linearAPIEndpoint, apiKey: apiKey}] = client
+	defaultRegistry.mu.Unlock()
+	t.Cleanup(defaultRegistry.Reset)
+}
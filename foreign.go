@@ -0,0 +1,72 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// issueForeignWorkspace classifies issues referenced by URL in another
+// Linear workspace than the one the API key belongs to.
+const issueForeignWorkspace = "foreign_workspace"
+
+// issueURLPattern matches Linear issue URLs, capturing the workspace URL key
+// and the identifier. The title slug is included so its words are not
+// mistaken for identifiers.
+var issueURLPattern = regexp.MustCompile(`(?i)\bhttps?://linear\.app/([\w-]+)/issue/([a-z][a-z0-9]*-\d+)[^\s)\]>]*`)
+
+// splitForeignIssues separates the identifiers the release's commits
+// reference only through issue URLs of workspaces other than urlKey. Such
+// an issue would at best not be found and at worst resolve to an unrelated
+// issue with the same identifier. Identifiers also mentioned outside such
+// URLs, or in branch names with branch_issues, are kept.
+func splitForeignIssues(cfg *Config, releaseCtx plugin.ReleaseContext, urlKey string, ids []string) (own, foreign []string) {
+	if urlKey == "" {
+		return ids, nil
+	}
+
+	referenced := make(map[string]bool)
+	var rest []string
+	for _, msg := range commitMessages(releaseCtx) {
+		rest = append(rest, issueURLPattern.ReplaceAllStringFunc(msg, func(url string) string {
+			m := issueURLPattern.FindStringSubmatch(url)
+			if strings.EqualFold(m[1], urlKey) {
+				return url
+			}
+			referenced[strings.ToUpper(m[2])] = true
+			return ""
+		}))
+	}
+	if len(referenced) == 0 {
+		return ids, nil
+	}
+
+	pattern := cfg.issueRegexp()
+	for _, id := range extractIssuesMatching(pattern, rest, "") {
+		delete(referenced, id)
+	}
+	if cfg.BranchIssues {
+		for _, id := range branchIssues(pattern, branchNames(releaseCtx)) {
+			delete(referenced, id)
+		}
+	}
+
+	for _, id := range ids {
+		if referenced[strings.ToUpper(id)] {
+			foreign = append(foreign, id)
+		} else {
+			own = append(own, id)
+		}
+	}
+	return own, foreign
+}
+
+// workspaceKey returns the URL key of the viewer's workspace, or "" when it
+// is unknown.
+func workspaceKey(viewer *Viewer) string {
+	if viewer == nil || viewer.Organization == nil {
+		return ""
+	}
+	return viewer.Organization.URLKey
+}
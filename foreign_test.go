@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSplitForeignIssues(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{
+		Branch: "feature/ENG-4-export",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{
+				{Description: "add export, see https://linear.app/partner/issue/ENG-1/eng-7-style-slug"},
+				{Description: "fix import (https://linear.app/acme/issue/ENG-2/import)"},
+				{Description: "port https://linear.app/partner/issue/ENG-3/sync from ENG-3"},
+				{Description: "track https://linear.app/partner/issue/ENG-4/export"},
+			},
+		},
+	}
+	ids := []string{"ENG-1", "ENG-2", "ENG-3", "ENG-4"}
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		urlKey      string
+		wantOwn     []string
+		wantForeign []string
+	}{
+		{"workspace unknown", nil, "", ids, nil},
+		{"foreign URLs skipped", nil, "acme", []string{"ENG-2", "ENG-3"}, []string{"ENG-1", "ENG-4"}},
+		{"branch keeps issue", map[string]any{"branch_issues": true}, "ACME", []string{"ENG-2", "ENG-3", "ENG-4"}, []string{"ENG-1"}},
+		{"other workspace", nil, "partner", []string{"ENG-1", "ENG-3", "ENG-4"}, []string{"ENG-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(tt.config)
+			own, foreign := splitForeignIssues(cfg, releaseCtx, tt.urlKey, ids)
+			if !reflect.DeepEqual(own, tt.wantOwn) || !reflect.DeepEqual(foreign, tt.wantForeign) {
+				t.Errorf("splitForeignIssues() = %v, %v, want %v, %v", own, foreign, tt.wantOwn, tt.wantForeign)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// FreezeWindow is a period in which releases are frozen, given as a date
+// range or as a cron expression matching the frozen minutes.
+type FreezeWindow struct {
+	Name     string `json:"name"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Cron     string `json:"cron,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// parseFreezeWindows parses the freeze_windows list.
+func parseFreezeWindows(raw map[string]any) []FreezeWindow {
+	list, _ := raw["freeze_windows"].([]any)
+	windows := make([]FreezeWindow, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		w := FreezeWindow{}
+		w.Name, _ = m["name"].(string)
+		w.From, _ = m["from"].(string)
+		w.To, _ = m["to"].(string)
+		w.Cron, _ = m["cron"].(string)
+		w.Timezone, _ = m["timezone"].(string)
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+// String names the window in messages.
+func (w FreezeWindow) String() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	if w.Cron != "" {
+		return w.Cron
+	}
+	return w.From + " to " + w.To
+}
+
+// location returns the time zone the window is given in, UTC by default.
+func (w FreezeWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// validate reports whether the window is a well-formed date range or cron
+// expression.
+func (w FreezeWindow) validate() error {
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", w.Timezone, err)
+	}
+	switch {
+	case w.Cron != "" && (w.From != "" || w.To != ""):
+		return fmt.Errorf("set either cron or from and to, not both")
+	case w.Cron != "":
+		_, err := parseCronSchedule(w.Cron)
+		return err
+	case w.From == "" || w.To == "":
+		return fmt.Errorf("set cron, or both from and to")
+	}
+	from, _, err := parseFreezeTime(w.From, time.UTC)
+	if err != nil {
+		return err
+	}
+	to, _, err := parseFreezeTime(w.To, time.UTC)
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("to %s is before from %s", w.To, w.From)
+	}
+	return nil
+}
+
+// contains reports whether t falls in the window. Date-only bounds cover
+// the whole day.
+func (w FreezeWindow) contains(t time.Time) bool {
+	loc, err := w.location()
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+
+	if w.Cron != "" {
+		schedule, err := parseCronSchedule(w.Cron)
+		return err == nil && schedule.matches(t)
+	}
+
+	from, _, err := parseFreezeTime(w.From, loc)
+	if err != nil {
+		return false
+	}
+	to, dateOnly, err := parseFreezeTime(w.To, loc)
+	if err != nil {
+		return false
+	}
+	if dateOnly {
+		to = to.AddDate(0, 0, 1)
+		return !t.Before(from) && t.Before(to)
+	}
+	return !t.Before(from) && !t.After(to)
+}
+
+// parseFreezeTime parses a window bound, a date (2006-01-02) or an RFC 3339
+// time, and reports whether it was a date.
+func parseFreezeTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time %q: use 2006-01-02 or RFC 3339", value)
+	}
+	return t, false, nil
+}
+
+// activeFreezeWindow returns the first freeze window containing t, if any.
+func (c *Config) activeFreezeWindow(t time.Time) *FreezeWindow {
+	for i := range c.FreezeWindows {
+		if c.FreezeWindows[i].contains(t) {
+			return &c.FreezeWindows[i]
+		}
+	}
+	return nil
+}
+
+// cronSchedule holds the values each field of a cron expression matches.
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// anyDay and anyWeekday record unrestricted day fields: as in cron, a
+	// time matches when either restricted day field matches.
+	anyDay, anyWeekday bool
+}
+
+// cronFields are the fields of a cron expression with their value ranges.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseCronSchedule parses a five-field cron expression (minute, hour, day
+// of month, month, day of week) of values, ranges, lists and steps.
+func parseCronSchedule(expr string) (cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: want %d fields", expr, len(cronFields))
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid cron expression %q: %s: %w", expr, cronFields[i].name, err)
+		}
+		sets[i] = set
+	}
+	// Sunday is 0 or 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return cronSchedule{
+		minutes: sets[0], hours: sets[1], days: sets[2], months: sets[3], weekdays: sets[4],
+		anyDay: fields[2] == "*", anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses one field of a cron expression into the set of
+// values it matches.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepText)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if valueRange != "*" {
+			first, last, isRange := strings.Cut(valueRange, "-")
+			var err error
+			if lo, err = strconv.Atoi(first); err != nil {
+				return 0, fmt.Errorf("invalid value %q", first)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid value %q", last)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// matches reports whether the minute of t matches the schedule.
+func (s cronSchedule) matches(t time.Time) bool {
+	if s.minutes&(1<<t.Minute()) == 0 || s.hours&(1<<t.Hour()) == 0 || s.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	day := s.days&(1<<t.Day()) != 0
+	weekday := s.weekdays&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// FreezeExceptionConfig controls how releases published during a freeze
+// window are escalated.
+type FreezeExceptionConfig struct {
+	Label       string   `json:"label"`
+	Subscribers []string `json:"subscribers,omitempty"`
+	Block       bool     `json:"block"`
+}
+
+// parseFreezeExceptionConfig parses the freeze_exception block.
+func parseFreezeExceptionConfig(raw map[string]any) FreezeExceptionConfig {
+	parser := helpers.NewConfigParser(raw)
+	return FreezeExceptionConfig{
+		Label:       parser.GetString("label", "", "freeze-exception"),
+		Subscribers: parser.GetStringSlice("subscribers", nil),
+		Block:       parser.GetBool("block", false),
+	}
+}
+
+// escalateFreezeException flags the release issue of a release published
+// during a freeze window: it adds the freeze exception label, subscribes
+// the configured subscribers and comments, which notifies them.
+func escalateFreezeException(ctx context.Context, run *releaseRun, issue *Issue, window *FreezeWindow) error {
+	cfg, client := run.cfg, run.client
+
+	if name := cfg.FreezeException.Label; name != "" && !issue.hasLabel(name) {
+		label, err := ensureLabel(ctx, client, run.team.ID, name)
+		if err != nil {
+			return err
+		}
+		if err := client.UpdateIssueLabels(ctx, issue.ID, append(issue.labelIDs(), label.ID)); err != nil {
+			return fmt.Errorf("failed to label %s: %w", issue.Identifier, err)
+		}
+	}
+
+	for _, subscriber := range cfg.FreezeException.Subscribers {
+		userID, err := resolveUser(ctx, client, "freeze exception subscriber", subscriber)
+		if err != nil {
+			return err
+		}
+		if err := client.SubscribeToIssue(ctx, issue.ID, userID); err != nil {
+			return fmt.Errorf("failed to subscribe %s to %s: %w", subscriber, issue.Identifier, err)
+		}
+	}
+
+	body := fmt.Sprintf("Release %s was published during the freeze window **%s** and is a freeze exception.", run.release.Version, window)
+	if err := client.AddComment(ctx, issue.ID, body); err != nil {
+		return fmt.Errorf("failed to comment on %s: %w", issue.Identifier, err)
+	}
+	return nil
+}
+
+// handlePrePublish gates the release on the freeze windows: a release in a
+// freeze window fails the hook with freeze_exception.block, and is
+// announced as a freeze exception otherwise.
+func (p *LinearPlugin) handlePrePublish(ctx context.Context, run *releaseRun) error {
+	cfg, rc := run.cfg, run.results
+	if len(cfg.FreezeWindows) == 0 {
+		rc.skip("freeze_window", "No freeze windows configured")
+		return nil
+	}
+	window := cfg.activeFreezeWindow(time.Now())
+	if window == nil {
+		rc.success("freeze_window", "Release %s is outside all freeze windows", run.release.Version)
+		return nil
+	}
+
+	rc.output("freeze_window", window.String())
+	switch {
+	case cfg.FreezeException.Block && run.dryRun:
+		rc.warn("freeze_window", "Would block release %s: freeze window %s is active", run.release.Version, window)
+	case cfg.FreezeException.Block:
+		rc.fail("freeze_window", "Release %s blocked: freeze window %s is active", run.release.Version, window)
+	default:
+		rc.warn("freeze_window", "Release %s falls in freeze window %s and will be flagged as a freeze exception", run.release.Version, window)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestFreezeWindowContains(t *testing.T) {
+	tests := []struct {
+		name   string
+		window FreezeWindow
+		at     string
+		want   bool
+	}{
+		{"inside dates", FreezeWindow{From: "2024-12-20", To: "2025-01-03"}, "2024-12-24T10:00:00Z", true},
+		{"last day covered", FreezeWindow{From: "2024-12-20", To: "2025-01-03"}, "2025-01-03T23:59:00Z", true},
+		{"after dates", FreezeWindow{From: "2024-12-20", To: "2025-01-03"}, "2025-01-04T00:00:00Z", false},
+		{"time range", FreezeWindow{From: "2024-06-01T18:00:00Z", To: "2024-06-01T20:00:00Z"}, "2024-06-01T20:00:01Z", false},
+		{"timezone", FreezeWindow{From: "2024-12-25", To: "2024-12-25", Timezone: "America/New_York"}, "2024-12-26T03:00:00Z", true},
+		{"friday afternoon", FreezeWindow{Cron: "* 14-23 * * 5"}, "2024-06-07T15:30:00Z", true},
+		{"friday morning", FreezeWindow{Cron: "* 14-23 * * 5"}, "2024-06-07T09:30:00Z", false},
+		{"sunday as 7", FreezeWindow{Cron: "* * * * 6-7"}, "2024-06-09T12:00:00Z", true},
+		{"day or weekday", FreezeWindow{Cron: "* * 1 * 1"}, "2024-06-03T12:00:00Z", true},
+		{"steps", FreezeWindow{Cron: "*/15 * * 12 *"}, "2024-12-02T12:30:00Z", true},
+		{"steps miss", FreezeWindow{Cron: "*/15 * * 12 *"}, "2024-12-02T12:31:00Z", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.window.validate(); err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			if got := tt.window.contains(at); got != tt.want {
+				t.Errorf("contains(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreezeWindowValidate(t *testing.T) {
+	invalid := []FreezeWindow{
+		{},
+		{From: "2024-12-20"},
+		{From: "2025-01-03", To: "2024-12-20"},
+		{From: "20.12.2024", To: "2025-01-03"},
+		{Cron: "* * * *"},
+		{Cron: "61 * * * *"},
+		{Cron: "* * * * 5", From: "2024-12-20", To: "2025-01-03"},
+		{Cron: "* * * * *", Timezone: "Mars/Olympus"},
+	}
+	for _, w := range invalid {
+		if err := w.validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", w)
+		}
+	}
+}
+
+// alwaysFrozen is a freeze window containing every release of the tests.
+var alwaysFrozen = []any{map[string]any{"name": "Year-end freeze", "cron": "* * * * *"}}
+
+func TestPrePublishInFreezeWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   bool
+		dryRun  bool
+		success bool
+	}{
+		{"escalate", false, false, true},
+		{"block", true, false, false},
+		{"block dry run", true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LinearPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:   plugin.HookPrePublish,
+				DryRun: tt.dryRun,
+				Config: map[string]any{
+					"api_key":          "lin_api_test",
+					"team_key":         "ENG",
+					"freeze_windows":   alwaysFrozen,
+					"freeze_exception": map[string]any{"block": tt.block},
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Success != tt.success {
+				t.Errorf("Success = %v, want %v (%s)", resp.Success, tt.success, resp.Message)
+			}
+			if resp.Outputs["freeze_window"] != "Year-end freeze" {
+				t.Errorf("freeze_window = %v", resp.Outputs["freeze_window"])
+			}
+		})
+	}
+}
+
+func TestEscalateFreezeException(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssueLabel": func(map[string]any) any {
+			return map[string]any{"issueLabels": map[string]any{"nodes": []any{}}}
+		},
+		"CreateIssueLabel": func(vars map[string]any) any {
+			return map[string]any{"issueLabelCreate": map[string]any{
+				"success":    true,
+				"issueLabel": map[string]any{"id": "label-freeze", "name": "freeze-exception"},
+			}}
+		},
+		"UpdateIssueLabels": successHandler("issueUpdate"),
+		"SubscribeToIssue":  successHandler("issueSubscribe"),
+		"AddComment":        successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"freeze_windows":   alwaysFrozen,
+		"freeze_exception": map[string]any{"subscribers": []any{"user-oncall"}},
+	})
+	run := fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{ID: "team-1"})
+	issue := &Issue{ID: "uuid-r", Identifier: "ENG-100", Labels: &Labels{Nodes: []Label{{ID: "label-release", Name: "release"}}}}
+
+	if err := escalateFreezeException(context.Background(), run, issue, cfg.activeFreezeWindow(time.Now())); err != nil {
+		t.Fatalf("escalateFreezeException() error = %v", err)
+	}
+
+	labels := fake.calls["UpdateIssueLabels"][0]["input"].(map[string]any)["labelIds"].([]any)
+	if len(labels) != 2 || labels[1] != "label-freeze" {
+		t.Errorf("labelIds = %v", labels)
+	}
+	if sub := fake.calls["SubscribeToIssue"][0]; sub["id"] != "uuid-r" || sub["userId"] != "user-oncall" {
+		t.Errorf("unexpected subscription %v", sub)
+	}
+	body, _ := fake.calls["AddComment"][0]["input"].(map[string]any)["body"].(string)
+	if !strings.Contains(body, "Year-end freeze") {
+		t.Errorf("unexpected comment %q", body)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "github.com/relicta-tech/relicta-plugin-sdk/helpers"
+
+// Linear issue priorities; lower non-zero values are more urgent.
+const (
+	priorityNone = 0
+	priorityLow  = 4
+)
+
+// PriorityGuardrail keeps release automation away from high-priority
+// issues, such as live incidents that happen to be referenced in commits.
+type PriorityGuardrail struct {
+	// Threshold protects issues at this priority or more urgent (1 = Urgent,
+	// 2 = High). Zero disables the guardrail.
+	Threshold int `json:"threshold"`
+	// Allow modifies protected issues anyway.
+	Allow bool `json:"allow"`
+}
+
+// parsePriorityGuardrail parses the priority_guardrail block.
+func parsePriorityGuardrail(raw map[string]any) PriorityGuardrail {
+	parser := helpers.NewConfigParser(raw)
+	return PriorityGuardrail{
+		Threshold: parser.GetInt("threshold", priorityNone),
+		Allow:     parser.GetBool("allow", false),
+	}
+}
+
+// protects reports whether the guardrail keeps the issue unmodified.
+func (g PriorityGuardrail) protects(issue *Issue) bool {
+	if g.Allow || g.Threshold == priorityNone || issue.Priority == priorityNone {
+		return false
+	}
+	return issue.Priority <= float64(g.Threshold)
+}
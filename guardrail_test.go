@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPriorityGuardrailProtects(t *testing.T) {
+	tests := []struct {
+		name     string
+		guard    PriorityGuardrail
+		priority float64
+		want     bool
+	}{
+		{"disabled", PriorityGuardrail{}, 1, false},
+		{"urgent protected", PriorityGuardrail{Threshold: 1}, 1, true},
+		{"high below urgent threshold", PriorityGuardrail{Threshold: 1}, 2, false},
+		{"high protected", PriorityGuardrail{Threshold: 2}, 2, true},
+		{"no priority", PriorityGuardrail{Threshold: 2}, 0, false},
+		{"allowed", PriorityGuardrail{Threshold: 2, Allow: true}, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.guard.protects(&Issue{Priority: tt.priority}); got != tt.want {
+				t.Errorf("protects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessLinkedIssuesSkipsProtectedIssues(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "priority": 1},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "priority": 3},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"priority_guardrail":   map[string]any{"threshold": 1},
+	})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+	if len(res.Protected) != 1 || res.Protected[0] != "ENG-1" {
+		t.Errorf("expected ENG-1 to be protected, got %v", res.Protected)
+	}
+	if fake.callCount("AddComment") != 1 {
+		t.Errorf("expected only ENG-2 to be commented, got %d", fake.callCount("AddComment"))
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// ProjectHealthConfig controls the post-release health snapshot of
+// project_id.
+type ProjectHealthConfig struct {
+	Enabled bool `json:"enabled"`
+	// AppendToUpdate adds the figures to the digest's project update.
+	AppendToUpdate bool `json:"append_to_update"`
+}
+
+// parseProjectHealthConfig parses the project_health block.
+func parseProjectHealthConfig(raw map[string]any) ProjectHealthConfig {
+	parser := helpers.NewConfigParser(raw)
+	return ProjectHealthConfig{
+		Enabled:        parser.GetBool("enabled", false),
+		AppendToUpdate: parser.GetBool("append_to_update", false),
+	}
+}
+
+// projectHealth counts a project's open and closed issues. Completed and
+// canceled issues are closed.
+type projectHealth struct {
+	Open     int     `json:"open"`
+	Closed   int     `json:"closed"`
+	Complete float64 `json:"percent_complete"`
+}
+
+// loadProjectHealth computes the health snapshot of a project.
+func loadProjectHealth(ctx context.Context, client *ReadOnlyLinearClient, projectID string) (*projectHealth, error) {
+	types, err := client.GetProjectIssueStates(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project issues: %w", err)
+	}
+
+	h := &projectHealth{}
+	for _, t := range types {
+		if t == "completed" || t == "canceled" {
+			h.Closed++
+		} else {
+			h.Open++
+		}
+	}
+	if total := h.Open + h.Closed; total > 0 {
+		h.Complete = float64(h.Closed*1000/total) / 10
+	}
+	return h, nil
+}
+
+// String summarizes the snapshot in one line.
+func (h *projectHealth) String() string {
+	return fmt.Sprintf("%d open, %d closed, %.1f%% complete", h.Open, h.Closed, h.Complete)
+}
+
+// markdown renders the snapshot as a section of a project update.
+func (h *projectHealth) markdown() string {
+	return fmt.Sprintf("## Project health\n\n- Open issues: %d\n- Closed issues: %d\n- Complete: %.1f%%", h.Open, h.Closed, h.Complete)
+}
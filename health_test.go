@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadProjectHealthPaginates(t *testing.T) {
+	// A first page of pageSize completed issues, then three open ones
+	var first, second []any
+	for range pageSize {
+		first = append(first, map[string]any{"state": map[string]any{"type": "completed"}})
+	}
+	for range 3 {
+		second = append(second, map[string]any{"state": map[string]any{"type": "started"}})
+	}
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetProjectIssueStates": func(vars map[string]any) any {
+			return map[string]any{"project": pagedHandler("issues", first, second)(vars)}
+		},
+	})
+
+	health, err := loadProjectHealth(context.Background(), fake.client().ReadOnly(), "project-1")
+	if err != nil {
+		t.Fatalf("loadProjectHealth() error = %v", err)
+	}
+	if health.Open != 3 || health.Closed != pageSize || health.Complete != 97 {
+		t.Errorf("health = %+v", health)
+	}
+	if n := fake.callCount("GetProjectIssueStates"); n != 2 {
+		t.Errorf("expected 2 page requests, got %d", n)
+	}
+}
+
+func TestLoadProjectHealth(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetProjectIssueStates": func(map[string]any) any {
+			var nodes []any
+			for _, typ := range []string{"completed", "completed", "canceled", "started", "unstarted", "backlog"} {
+				nodes = append(nodes, map[string]any{"state": map[string]any{"type": typ}})
+			}
+			return map[string]any{"project": map[string]any{"issues": map[string]any{"nodes": nodes}}}
+		},
+	})
+
+	health, err := loadProjectHealth(context.Background(), fake.client().ReadOnly(), "project-1")
+	if err != nil {
+		t.Fatalf("loadProjectHealth() error = %v", err)
+	}
+	if health.Open != 3 || health.Closed != 3 || health.Complete != 50 {
+		t.Errorf("health = %+v", health)
+	}
+	if got, want := health.String(), "3 open, 3 closed, 50.0% complete"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestProjectHealthEmptyProject(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetProjectIssueStates": func(map[string]any) any {
+			return map[string]any{"project": map[string]any{"issues": map[string]any{"nodes": []any{}}}}
+		},
+	})
+
+	health, err := loadProjectHealth(context.Background(), fake.client().ReadOnly(), "project-1")
+	if err != nil {
+		t.Fatalf("loadProjectHealth() error = %v", err)
+	}
+	if health.Complete != 0 {
+		t.Errorf("Complete = %v, want 0", health.Complete)
+	}
+}
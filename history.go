@@ -0,0 +1,75 @@
+package main
+
+import "context"
+
+// tracksReleases reports whether release history is read from the markers
+// on issue comments.
+func (c *Config) tracksReleases() bool {
+	return c.SkipPreviouslyReleased || c.PromotionComments || c.ReReleaseComments
+}
+
+// marksComments reports whether release comments carry a released marker.
+func (c *Config) marksComments() bool {
+	return c.tracksReleases() || c.EditReleaseComments
+}
+
+// releasedVersions returns the versions recorded in released markers of the
+// given kind on the issue's comments.
+func releasedVersions(ctx context.Context, client *LinearClient, issueID, kind string) ([]string, error) {
+	comments, err := client.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, c := range comments {
+		versions = append(versions, findMarkers(c.Body, kind)...)
+	}
+	return versions, nil
+}
+
+// latestVersion returns the highest of the versions, or "" if there are none.
+func latestVersion(versions []string) string {
+	var latest string
+	for _, v := range versions {
+		if latest == "" || compareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// promotedFrom returns the latest prerelease of current found in versions
+// when current is a stable release, e.g. "1.4.0-rc.2" for "1.4.0".
+func promotedFrom(versions []string, current string) string {
+	if isPrerelease(current) {
+		return ""
+	}
+
+	core, _ := splitVersion(current)
+	var from string
+	for _, v := range versions {
+		vCore, pre := splitVersion(v)
+		if pre == "" || compareVersions(joinCore(vCore), joinCore(core)) != 0 {
+			continue
+		}
+		if from == "" || compareVersions(v, from) > 0 {
+			from = v
+		}
+	}
+	return from
+}
+
+// renderPromotionComment renders the promotion comment for an issue,
+// including the release marker.
+func renderPromotionComment(ctx context.Context, run *releaseRun, from string) (string, error) {
+	cfg := run.cfg
+	data := run.templateData(ctx, cfg.PromotionTemplate)
+	data.PromotedFrom = from
+
+	comment, err := renderTemplateData(cfg.PromotionTemplate, data, cfg.TemplatePartials)
+	if err != nil {
+		return "", err
+	}
+	return appendMarker(comment, cfg.Naming.marker(markerReleased), run.release.Version), nil
+}
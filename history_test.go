@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPromotedFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		current  string
+		want     string
+	}{
+		{"latest prerelease", []string{"1.4.0-rc.1", "1.4.0-rc.2"}, "1.4.0", "1.4.0-rc.2"},
+		{"other core version", []string{"1.3.0-rc.1"}, "1.4.0", ""},
+		{"stable only", []string{"1.3.0"}, "1.4.0", ""},
+		{"current is prerelease", []string{"1.4.0-rc.1"}, "1.4.0-rc.2", ""},
+		{"no history", nil, "1.4.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := promotedFrom(tt.versions, tt.current); got != tt.want {
+				t.Errorf("promotedFrom(%v, %q) = %q, want %q", tt.versions, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	if got := latestVersion([]string{"1.2.0", "1.10.0", "1.4.0-rc.1"}); got != "1.10.0" {
+		t.Errorf("expected 1.10.0, got %q", got)
+	}
+	if got := latestVersion(nil); got != "" {
+		t.Errorf("expected empty version, got %q", got)
+	}
+}
+
+func TestProcessLinkedIssuesPromotesPrereleases(t *testing.T) {
+	comments := make(map[string]string)
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"GetIssueComments": func(vars map[string]any) any {
+			var nodes []map[string]any
+			if vars["id"] == "uuid-1" {
+				nodes = append(nodes,
+					map[string]any{"id": "c1", "body": "`relicta:released=1.4.0-rc.1`"},
+					map[string]any{"id": "c2", "body": "`relicta:released=1.4.0-rc.2`"},
+				)
+			}
+			return map[string]any{"issue": map[string]any{"comments": map[string]any{"nodes": nodes}}}
+		},
+		"AddComment": func(vars map[string]any) any {
+			input := vars["input"].(map[string]any)
+			comments[input["issueId"].(string)] = input["body"].(string)
+			return map[string]any{"commentCreate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":     false,
+		"skip_previously_released": true,
+		"promotion_comments":       true,
+		"promotion_template":       "Promoted from {{.PromotedFrom}} to {{.Version}} by {{.Team}}",
+	})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.4.0"}, &Team{Name: "Engineering"}), []string{"ENG-1", "ENG-2"})
+
+	if res.Promoted["ENG-1"] != "1.4.0-rc.2" {
+		t.Errorf("expected ENG-1 promoted from 1.4.0-rc.2, got %v", res.Promoted)
+	}
+	if len(res.PreviouslyReleased) != 0 {
+		t.Errorf("expected promoted issue not to be skipped, got %v", res.PreviouslyReleased)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(comments))
+	}
+	if !strings.Contains(comments["uuid-1"], "Promoted from 1.4.0-rc.2 to 1.4.0 by Engineering") {
+		t.Errorf("expected promotion comment, got %q", comments["uuid-1"])
+	}
+	if !strings.Contains(comments["uuid-1"], formatMarker(markerReleased, "1.4.0")) {
+		t.Errorf("expected promotion comment to carry the release marker, got %q", comments["uuid-1"])
+	}
+	if strings.Contains(comments["uuid-2"], "Promoted") {
+		t.Errorf("expected regular comment for ENG-2, got %q", comments["uuid-2"])
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Optional is an input field with three states: unset fields are omitted,
+// cleared fields are sent as null, and set fields are sent with their
+// value, even a zero value such as priority 0 (no priority). Fields using
+// it are tagged omitzero.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+type optionalState uint8
+
+const (
+	optionalUnset optionalState = iota
+	optionalSet
+	optionalNull
+)
+
+// Set returns a field sent with value.
+func Set[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalSet}
+}
+
+// Clear returns a field sent as null, clearing it in Linear.
+func Clear[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// IsZero reports whether the field is unset, so omitzero omits it.
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionalUnset
+}
+
+// MarshalJSON encodes the value, or null for a cleared field.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// The input types below mirror Linear's GraphQL input types of the same
+// name, restricted to the fields the plugin sets. Required fields are plain
+// values; optional ones are omitted when unset, and those an update may
+// clear or set to a zero value use Optional.
+
+// CreateIssueInput is Linear's IssueCreateInput. ID is generated when
+// empty.
+type CreateIssueInput struct {
+	ID          string `json:"id,omitempty"`
+	TeamID      string `json:"teamId"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// Priority is 0 (none), 1 (urgent) to 4 (low); unset leaves it to
+	// Linear or the template.
+	Priority   Optional[int] `json:"priority,omitzero"`
+	ProjectID  string        `json:"projectId,omitempty"`
+	AssigneeID string        `json:"assigneeId,omitempty"`
+	// DueDate is a calendar date such as "2024-01-31".
+	DueDate string `json:"dueDate,omitempty"`
+	// TemplateID applies an issue template; the other fields override
+	// the template's values.
+	TemplateID string `json:"templateId,omitempty"`
+}
+
+// IssueUpdateInput is Linear's IssueUpdateInput. Only the fields set are
+// changed; cleared fields are removed from the issue.
+type IssueUpdateInput struct {
+	StateID     string           `json:"stateId,omitempty"`
+	Description Optional[string] `json:"description,omitzero"`
+	Priority    Optional[int]    `json:"priority,omitzero"`
+	AssigneeID  Optional[string] `json:"assigneeId,omitzero"`
+	Estimate    Optional[int]    `json:"estimate,omitzero"`
+	DueDate     Optional[string] `json:"dueDate,omitzero"`
+	// LabelIDs replaces the labels; an empty, non-nil list removes all.
+	LabelIDs           []string         `json:"labelIds,omitzero"`
+	ProjectID          Optional[string] `json:"projectId,omitzero"`
+	ProjectMilestoneID Optional[string] `json:"projectMilestoneId,omitzero"`
+	CycleID            Optional[string] `json:"cycleId,omitzero"`
+}
+
+// CommentCreateInput is Linear's CommentCreateInput.
+type CommentCreateInput struct {
+	IssueID string `json:"issueId"`
+	Body    string `json:"body"`
+	// ParentID makes the comment a reply in the parent's thread.
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// CommentUpdateInput is Linear's CommentUpdateInput.
+type CommentUpdateInput struct {
+	Body string `json:"body"`
+}
+
+// AttachmentInput is Linear's AttachmentCreateInput.
+type AttachmentInput struct {
+	IssueID  string `json:"issueId"`
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	IconURL  string `json:"iconUrl,omitempty"`
+}
+
+// IssueRelationCreateInput is Linear's IssueRelationCreateInput.
+type IssueRelationCreateInput struct {
+	IssueID        string `json:"issueId"`
+	RelatedIssueID string `json:"relatedIssueId"`
+	// Type is blocks, duplicate, related or similar.
+	Type string `json:"type"`
+}
+
+// LabelInput is Linear's IssueLabelCreateInput.
+type LabelInput struct {
+	Name     string `json:"name"`
+	TeamID   string `json:"teamId"`
+	ParentID string `json:"parentId,omitempty"`
+	IsGroup  bool   `json:"isGroup,omitempty"`
+}
+
+// IssueLabelUpdateInput is Linear's IssueLabelUpdateInput.
+type IssueLabelUpdateInput struct {
+	Name string `json:"name"`
+}
+
+// ProjectMilestoneCreateInput is Linear's ProjectMilestoneCreateInput.
+type ProjectMilestoneCreateInput struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+// ProjectUpdateCreateInput is Linear's ProjectUpdateCreateInput.
+type ProjectUpdateCreateInput struct {
+	ProjectID string `json:"projectId"`
+	Body      string `json:"body"`
+}
+
+// DocumentCreateInput is Linear's DocumentCreateInput.
+type DocumentCreateInput struct {
+	ProjectID string `json:"projectId"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+}
+
+// encodeVariables returns the variables with typed inputs encoded as the
+// JSON objects Linear receives, so the read-only guard, the canary budget
+// and debug logging inspect exactly what is sent.
+func encodeVariables(variables map[string]any) (map[string]any, error) {
+	if variables == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variables: %w", err)
+	}
+	var encoded map[string]any
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode variables: %w", err)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTypedInputsSendZeroValues(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"CreateIssue": func(map[string]any) any {
+			return map[string]any{"issueCreate": map[string]any{
+				"success": true,
+				"issue":   map[string]any{"id": "uuid-1", "identifier": "ENG-1"},
+			}}
+		},
+		"UpdateIssueDescription": successHandler("issueUpdate"),
+		"UpdateIssueLabels":      successHandler("issueUpdate"),
+	})
+	client := fake.client()
+	ctx := context.Background()
+
+	if _, err := client.CreateIssue(ctx, CreateIssueInput{TeamID: "team-1", Title: "Release 1.0.0", Priority: Set(priorityNone)}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if _, err := client.CreateIssue(ctx, CreateIssueInput{TeamID: "team-1", Title: "Release 1.0.1"}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if err := client.UpdateIssueDescription(ctx, "uuid-1", ""); err != nil {
+		t.Fatalf("UpdateIssueDescription() error = %v", err)
+	}
+	if err := client.UpdateIssueLabels(ctx, "uuid-1", nil); err != nil {
+		t.Fatalf("UpdateIssueLabels() error = %v", err)
+	}
+
+	input := func(op string, i int) map[string]any {
+		return fake.calls[op][i]["input"].(map[string]any)
+	}
+	if p, ok := input("CreateIssue", 0)["priority"]; !ok || p != float64(0) {
+		t.Errorf("expected priority 0 (none) to be sent, got %v", input("CreateIssue", 0))
+	}
+	if _, ok := input("CreateIssue", 1)["priority"]; ok {
+		t.Errorf("expected no priority without one set, got %v", input("CreateIssue", 1))
+	}
+	if d, ok := input("UpdateIssueDescription", 0)["description"]; !ok || d != "" {
+		t.Errorf("expected an empty description to be sent, got %v", input("UpdateIssueDescription", 0))
+	}
+	if labels, ok := input("UpdateIssueLabels", 0)["labelIds"].([]any); !ok || len(labels) != 0 {
+		t.Errorf("expected an empty label list to be sent, got %v", input("UpdateIssueLabels", 0))
+	}
+}
+
+func TestEncodeVariables(t *testing.T) {
+	vars, err := encodeVariables(map[string]any{
+		"id":    "uuid-1",
+		"input": IssueUpdateInput{StateID: "state-1"},
+	})
+	if err != nil {
+		t.Fatalf("encodeVariables() error = %v", err)
+	}
+	input, ok := vars["input"].(map[string]any)
+	if !ok || input["stateId"] != "state-1" || len(input) != 1 {
+		t.Errorf("expected the input encoded as its JSON object, got %v", vars)
+	}
+
+	// Generic checks see the encoded input
+	vars, _ = encodeVariables(map[string]any{"input": CommentCreateInput{IssueID: "uuid-2", Body: "Released"}})
+	if got := describeMutation(`mutation AddComment($input: CommentCreateInput!) { x }`, vars); got != "AddComment(uuid-2)" {
+		t.Errorf("describeMutation() = %q", got)
+	}
+}
+
+func TestOptionalFields(t *testing.T) {
+	data, err := json.Marshal(IssueUpdateInput{
+		Priority:   Set(priorityNone),
+		AssigneeID: Clear[string](),
+		CycleID:    Set(""),
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"priority":0,"assigneeId":null,"cycleId":""}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	// Unset fields are omitted entirely
+	if data, _ := json.Marshal(IssueUpdateInput{StateID: "state-1"}); string(data) != `{"stateId":"state-1"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestUpdateIssueSendsOneMutation(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"UpdateIssue": successHandler("issueUpdate"),
+	})
+	client := fake.client()
+	ctx := context.Background()
+
+	err := client.UpdateIssue(ctx, "uuid-1", IssueUpdateInput{
+		LabelIDs:   []string{"label-1"},
+		AssigneeID: Clear[string](),
+		ProjectID:  Set("proj-1"),
+		Estimate:   Set(0),
+		DueDate:    Set("2026-11-01"),
+	})
+	if err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if n := fake.callCount("UpdateIssue"); n != 1 {
+		t.Fatalf("expected one mutation, got %d", n)
+	}
+	call := fake.calls["UpdateIssue"][0]
+	input := call["input"].(map[string]any)
+	if call["id"] != "uuid-1" || input["projectId"] != "proj-1" || input["dueDate"] != "2026-11-01" {
+		t.Errorf("unexpected variables %v", call)
+	}
+	if a, ok := input["assigneeId"]; !ok || a != nil {
+		t.Errorf("expected the assignee to be cleared, got %v", input)
+	}
+	if e, ok := input["estimate"]; !ok || e != float64(0) {
+		t.Errorf("expected estimate 0 to be sent, got %v", input)
+	}
+	if _, ok := input["stateId"]; ok {
+		t.Errorf("expected no state change, got %v", input)
+	}
+
+	// An empty update sends nothing
+	if err := client.UpdateIssue(ctx, "uuid-1", IssueUpdateInput{}); err != nil || fake.callCount("UpdateIssue") != 1 {
+		t.Errorf("UpdateIssue() with no changes: err = %v, calls = %d", err, fake.callCount("UpdateIssue"))
+	}
+}
+
+func TestUpdateIssueFailure(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"UpdateIssueCycle": func(map[string]any) any {
+			return map[string]any{"issueUpdate": map[string]any{"success": false}}
+		},
+	})
+	err := fake.client().UpdateIssueCycle(context.Background(), "uuid-1", "cycle-1")
+	if err == nil || err.Error() != "failed to update issue cycle" {
+		t.Errorf("UpdateIssueCycle() error = %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// versionPlaceholder stands in for the version when rendering
+// version_label_template into a pattern.
+const versionPlaceholder = "\x00"
+
+// versionLabelPattern recognises labels rendered from
+// version_label_template: the rendered text around the version.
+type versionLabelPattern struct {
+	prefix, suffix string
+}
+
+// newVersionLabelPattern derives the pattern of version labels from
+// version_label_template. The template may use other fields, but labels
+// only match if those render the same for every release.
+func newVersionLabelPattern(cfg *Config) (versionLabelPattern, error) {
+	if cfg.VersionLabelTemplate == "" {
+		return versionLabelPattern{}, fmt.Errorf("version_label_template is not set")
+	}
+	rendered, err := renderTemplateData(cfg.VersionLabelTemplate, templateData{Version: versionPlaceholder}, cfg.TemplatePartials)
+	if err != nil {
+		return versionLabelPattern{}, fmt.Errorf("failed to render version label template: %w", err)
+	}
+	rendered = strings.TrimSpace(rendered)
+	if strings.Count(rendered, versionPlaceholder) != 1 {
+		return versionLabelPattern{}, fmt.Errorf("version_label_template must use {{.Version}} exactly once")
+	}
+	prefix, suffix, _ := strings.Cut(rendered, versionPlaceholder)
+	return versionLabelPattern{prefix: prefix, suffix: suffix}, nil
+}
+
+// version returns the version a label name was rendered from.
+func (p versionLabelPattern) version(name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, p.prefix)
+	if !ok {
+		return "", false
+	}
+	version, ok := strings.CutSuffix(rest, p.suffix)
+	if !ok || !isVersion(version) {
+		return "", false
+	}
+	return version, true
+}
+
+// isVersion reports whether s is a version such as 1.2.3, v2.0 or
+// 1.0.0-rc.1.
+func isVersion(s string) bool {
+	core := strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	if core == "" {
+		return false
+	}
+	for _, part := range strings.Split(core, ".") {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// staleVersionLabel is a version label older than the releases kept.
+type staleVersionLabel struct {
+	Label   Label
+	Version string
+}
+
+// staleVersionLabels returns the version labels of all but the newest keep
+// versions, oldest first. With a group, only labels in that group count.
+func staleVersionLabels(labels []Label, pattern versionLabelPattern, group string, keep int) []staleVersionLabel {
+	var found []staleVersionLabel
+	for _, l := range labels {
+		if l.IsGroup {
+			continue
+		}
+		if group != "" && (l.Parent == nil || !strings.EqualFold(l.Parent.Name, group)) {
+			continue
+		}
+		if version, ok := pattern.version(l.Name); ok {
+			found = append(found, staleVersionLabel{Label: l, Version: version})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return compareVersions(found[i].Version, found[j].Version) < 0
+	})
+
+	// Keep the labels of the newest versions; a version may have several
+	// labels, such as 1.2.0 and v1.2.0
+	kept := 0
+	end := len(found)
+	for end > 0 && kept < keep {
+		v := found[end-1].Version
+		for end > 0 && compareVersions(found[end-1].Version, v) == 0 {
+			end--
+		}
+		kept++
+	}
+	return found[:end]
+}
+
+// runPruneLabels deletes or renames the version labels of all but the
+// newest releases, since a label per version soon clutters the team's label
+// picker.
+func runPruneLabels(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("prune-labels", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "path to a JSON file with the plugin configuration")
+	keep := fs.Int("keep", 10, "number of most recent versions whose labels are kept")
+	rename := fs.String("rename", "", "rename old labels with this template instead of deleting them, e.g. \"archived/{{.Version}}\"")
+	dryRun := fs.Bool("dry-run", false, "report what would be done without changing Linear")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *keep < 0 {
+		_, _ = fmt.Fprintln(stderr, "prune-labels: --keep must not be negative")
+		return 2
+	}
+
+	raw, err := loadConfigFile(*configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "prune-labels: %v\n", err)
+		return 1
+	}
+	cfg := (&LinearPlugin{}).parseConfig(raw)
+	pattern, err := newVersionLabelPattern(cfg)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "prune-labels: %v\n", err)
+		return 1
+	}
+	if !cfg.hasCredentials() {
+		_, _ = fmt.Fprintln(stderr, "prune-labels: api_key is required")
+		return 1
+	}
+
+	ctx := context.Background()
+	client := cfg.client()
+	team, err := client.GetTeam(ctx, cfg.TeamID, cfg.TeamKey)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "prune-labels: failed to get team: %v\n", err)
+		return 1
+	}
+	labels, err := client.ListTeamLabels(ctx, team.ID)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "prune-labels: failed to list labels: %v\n", err)
+		return 1
+	}
+
+	stale := staleVersionLabels(labels, pattern, cfg.VersionLabelGroup, *keep)
+	if len(stale) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No version labels to prune")
+		return 0
+	}
+
+	failed := false
+	for _, s := range stale {
+		if *rename == "" {
+			if *dryRun {
+				_, _ = fmt.Fprintf(stdout, "%s: would delete\n", s.Label.Name)
+				continue
+			}
+			if err := client.DeleteIssueLabel(ctx, s.Label.ID); err != nil {
+				failed = true
+				_, _ = fmt.Fprintf(stdout, "%s: error: %v\n", s.Label.Name, err)
+				continue
+			}
+			_, _ = fmt.Fprintf(stdout, "%s: deleted\n", s.Label.Name)
+			continue
+		}
+
+		name, err := renderTemplate(*rename, plugin.ReleaseContext{Version: s.Version}, cfg.TemplatePartials)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "prune-labels: failed to render --rename: %v\n", err)
+			return 1
+		}
+		if name = strings.TrimSpace(name); name == "" {
+			_, _ = fmt.Fprintln(stderr, "prune-labels: --rename rendered an empty name")
+			return 1
+		}
+		if name == s.Label.Name {
+			continue
+		}
+		if *dryRun {
+			_, _ = fmt.Fprintf(stdout, "%s: would rename to %s\n", s.Label.Name, name)
+			continue
+		}
+		if err := client.RenameIssueLabel(ctx, s.Label.ID, name); err != nil {
+			failed = true
+			_, _ = fmt.Fprintf(stdout, "%s: error: %v\n", s.Label.Name, err)
+			continue
+		}
+		_, _ = fmt.Fprintf(stdout, "%s: renamed to %s\n", s.Label.Name, name)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVersionLabelPattern(t *testing.T) {
+	p := &LinearPlugin{}
+	pattern, err := newVersionLabelPattern(p.parseConfig(map[string]any{"version_label_template": "release/v{{.Version}}"}))
+	if err != nil {
+		t.Fatalf("newVersionLabelPattern() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		ok      bool
+	}{
+		{"release/v1.2.0", "1.2.0", true},
+		{"release/v2.0.0-rc.1", "2.0.0-rc.1", true},
+		{"release/vnext", "", false},
+		{"release/v", "", false},
+		{"bug", "", false},
+	}
+	for _, tt := range tests {
+		version, ok := pattern.version(tt.name)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("version(%q) = %q, %v", tt.name, version, ok)
+		}
+	}
+
+	for _, tmpl := range []string{"", "release", "{{.Version}}-{{.Version}}"} {
+		if _, err := newVersionLabelPattern(p.parseConfig(map[string]any{"version_label_template": tmpl})); err == nil {
+			t.Errorf("expected an error for template %q", tmpl)
+		}
+	}
+}
+
+func TestStaleVersionLabels(t *testing.T) {
+	group := &Label{ID: "group-1", Name: "Releases"}
+	labels := []Label{
+		{ID: "l1", Name: "1.10.0", Parent: group},
+		{ID: "l2", Name: "1.2.0", Parent: group},
+		{ID: "l3", Name: "1.9.0", Parent: group},
+		{ID: "l4", Name: "1.0.0", Parent: group},
+		{ID: "l5", Name: "0.9.0"},
+		{ID: "l6", Name: "bug", Parent: group},
+		{ID: "group-1", Name: "Releases", IsGroup: true},
+	}
+	pattern := versionLabelPattern{}
+
+	stale := staleVersionLabels(labels, pattern, "releases", 2)
+	var names []string
+	for _, s := range stale {
+		names = append(names, s.Label.Name)
+	}
+	if got := strings.Join(names, ","); got != "1.0.0,1.2.0" {
+		t.Errorf("stale labels = %s, want 1.0.0,1.2.0", got)
+	}
+
+	if stale := staleVersionLabels(labels, pattern, "", 2); len(stale) != 3 {
+		t.Errorf("without a group expected 3 stale labels, got %d", len(stale))
+	}
+	if stale := staleVersionLabels(labels, pattern, "", 10); len(stale) != 0 {
+		t.Errorf("expected all labels to be kept, got %d stale", len(stale))
+	}
+}
+
+func TestRunPruneLabels(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG"}}
+		},
+		"ListTeamLabels": pagedHandler("issueLabels",
+			[]any{
+				map[string]any{"id": "l1", "name": "v1.0.0"},
+				map[string]any{"id": "l2", "name": "v1.1.0"},
+			},
+			[]any{
+				map[string]any{"id": "l3", "name": "v1.2.0"},
+				map[string]any{"id": "l4", "name": "needs-review"},
+			},
+		),
+		"DeleteIssueLabel": successHandler("issueLabelDelete"),
+		"RenameIssueLabel": successHandler("issueLabelUpdate"),
+	})
+	fake.register(t, "lin_api_prune")
+
+	config := filepath.Join(t.TempDir(), "linear.json")
+	data := `{"api_key": "lin_api_prune", "team_id": "team-1", "version_label_template": "v{{.Version}}"}`
+	if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runPruneLabels([]string{"--config", config, "--keep", "1", "--dry-run"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("dry run exit code = %d, stderr = %s", code, stderr.String())
+	}
+	if fake.callCount("DeleteIssueLabel") != 0 || !strings.Contains(stdout.String(), "v1.0.0: would delete") {
+		t.Errorf("unexpected dry run: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runPruneLabels([]string{"--config", config, "--keep", "1"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	deleted := fake.calls["DeleteIssueLabel"]
+	if len(deleted) != 2 || deleted[0]["id"] != "l1" || deleted[1]["id"] != "l2" {
+		t.Errorf("unexpected deletions %v", deleted)
+	}
+
+	stdout.Reset()
+	if code := runPruneLabels([]string{"--config", config, "--keep", "2", "--rename", "archived/{{.Version}}"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("rename exit code = %d, stderr = %s", code, stderr.String())
+	}
+	renamed := fake.calls["RenameIssueLabel"]
+	if len(renamed) != 1 || renamed[0]["id"] != "l1" || renamed[0]["input"].(map[string]any)["name"] != "archived/1.0.0" {
+		t.Errorf("unexpected renames %v", renamed)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// ensureVersionLabel finds or creates the label rendered from
+// version_label_template, inside version_label_group when one is set.
+func ensureVersionLabel(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, team *Team) (*Label, error) {
+	name, err := renderTemplate(cfg.VersionLabelTemplate, releaseCtx, cfg.TemplatePartials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render version label: %w", err)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("version label template rendered an empty name")
+	}
+
+	label, err := client.FindIssueLabel(ctx, team.ID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up label %s: %w", name, err)
+	}
+	if label != nil {
+		return label, nil
+	}
+
+	input := LabelInput{Name: name, TeamID: team.ID}
+	if cfg.VersionLabelGroup != "" {
+		group, err := ensureLabelGroup(ctx, client, team, cfg.VersionLabelGroup)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentID = group.ID
+	}
+
+	label, err = client.CreateIssueLabel(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label %s: %w", name, err)
+	}
+	return label, nil
+}
+
+// ensureLabelGroup finds or creates a team label group.
+func ensureLabelGroup(ctx context.Context, client *LinearClient, team *Team, name string) (*Label, error) {
+	group, err := client.FindIssueLabel(ctx, team.ID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up label group %s: %w", name, err)
+	}
+	if group != nil {
+		if !group.IsGroup {
+			return nil, fmt.Errorf("label %s exists but is not a group", name)
+		}
+		return group, nil
+	}
+
+	group, err = client.CreateIssueLabel(ctx, LabelInput{Name: name, TeamID: team.ID, IsGroup: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label group %s: %w", name, err)
+	}
+	return group, nil
+}
+
+// ensureLabel finds or creates a team label.
+func ensureLabel(ctx context.Context, client *LinearClient, teamID, name string) (*Label, error) {
+	label, err := client.FindIssueLabel(ctx, teamID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up label %s: %w", name, err)
+	}
+	if label != nil {
+		return label, nil
+	}
+
+	label, err = client.CreateIssueLabel(ctx, LabelInput{Name: name, TeamID: teamID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label %s: %w", name, err)
+	}
+	return label, nil
+}
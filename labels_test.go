@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestEnsureVersionLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   map[string]map[string]any
+		group      string
+		wantCreate []map[string]any
+	}{
+		{
+			name:     "existing label",
+			existing: map[string]map[string]any{"released/1.2.0": {"id": "label-1", "name": "released/1.2.0", "team": map[string]any{"id": "team-1"}}},
+		},
+		{
+			name:       "missing label",
+			existing:   map[string]map[string]any{},
+			wantCreate: []map[string]any{{"name": "released/1.2.0", "teamId": "team-1"}},
+		},
+		{
+			name:     "missing label and group",
+			existing: map[string]map[string]any{},
+			group:    "Releases",
+			wantCreate: []map[string]any{
+				{"name": "Releases", "teamId": "team-1", "isGroup": true},
+				{"name": "released/1.2.0", "teamId": "team-1", "parentId": "label-Releases"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinear(t, map[string]func(map[string]any) any{
+				"FindIssueLabel": func(vars map[string]any) any {
+					var nodes []any
+					if l, ok := tt.existing[vars["name"].(string)]; ok {
+						nodes = append(nodes, l)
+					}
+					return map[string]any{"issueLabels": map[string]any{"nodes": nodes}}
+				},
+				"CreateIssueLabel": func(vars map[string]any) any {
+					name := vars["input"].(map[string]any)["name"].(string)
+					return map[string]any{"issueLabelCreate": map[string]any{
+						"success":    true,
+						"issueLabel": map[string]any{"id": "label-" + name, "name": name},
+					}}
+				},
+			})
+
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(map[string]any{
+				"version_label_template": "released/{{.Version}}",
+				"version_label_group":    tt.group,
+			})
+
+			label, err := ensureVersionLabel(context.Background(), fake.client(), cfg,
+				plugin.ReleaseContext{Version: "1.2.0"}, &Team{ID: "team-1"})
+			if err != nil {
+				t.Fatalf("ensureVersionLabel() error = %v", err)
+			}
+			if label.Name != "released/1.2.0" {
+				t.Errorf("label = %+v", label)
+			}
+
+			var created []map[string]any
+			for _, vars := range fake.calls["CreateIssueLabel"] {
+				created = append(created, vars["input"].(map[string]any))
+			}
+			if !reflect.DeepEqual(created, tt.wantCreate) {
+				t.Errorf("created %v, want %v", created, tt.wantCreate)
+			}
+		})
+	}
+}
+
+func TestProcessLinkedIssuesAddsVersionLabel(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "labels": map[string]any{"nodes": []any{
+				map[string]any{"id": "label-bug", "name": "Bug"},
+			}}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "labels": map[string]any{"nodes": []any{
+				map[string]any{"id": "label-1", "name": "released/1.2.0"},
+			}}},
+		}),
+		"FindIssueLabel": func(map[string]any) any {
+			return map[string]any{"issueLabels": map[string]any{"nodes": []any{
+				map[string]any{"id": "label-1", "name": "released/1.2.0"},
+			}}}
+		},
+		"UpdateIssueLabels": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":   false,
+		"add_release_comment":    false,
+		"version_label_template": "released/{{.Version}}",
+	})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.2.0"}, &Team{ID: "team-1"}), []string{"ENG-1", "ENG-2"})
+
+	if res.Labelled != 1 || fake.callCount("UpdateIssueLabels") != 1 {
+		t.Fatalf("Labelled = %d, updates = %d; want 1 (ENG-2 is already labelled)", res.Labelled, fake.callCount("UpdateIssueLabels"))
+	}
+	input := fake.calls["UpdateIssueLabels"][0]["input"].(map[string]any)
+	if !reflect.DeepEqual(input["labelIds"], []any{"label-bug", "label-1"}) {
+		t.Errorf("labelIds = %v, want existing labels kept", input["labelIds"])
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderLinkback renders the shipped issues as a markdown block for the
+// release body on GitHub or GitLab, linking each issue back to Linear.
+func renderLinkback(issues []*Issue) string {
+	if len(issues) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("### Linear issues\n\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "- [%s](%s) %s\n", issue.Identifier, issue.URL, strings.TrimSpace(issue.Title))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
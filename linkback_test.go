@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderLinkback(t *testing.T) {
+	got := renderLinkback([]*Issue{
+		{Identifier: "ENG-1", Title: "Add export ", URL: "https://linear.app/acme/issue/ENG-1"},
+		{Identifier: "ENG-2", Title: "Fix crash", URL: "https://linear.app/acme/issue/ENG-2"},
+	})
+	want := "### Linear issues\n\n" +
+		"- [ENG-1](https://linear.app/acme/issue/ENG-1) Add export\n" +
+		"- [ENG-2](https://linear.app/acme/issue/ENG-2) Fix crash"
+	if got != want {
+		t.Errorf("renderLinkback() =\n%s\nwant\n%s", got, want)
+	}
+	if renderLinkback(nil) != "" {
+		t.Error("expected no block without issues")
+	}
+}
+
+func TestPostPublishOutputsReleaseBodyLinks(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{"id": "user-1", "name": "Jane Doe"}}
+		},
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG"}}
+		},
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "title": "Add export", "url": "https://linear.app/acme/issue/ENG-1"},
+		}),
+	})
+	fake.register(t, "lin_api_linkback_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"api_key":              "lin_api_linkback_test",
+			"team_id":              "team-1",
+			"create_release_issue": false,
+			"update_linked_issues": false,
+			"add_release_comment":  false,
+			"release_body_links":   true,
+		},
+		Context: plugin.ReleaseContext{
+			Version: "1.0.0",
+			Changes: &plugin.CategorizedChanges{
+				Features: []plugin.ConventionalCommit{{Hash: "abc1234", Description: "add export ENG-1"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "### Linear issues\n\n- [ENG-1](https://linear.app/acme/issue/ENG-1) Add export"
+	if got := resp.Outputs["release_body_links"]; got != want {
+		t.Errorf("release_body_links = %v, want %q (%s)", got, want, resp.Message)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// releaseLinksSubtitle groups plugin-created attachments in the issue sidebar.
+const releaseLinksSubtitle = "Releases"
+
+// releaseLink is an external link attached to linked issues.
+type releaseLink struct {
+	Title string
+	URL   string
+}
+
+// releaseLinks returns the links attached to linked issues: the release
+// page configured by release_link_url, if any, followed by the links
+// derived from the repository.
+func releaseLinks(cfg *Config, releaseCtx plugin.ReleaseContext) ([]releaseLink, error) {
+	var links []releaseLink
+	if cfg.ReleaseLinkURL != "" {
+		url, err := renderTemplate(cfg.ReleaseLinkURL, releaseCtx, cfg.TemplatePartials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render release_link_url: %w", err)
+		}
+		if url = strings.TrimSpace(url); url != "" {
+			links = append(links, releaseLink{Title: fmt.Sprintf("Release notes %s", releaseLabel(releaseCtx)), URL: url})
+		}
+	}
+	return append(links, buildReleaseLinks(releaseCtx)...), nil
+}
+
+// releaseLabel names the release by tag, or by version without a tag.
+func releaseLabel(releaseCtx plugin.ReleaseContext) string {
+	if releaseCtx.TagName != "" {
+		return releaseCtx.TagName
+	}
+	return releaseCtx.Version
+}
+
+// buildReleaseLinks derives release, tag and compare URLs from the repository
+// URL in the release context. GitHub-style paths are used unless the
+// repository is hosted on GitLab.
+func buildReleaseLinks(releaseCtx plugin.ReleaseContext) []releaseLink {
+	repo, sep := repositoryPaths(releaseCtx)
+	tag := releaseCtx.TagName
+	if repo == "" || tag == "" {
+		return nil
+	}
+
+	links := []releaseLink{
+		{Title: fmt.Sprintf("Release %s", tag), URL: repo + sep + "releases/tag/" + tag},
+		{Title: fmt.Sprintf("Tag %s", tag), URL: repo + sep + "tree/" + tag},
+	}
+
+	if prev := previousTag(releaseCtx); prev != "" {
+		links = append(links, releaseLink{
+			Title: fmt.Sprintf("Changes %s...%s", prev, tag),
+			URL:   repo + sep + "compare/" + prev + "..." + tag,
+		})
+	}
+
+	return links
+}
+
+// repositoryPaths returns the repository URL without a trailing slash or
+// .git suffix, and the separator before paths such as "releases/": "/-/"
+// on GitLab, "/" elsewhere.
+func repositoryPaths(releaseCtx plugin.ReleaseContext) (repo, sep string) {
+	repo = strings.TrimSuffix(strings.TrimSuffix(releaseCtx.RepositoryURL, "/"), ".git")
+	if strings.Contains(repo, "gitlab") {
+		return repo, "/-/"
+	}
+	return repo, "/"
+}
+
+// previousTag reconstructs the previous tag name using the same prefix as the
+// current tag (e.g. "v" for "v1.2.3").
+func previousTag(releaseCtx plugin.ReleaseContext) string {
+	if releaseCtx.PreviousVersion == "" {
+		return ""
+	}
+	prefix := strings.TrimSuffix(releaseCtx.TagName, releaseCtx.Version)
+	if prefix == releaseCtx.TagName {
+		prefix = ""
+	}
+	return prefix + releaseCtx.PreviousVersion
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestBuildReleaseLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      plugin.ReleaseContext
+		expected []string
+	}{
+		{
+			name: "github with previous version",
+			ctx: plugin.ReleaseContext{
+				Version:         "1.2.3",
+				PreviousVersion: "1.2.2",
+				TagName:         "v1.2.3",
+				RepositoryURL:   "https://github.com/acme/app.git",
+			},
+			expected: []string{
+				"https://github.com/acme/app/releases/tag/v1.2.3",
+				"https://github.com/acme/app/tree/v1.2.3",
+				"https://github.com/acme/app/compare/v1.2.2...v1.2.3",
+			},
+		},
+		{
+			name: "gitlab without previous version",
+			ctx: plugin.ReleaseContext{
+				Version:       "2.0.0",
+				TagName:       "2.0.0",
+				RepositoryURL: "https://gitlab.com/acme/app/",
+			},
+			expected: []string{
+				"https://gitlab.com/acme/app/-/releases/tag/2.0.0",
+				"https://gitlab.com/acme/app/-/tree/2.0.0",
+			},
+		},
+		{
+			name:     "missing repository",
+			ctx:      plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := buildReleaseLinks(tt.ctx)
+			if len(links) != len(tt.expected) {
+				t.Fatalf("expected %d links, got %d: %v", len(tt.expected), len(links), links)
+			}
+			for i, url := range tt.expected {
+				if links[i].URL != url {
+					t.Errorf("link %d = %s, want %s", i, links[i].URL, url)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseLinksWithReleasePage(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"add_release_links": true,
+		"release_link_url":  "https://docs.acme.com/changelog#{{.Version}}",
+	})
+
+	links, err := releaseLinks(cfg, plugin.ReleaseContext{Version: "1.2.3"})
+	if err != nil {
+		t.Fatalf("releaseLinks() error = %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "https://docs.acme.com/changelog#1.2.3" || links[0].Title != "Release notes 1.2.3" {
+		t.Errorf("links = %+v", links)
+	}
+
+	// The release page does not depend on repository data from the host
+	if disabled := disableMissingContextFeatures(cfg, plugin.ReleaseContext{Version: "1.2.3"}); len(disabled) != 0 || !cfg.AddReleaseLinks {
+		t.Errorf("expected add_release_links to stay enabled, disabled %v", disabled)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// localeEnvVar selects the comment locale from the release environment when
+// the configuration does not set one.
+const localeEnvVar = "LINEAR_LOCALE"
+
+// parseCommentTemplates parses the per-locale comment templates, keyed by
+// lower-cased locale.
+func parseCommentTemplates(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	templates := make(map[string]string, len(raw))
+	for locale, v := range raw {
+		if s, ok := v.(string); ok && s != "" {
+			templates[normalizeLocale(locale)] = s
+		}
+	}
+	return templates
+}
+
+// commentTemplate returns the comment template for the release locale.
+// An exact locale match ("de-at") wins over its language ("de"); without a
+// match the default comment_template is used.
+func commentTemplate(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	locale := cfg.Locale
+	if locale == "" {
+		locale = releaseCtx.Environment[localeEnvVar]
+	}
+	locale = normalizeLocale(locale)
+	if locale == "" || len(cfg.CommentTemplates) == 0 {
+		return cfg.CommentTemplate
+	}
+
+	if tmpl, ok := cfg.CommentTemplates[locale]; ok {
+		return tmpl
+	}
+	if lang, _, found := strings.Cut(locale, "-"); found {
+		if tmpl, ok := cfg.CommentTemplates[lang]; ok {
+			return tmpl
+		}
+	}
+	return cfg.CommentTemplate
+}
+
+// normalizeLocale lower-cases a locale and uses "-" as separator.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
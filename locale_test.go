@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCommentTemplateLocale(t *testing.T) {
+	p := &LinearPlugin{}
+	raw := map[string]any{
+		"comment_template": "Released in {{.Version}}",
+		"comment_templates": map[string]any{
+			"de":    "Veröffentlicht in {{.Version}}",
+			"fr_CA": "Publié dans {{.Version}}",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		locale string
+		env    map[string]string
+		want   string
+	}{
+		{"no locale", "", nil, "Released in {{.Version}}"},
+		{"exact", "fr-ca", nil, "Publié dans {{.Version}}"},
+		{"language fallback", "de_AT", nil, "Veröffentlicht in {{.Version}}"},
+		{"unknown", "es", nil, "Released in {{.Version}}"},
+		{"release environment", "", map[string]string{"LINEAR_LOCALE": "de"}, "Veröffentlicht in {{.Version}}"},
+		{"config wins", "en", map[string]string{"LINEAR_LOCALE": "de"}, "Released in {{.Version}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := p.parseConfig(raw)
+			cfg.Locale = tt.locale
+			got := commentTemplate(cfg, plugin.ReleaseContext{Environment: tt.env})
+			if got != tt.want {
+				t.Errorf("commentTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
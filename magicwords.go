@@ -0,0 +1,110 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Classes of issue references, following Linear's magic words.
+const (
+	referenceClosing  = "closing"
+	referenceRelated  = "reference"
+	referenceUnmarked = "unmarked"
+)
+
+// magicWordPattern matches a magic word at the end of the text preceding an
+// identifier, allowing lists such as "fixes ENG-1, ENG-2 and ENG-3".
+var magicWordPattern = regexp.MustCompile(`(?i)\b(close[sd]?|closing|fix(?:e[sd]|ing)?|resolve[sd]?|resolving|complete[sd]?|completing|refs?|references|part of|related to|contributes to|towards)[:\s]+(?:[a-z0-9]+-\d+(?:\s*,\s*|\s+and\s+|\s+))*$`)
+
+// MagicWordConfig selects which mutations apply to an issue by how commits
+// reference it: "fixes ENG-1" (closing), "refs ENG-1" (reference) or a bare
+// "ENG-1" (unmarked).
+type MagicWordConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Closing   []string `json:"closing"`
+	Reference []string `json:"reference"`
+	Unmarked  []string `json:"unmarked"`
+}
+
+// parseMagicWordConfig parses the magic_words block. By default references
+// only get a comment and links, while other issues get every mutation.
+func parseMagicWordConfig(raw map[string]any) MagicWordConfig {
+	parser := helpers.NewConfigParser(raw)
+	return MagicWordConfig{
+		Enabled:   parser.GetBool("enabled", false),
+		Closing:   parser.GetStringSlice("closing", defaultMutationOrder),
+		Reference: parser.GetStringSlice("reference", []string{mutationComment, mutationLinks}),
+		Unmarked:  parser.GetStringSlice("unmarked", defaultMutationOrder),
+	}
+}
+
+// mutations returns the mutations allowed for a reference class.
+func (m MagicWordConfig) mutations(class string) []string {
+	switch class {
+	case referenceClosing:
+		return m.Closing
+	case referenceRelated:
+		return m.Reference
+	default:
+		return m.Unmarked
+	}
+}
+
+// classifyReference returns the class of the reference to an identifier
+// that starts at offset start of text.
+func classifyReference(text string, start int) string {
+	line := text[:start]
+	if i := strings.LastIndexByte(line, '\n'); i >= 0 {
+		line = line[i+1:]
+	}
+	m := magicWordPattern.FindStringSubmatch(line)
+	if m == nil {
+		return referenceUnmarked
+	}
+	switch word := strings.ToLower(m[1]); {
+	case strings.HasPrefix(word, "clos"), strings.HasPrefix(word, "fix"),
+		strings.HasPrefix(word, "resolv"), strings.HasPrefix(word, "complet"):
+		return referenceClosing
+	default:
+		return referenceRelated
+	}
+}
+
+// issueReferences classifies every reference to an issue in the release's
+// commit subjects. An issue referenced several times collects each class.
+func issueReferences(cfg *Config, releaseCtx plugin.ReleaseContext) map[string][]string {
+	pattern := cfg.issueRegexp()
+	refs := make(map[string][]string)
+	for _, msg := range commitMessages(releaseCtx) {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(msg, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) > 3 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			id := strings.ToUpper(msg[start:end])
+			if class := classifyReference(msg, start); !slices.Contains(refs[id], class) {
+				refs[id] = append(refs[id], class)
+			}
+		}
+	}
+	return refs
+}
+
+// allowsMutation reports whether a mutation applies to an issue given the
+// classes of its references; the mutations of all classes are combined.
+// Issues without classified references get every mutation.
+func (m MagicWordConfig) allowsMutation(classes []string, kind string) bool {
+	if !m.Enabled || len(classes) == 0 {
+		return true
+	}
+	for _, class := range classes {
+		if slices.Contains(m.mutations(class), kind) {
+			return true
+		}
+	}
+	return false
+}
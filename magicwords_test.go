@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestClassifyReference(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"fixes ENG-1", referenceClosing},
+		{"Closes: ENG-1", referenceClosing},
+		{"resolved ENG-1", referenceClosing},
+		{"fix ENG-2, ENG-1", referenceClosing},
+		{"fixes ENG-2 and ENG-1", referenceClosing},
+		{"refs ENG-1", referenceRelated},
+		{"part of ENG-1", referenceRelated},
+		{"related to ENG-1", referenceRelated},
+		{"ENG-1 tidy up", referenceUnmarked},
+		{"add prefix handling ENG-1", referenceUnmarked},
+		{"fixes ENG-2\nENG-1", referenceUnmarked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			start := strings.Index(tt.msg, "ENG-1")
+			if got := classifyReference(tt.msg, start); got != tt.want {
+				t.Errorf("classifyReference(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueReferences(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_key": "ENG"})
+	releaseCtx := plugin.ReleaseContext{
+		Changes: &plugin.CategorizedChanges{
+			Fixes: []plugin.ConventionalCommit{
+				{Description: "fixes ENG-1, refs ENG-2"},
+				{Description: "handle ENG-2 follow-up"},
+			},
+		},
+	}
+
+	want := map[string][]string{
+		"ENG-1": {referenceClosing},
+		"ENG-2": {referenceRelated, referenceUnmarked},
+	}
+	if got := issueReferences(cfg, releaseCtx); !reflect.DeepEqual(got, want) {
+		t.Errorf("issueReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestMagicWordsLimitMutations(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-todo"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-todo"}},
+		}),
+		"UpdateIssueState": successHandler("issueUpdate"),
+		"AddComment":       successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"team_key":    "ENG",
+		"magic_words": map[string]any{"enabled": true},
+	})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+	releaseCtx := plugin.ReleaseContext{
+		Version: "1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Fixes: []plugin.ConventionalCommit{{Description: "fixes ENG-1, refs ENG-2"}},
+		},
+	}
+
+	p.processLinkedIssues(context.Background(), fake.run(cfg, releaseCtx, team), []string{"ENG-1", "ENG-2"})
+
+	if n := fake.callCount("UpdateIssueState"); n != 1 {
+		t.Errorf("expected only the closing reference to change state, got %d updates", n)
+	}
+	if n := fake.callCount("AddComment"); n != 2 {
+		t.Errorf("expected both issues commented, got %d comments", n)
+	}
+}
+
+func TestValidateMagicWords(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"api_key":     "lin_api_test",
+		"team_id":     "team-1",
+		"magic_words": map[string]any{"enabled": true, "reference": []any{"comment", "assignee"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Valid {
+		t.Fatal("expected unknown mutation to be rejected")
+	}
+}
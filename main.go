@@ -1,8 +1,15 @@
 // Package main provides the entry point for the Linear plugin.
 package main
 
-import "github.com/relicta-tech/relicta-plugin-sdk/plugin"
+import (
+	"os"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
 
 func main() {
+	if !plugin.IsPlugin() && len(os.Args) > 1 {
+		os.Exit(runCLI(os.Args[1:], os.Stdout, os.Stderr))
+	}
 	plugin.Serve(&LinearPlugin{})
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Markers are short machine-readable tags the plugin appends to the content
+// it writes into Linear, so later runs can tell what was already done. They
+// are rendered as inline code, which survives Linear's markdown round-trip.
+const (
+	markerReleased = "released"
+	markerRelease  = "release"
+	markerThread   = "thread"
+)
+
+// markerPattern matches markers such as `relicta:released=1.4.0`, including
+// kinds namespaced by a naming prefix such as `relicta:web/released=1.4.0`.
+var markerPattern = regexp.MustCompile("`relicta:([a-z0-9_/-]+)=([^`\\s]+)`")
+
+// formatMarker renders a marker of the given kind.
+func formatMarker(kind, value string) string {
+	return fmt.Sprintf("`relicta:%s=%s`", kind, value)
+}
+
+// markerPrefix returns the start of every marker of the given kind, for
+// searching Linear content regardless of the marker value.
+func markerPrefix(kind string) string {
+	return "`relicta:" + kind + "="
+}
+
+// appendMarker appends a marker on its own line to body.
+func appendMarker(body, kind, value string) string {
+	return body + "\n\n" + formatMarker(kind, value)
+}
+
+// findMarkers returns all values of the given marker kind found in body.
+func findMarkers(body, kind string) []string {
+	var values []string
+	for _, m := range markerPattern.FindAllStringSubmatch(body, -1) {
+		if m[1] == kind {
+			values = append(values, m[2])
+		}
+	}
+	return values
+}
+
+// stripMarkers removes all marker lines from body.
+func stripMarkers(body string) string {
+	return strings.TrimSpace(markerPattern.ReplaceAllString(body, ""))
+}
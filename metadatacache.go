@@ -0,0 +1,94 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// metadataTTL bounds how long a client reuses team and viewer lookups.
+// Hooks of one release share the client, so its validation and hooks look
+// each team up once; the bound keeps a long-lived process from holding on
+// to workflow states renamed since.
+const metadataTTL = 5 * time.Minute
+
+// metadataCache holds the teams and the viewer a client looked up. The
+// zero value is ready to use.
+type metadataCache struct {
+	mu       sync.Mutex
+	teams    map[string]cachedTeam
+	viewer   *Viewer
+	viewerAt time.Time
+}
+
+// cachedTeam is a team with the time it was looked up.
+type cachedTeam struct {
+	team Team
+	at   time.Time
+}
+
+// teamCacheKey keys a team lookup by ID, or by key without an ID, as
+// GetTeam resolves them.
+func teamCacheKey(teamID, teamKey string) string {
+	if teamID != "" {
+		return "id:" + teamID
+	}
+	return "key:" + teamKey
+}
+
+// team returns a copy of the cached team, if it is fresh.
+func (m *metadataCache) team(teamID, teamKey string) (*Team, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cached, ok := m.teams[teamCacheKey(teamID, teamKey)]
+	if !ok || time.Since(cached.at) > metadataTTL {
+		return nil, false
+	}
+	t := cached.team
+	t.States = slices.Clone(t.States)
+	return &t, true
+}
+
+// storeTeam caches a team under both its ID and its key.
+func (m *metadataCache) storeTeam(team *Team) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.teams == nil {
+		m.teams = make(map[string]cachedTeam)
+	}
+	cached := cachedTeam{team: *team, at: time.Now()}
+	cached.team.States = slices.Clone(team.States)
+	m.teams[teamCacheKey(team.ID, "")] = cached
+	m.teams[teamCacheKey("", team.Key)] = cached
+}
+
+// forgetTeam drops a cached team, so the next lookup reads it again.
+func (m *metadataCache) forgetTeam(teamID, teamKey string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cached, ok := m.teams[teamCacheKey(teamID, teamKey)]
+	if !ok {
+		return
+	}
+	delete(m.teams, teamCacheKey(cached.team.ID, ""))
+	delete(m.teams, teamCacheKey("", cached.team.Key))
+}
+
+// cachedViewer returns a copy of the cached viewer, if it is fresh.
+func (m *metadataCache) cachedViewer() (*Viewer, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.viewer == nil || time.Since(m.viewerAt) > metadataTTL {
+		return nil, false
+	}
+	v := *m.viewer
+	return &v, true
+}
+
+// storeViewer caches the viewer.
+func (m *metadataCache) storeViewer(viewer *Viewer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v := *viewer
+	m.viewer, m.viewerAt = &v, time.Now()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetTeamIsCached(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{
+				"id": "team-1", "key": "ENG",
+				"states": map[string]any{"nodes": []any{map[string]any{"id": "state-1", "name": "Done"}}},
+			}}
+		},
+	})
+	client := fake.client()
+	ctx := context.Background()
+
+	team, err := client.GetTeam(ctx, "team-1", "")
+	if err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+	team.States[0].Name = "changed by caller"
+
+	again, err := client.GetTeam(ctx, "team-1", "")
+	if err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+	if again.States[0].Name != "Done" {
+		t.Errorf("cached team was modified through a returned copy: %+v", again.States)
+	}
+	// The team is also cached under its key
+	if byKey, err := client.GetTeam(ctx, "", "ENG"); err != nil || byKey.ID != "team-1" {
+		t.Errorf("GetTeam() by key = %v, %v", byKey, err)
+	}
+	if n := fake.callCount("GetTeam") + fake.callCount("GetTeams"); n != 1 {
+		t.Errorf("expected one team lookup, got %d", n)
+	}
+
+	client.metadata.forgetTeam("", "ENG")
+	if _, err := client.GetTeam(ctx, "team-1", ""); err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+	if n := fake.callCount("GetTeam"); n != 2 {
+		t.Errorf("expected a forgotten team to be looked up again, got %d lookups", n)
+	}
+}
+
+func TestGetViewerIsCached(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{"id": "user-1", "name": "Jane Doe"}}
+		},
+	})
+	client := fake.client()
+
+	for range 3 {
+		if viewer, err := client.GetViewer(context.Background()); err != nil || viewer.ID != "user-1" {
+			t.Fatalf("GetViewer() = %v, %v", viewer, err)
+		}
+	}
+	if n := fake.callCount("GetViewer"); n != 1 {
+		t.Errorf("expected one viewer lookup, got %d", n)
+	}
+
+	// An expired entry is looked up again
+	client.metadata.viewerAt = time.Now().Add(-metadataTTL - time.Second)
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+	if n := fake.callCount("GetViewer"); n != 2 {
+		t.Errorf("expected an expired viewer to be looked up again, got %d lookups", n)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// MilestoneConfig assigns linked issues to a milestone of project_id named
+// after the release.
+type MilestoneConfig struct {
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name"`
+}
+
+// parseMilestoneConfig parses the milestone block.
+func parseMilestoneConfig(raw map[string]any) MilestoneConfig {
+	parser := helpers.NewConfigParser(raw)
+	return MilestoneConfig{
+		Enabled: parser.GetBool("enabled", false),
+		Name:    parser.GetString("name", "", "{{.Version}}"),
+	}
+}
+
+// ensureMilestone finds the milestone of a project named after the release,
+// matching names case-insensitively, or creates it.
+func ensureMilestone(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, projectID string) (*ProjectMilestone, error) {
+	name, err := renderTemplate(cfg.Milestone.Name, releaseCtx, cfg.TemplatePartials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render milestone name: %w", err)
+	}
+	name = strings.TrimSpace(name)
+
+	milestones, err := client.GetProjectMilestones(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project milestones: %w", err)
+	}
+	for i := range milestones {
+		if strings.EqualFold(milestones[i].Name, name) {
+			return &milestones[i], nil
+		}
+	}
+
+	milestone, err := client.CreateProjectMilestone(ctx, projectID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone %s: %w", name, err)
+	}
+	return milestone, nil
+}
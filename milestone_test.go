@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestProcessLinkedIssuesAssignsMilestone(t *testing.T) {
+	tests := []struct {
+		name       string
+		milestones []any
+		wantCreate int
+	}{
+		{"existing milestone", []any{map[string]any{"id": "ms-1", "name": "v1.2.0"}}, 0},
+		{"missing milestone", []any{map[string]any{"id": "ms-0", "name": "v1.1.0"}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinear(t, map[string]func(map[string]any) any{
+				"GetIssue": issueHandler(map[string]map[string]any{
+					"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+					"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+				}),
+				"GetProjectMilestones": func(map[string]any) any {
+					return map[string]any{"project": map[string]any{"projectMilestones": map[string]any{"nodes": tt.milestones}}}
+				},
+				"CreateProjectMilestone": func(map[string]any) any {
+					return map[string]any{"projectMilestoneCreate": map[string]any{
+						"success":          true,
+						"projectMilestone": map[string]any{"id": "ms-1", "name": "v1.2.0"},
+					}}
+				},
+				"UpdateIssueMilestone": successHandler("issueUpdate"),
+			})
+
+			p := &LinearPlugin{}
+			cfg := p.parseConfig(map[string]any{
+				"project_id":           "project-1",
+				"update_linked_issues": false,
+				"add_release_comment":  false,
+				"milestone":            map[string]any{"enabled": true, "name": "v{{.Version}}"},
+			})
+			res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.2.0"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+			if got := fake.callCount("CreateProjectMilestone"); got != tt.wantCreate {
+				t.Errorf("CreateProjectMilestone calls = %d, want %d", got, tt.wantCreate)
+			}
+			if res.Milestoned != 2 {
+				t.Errorf("Milestoned = %d, want 2", res.Milestoned)
+			}
+			for _, vars := range fake.calls["UpdateIssueMilestone"] {
+				input := vars["input"].(map[string]any)
+				if input["projectId"] != "project-1" || input["projectMilestoneId"] != "ms-1" {
+					t.Errorf("unexpected issue update input %v", input)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMilestoneRequiresProject(t *testing.T) {
+	p := &LinearPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"api_key":   "lin_api_test",
+		"team_id":   "team-1",
+		"milestone": map[string]any{"enabled": true},
+	})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, e := range resp.Errors {
+		if e.Field == "milestone" {
+			return
+		}
+	}
+	t.Errorf("expected a milestone error, got %v", resp.Errors)
+}
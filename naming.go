@@ -0,0 +1,41 @@
+package main
+
+import (
+	"regexp"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// NamingConfig controls the names of entities the plugin creates, so several
+// repositories sharing one Linear workspace do not collide.
+type NamingConfig struct {
+	// Prefix identifies the repository. It is prepended to release issue and
+	// digest titles and namespaces the markers the plugin writes.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// namingPrefixPattern restricts prefixes to characters safe in markers.
+var namingPrefixPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// parseNamingConfig parses the naming block.
+func parseNamingConfig(raw map[string]any) NamingConfig {
+	return NamingConfig{
+		Prefix: helpers.NewConfigParser(raw).GetString("prefix", "", ""),
+	}
+}
+
+// title prefixes a generated title, e.g. "[web] Release 1.2.0".
+func (n NamingConfig) title(s string) string {
+	if n.Prefix == "" {
+		return s
+	}
+	return "[" + n.Prefix + "] " + s
+}
+
+// marker namespaces a marker kind, e.g. "web/release".
+func (n NamingConfig) marker(kind string) string {
+	if n.Prefix == "" {
+		return kind
+	}
+	return n.Prefix + "/" + kind
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestNamingConfig(t *testing.T) {
+	n := NamingConfig{Prefix: "web"}
+	if got := n.title("Release 1.0.0"); got != "[web] Release 1.0.0" {
+		t.Errorf("title() = %q", got)
+	}
+	if got := n.marker(markerRelease); got != "web/release" {
+		t.Errorf("marker() = %q", got)
+	}
+
+	var none NamingConfig
+	if none.title("Release 1.0.0") != "Release 1.0.0" || none.marker(markerRelease) != markerRelease {
+		t.Error("expected names unchanged without a prefix")
+	}
+}
+
+func TestNamespacedMarkersDoNotCollide(t *testing.T) {
+	body := appendMarker("notes", NamingConfig{Prefix: "web"}.marker(markerRelease), "1.0.0")
+
+	if got := findMarkers(body, "web/release"); len(got) != 1 || got[0] != "1.0.0" {
+		t.Errorf("expected namespaced marker, got %v", got)
+	}
+	if got := findMarkers(body, markerRelease); len(got) != 0 {
+		t.Errorf("expected no unprefixed marker, got %v", got)
+	}
+	if stripMarkers(body) != "notes" {
+		t.Errorf("expected namespaced marker to be stripped, got %q", stripMarkers(body))
+	}
+}
+
+func TestRenderReleaseIssueUsesNaming(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"naming": map[string]any{"prefix": "api"}})
+
+	run := &releaseRun{cfg: cfg, release: plugin.ReleaseContext{Version: "2.0.0"}}
+	title, description, err := renderReleaseIssue(context.Background(), run, nil)
+	if err != nil {
+		t.Fatalf("renderReleaseIssue() error = %v", err)
+	}
+	if title != "[api] Release 2.0.0" {
+		t.Errorf("title = %q", title)
+	}
+	if got := findMarkers(description, "api/release"); len(got) != 1 {
+		t.Errorf("expected namespaced release marker in %q", description)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// NetworkConfig routes API requests through an HTTP proxy and trusts
+// additional CA certificates, for runners whose egress goes through a
+// corporate proxy.
+type NetworkConfig struct {
+	// ProxyURL is the proxy all API requests use. Without it the standard
+	// HTTPS_PROXY and NO_PROXY variables apply.
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// CAFile is a PEM bundle of certificates trusted in addition to the
+	// system roots.
+	CAFile string `json:"ca_file,omitempty"`
+}
+
+// parseNetworkConfig parses the network block. The CA file defaults to
+// LINEAR_CA_FILE.
+func parseNetworkConfig(raw map[string]any) NetworkConfig {
+	parser := helpers.NewConfigParser(raw)
+	return NetworkConfig{
+		ProxyURL: parser.GetString("proxy_url", "", ""),
+		CAFile:   parser.GetString("ca_file", "LINEAR_CA_FILE", ""),
+	}
+}
+
+// validate reports invalid network settings.
+func (c NetworkConfig) validate(vb *helpers.ValidationBuilder) {
+	if c.ProxyURL != "" {
+		if _, err := c.proxy(); err != nil {
+			vb.AddError("network.proxy_url", err.Error())
+		}
+	}
+	if c.CAFile != "" {
+		if _, err := c.rootCAs(); err != nil {
+			vb.AddError("network.ca_file", err.Error())
+		}
+	}
+}
+
+// ID identifies the settings, so clients are only shared by configurations
+// connecting alike. The proxy URL is hashed as it may carry credentials.
+func (c NetworkConfig) ID() string {
+	if c == (NetworkConfig{}) {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(c.ProxyURL + "\n" + c.CAFile))
+	return hex.EncodeToString(sum[:])
+}
+
+// proxy returns the proxy function of the transport.
+func (c NetworkConfig) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if c.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(c.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q (must be an http, https or socks5 URL with a host)", u.Redacted())
+	}
+	return http.ProxyURL(u), nil
+}
+
+// rootCAs returns the system roots with the CA file's certificates added,
+// or nil to use the system roots alone.
+func (c NetworkConfig) rootCAs() (*x509.CertPool, error) {
+	if c.CAFile == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA file %s contains no PEM certificates", c.CAFile)
+	}
+	return pool, nil
+}
+
+// transport returns the HTTP transport of the settings.
+func (c NetworkConfig) transport() (*http.Transport, error) {
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+	roots, err := c.rootCAs()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Transport{
+		Proxy:           proxy,
+		TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots},
+	}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Sources of the release notes templates see as {{.ReleaseNotes}}.
+const (
+	notesSourceHost    = "host"
+	notesSourceChanges = "changes"
+	notesSourceFile    = "file"
+)
+
+// ReleaseNotesConfig selects the release notes fed to templates: the
+// host's notes, notes regenerated from the categorized changes, or a file,
+// for when the host's formatting does not suit Linear.
+type ReleaseNotesConfig struct {
+	Source string `json:"source"`
+	// File is the path of the notes with source "file".
+	File string `json:"file,omitempty"`
+}
+
+// parseReleaseNotesConfig parses the release_notes block. The file
+// defaults to LINEAR_RELEASE_NOTES_FILE.
+func parseReleaseNotesConfig(raw map[string]any) ReleaseNotesConfig {
+	parser := helpers.NewConfigParser(raw)
+	return ReleaseNotesConfig{
+		Source: strings.ToLower(parser.GetString("source", "", notesSourceHost)),
+		File:   parser.GetString("file", "LINEAR_RELEASE_NOTES_FILE", ""),
+	}
+}
+
+// validate reports an unknown source or a file source without a file.
+func (c ReleaseNotesConfig) validate(vb *helpers.ValidationBuilder) {
+	switch c.Source {
+	case notesSourceHost, notesSourceChanges:
+	case notesSourceFile:
+		if c.File == "" {
+			vb.AddError("release_notes.file", "A file is required with source 'file'")
+		}
+	default:
+		vb.AddError("release_notes.source", "Release notes source must be 'host', 'changes' or 'file'")
+	}
+}
+
+// releaseNotes returns the notes of the configured source.
+func (c ReleaseNotesConfig) releaseNotes(releaseCtx plugin.ReleaseContext) (string, error) {
+	switch c.Source {
+	case notesSourceChanges:
+		if releaseCtx.Changes == nil {
+			return "", errors.New("the host did not provide categorized changes")
+		}
+		return formatChanges(releaseCtx), nil
+	case notesSourceFile:
+		data, err := os.ReadFile(c.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read release notes: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return releaseCtx.ReleaseNotes, nil
+}
+
+// applyReleaseNotesSource replaces the host's release notes with those of
+// the configured source. On failure the host's notes are kept. It reports
+// whether the notes were replaced.
+func applyReleaseNotesSource(cfg *Config, releaseCtx *plugin.ReleaseContext) (bool, error) {
+	if cfg.ReleaseNotes.Source == notesSourceHost || cfg.ReleaseNotes.Source == "" {
+		return false, nil
+	}
+	notes, err := cfg.ReleaseNotes.releaseNotes(*releaseCtx)
+	if err != nil {
+		return false, err
+	}
+	releaseCtx.ReleaseNotes = notes
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestApplyReleaseNotesSource(t *testing.T) {
+	p := &LinearPlugin{}
+	release := plugin.ReleaseContext{
+		ReleaseNotes: "## Host notes",
+		Changes:      &plugin.CategorizedChanges{Fixes: []plugin.ConventionalCommit{{Description: "fix crash"}}},
+	}
+
+	// The host's notes are used by default
+	rel := release
+	if replaced, err := applyReleaseNotesSource(p.parseConfig(nil), &rel); replaced || err != nil || rel.ReleaseNotes != "## Host notes" {
+		t.Errorf("host source: replaced = %v, err = %v, notes = %q", replaced, err, rel.ReleaseNotes)
+	}
+
+	rel = release
+	cfg := p.parseConfig(map[string]any{"release_notes": map[string]any{"source": "changes"}})
+	if replaced, err := applyReleaseNotesSource(cfg, &rel); !replaced || err != nil || !strings.Contains(rel.ReleaseNotes, "- fix crash") {
+		t.Errorf("changes source: replaced = %v, err = %v, notes = %q", replaced, err, rel.ReleaseNotes)
+	}
+
+	file := filepath.Join(t.TempDir(), "NOTES.md")
+	if err := os.WriteFile(file, []byte("Curated notes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rel = release
+	cfg = p.parseConfig(map[string]any{"release_notes": map[string]any{"source": "file", "file": file}})
+	if replaced, err := applyReleaseNotesSource(cfg, &rel); !replaced || err != nil || rel.ReleaseNotes != "Curated notes" {
+		t.Errorf("file source: replaced = %v, err = %v, notes = %q", replaced, err, rel.ReleaseNotes)
+	}
+
+	// A missing file keeps the host's notes
+	rel = release
+	cfg.ReleaseNotes.File = filepath.Join(t.TempDir(), "missing.md")
+	if replaced, err := applyReleaseNotesSource(cfg, &rel); replaced || err == nil || rel.ReleaseNotes != "## Host notes" {
+		t.Errorf("missing file: replaced = %v, err = %v, notes = %q", replaced, err, rel.ReleaseNotes)
+	}
+}
+
+func TestValidateReleaseNotes(t *testing.T) {
+	tests := []struct {
+		cfg   ReleaseNotesConfig
+		field string
+	}{
+		{cfg: ReleaseNotesConfig{Source: "host"}},
+		{cfg: ReleaseNotesConfig{Source: "file", File: "NOTES.md"}},
+		{cfg: ReleaseNotesConfig{Source: "file"}, field: "release_notes.file"},
+		{cfg: ReleaseNotesConfig{Source: "changelog"}, field: "release_notes.source"},
+	}
+	for _, tt := range tests {
+		vb := helpers.NewValidationBuilder()
+		tt.cfg.validate(vb)
+		errs := vb.Build().Errors
+		if tt.field == "" && len(errs) != 0 || tt.field != "" && (len(errs) != 1 || errs[0].Field != tt.field) {
+			t.Errorf("validate(%+v) = %v, want an error for %q", tt.cfg, errs, tt.field)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Mutations applied to each linked issue, in configurable order. Some
+// workflow automations trigger on state changes, so teams may want the
+// release comment posted before the transition.
+const (
+	mutationState     = "state"
+	mutationComment   = "comment"
+	mutationLinks     = "links"
+	mutationMilestone = "milestone"
+	mutationLabel     = "label"
+	mutationCycle     = "cycle"
+)
+
+// defaultMutationOrder is the order mutations run in unless configured.
+var defaultMutationOrder = []string{mutationState, mutationComment, mutationLinks, mutationMilestone, mutationLabel, mutationCycle}
+
+// isKnownMutation reports whether kind names a mutation.
+func isKnownMutation(kind string) bool {
+	for _, k := range defaultMutationOrder {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMutationOrder returns the configured order without unknown or
+// repeated entries, followed by any mutations not mentioned, in default
+// order.
+func parseMutationOrder(order []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, kind := range append(order, defaultMutationOrder...) {
+		if isKnownMutation(kind) && !seen[kind] {
+			seen[kind] = true
+			out = append(out, kind)
+		}
+	}
+	return out
+}
+
+// applyMutation performs one kind of mutation on a linked issue when it is
+// enabled, recording the outcome in res. It reports whether it succeeded.
+func applyMutation(ctx context.Context, run *releaseRun, plan *linkedIssuePlan, kind string, li *linkedIssue, res *linkedIssueResults) bool {
+	cfg, client := run.cfg, run.client
+	issue, issueID, comment := li.issue, li.issueID, li.comment
+	switch kind {
+	case mutationState:
+		tp := plan.stateTarget(issue, li.extractedID)
+		if !cfg.UpdateLinkedIssues || tp == nil || tp.stateID == "" {
+			return true
+		}
+		if cfg.keepsClosedState(issue) {
+			res.Closed[issueID] = issue.State.Name
+			return true
+		}
+		// Capture the current state first so the audit trail covers failures
+		t := newTransition(issue, tp.state)
+		if err := updateState(ctx, client, tp, issue.ID); err != nil {
+			if !errors.Is(err, errMutationSkipped) {
+				res.Transitions = append(res.Transitions, t)
+			}
+			res.warn(err, "Failed to update %s", issueID)
+			return false
+		}
+		res.recordTransition(t, issueID, t.verify(ctx, client, tp.stateID))
+
+	case mutationComment:
+		if !cfg.AddReleaseComment || comment == "" || cfg.CommentMode == commentModeAssignee {
+			return true
+		}
+		if !postReleaseComment(ctx, client, cfg, plan, issue, issueID, comment, res) {
+			return false
+		}
+
+	case mutationMilestone:
+		projectID := cfg.ProjectID
+		if tp := plan.teamFor(issue); tp != nil && tp.projectID != "" {
+			projectID = tp.projectID
+		}
+		milestone := plan.milestones[projectID]
+		if milestone == nil {
+			return true
+		}
+		if err := client.UpdateIssueMilestone(ctx, issue.ID, projectID, milestone.ID); err != nil {
+			res.warn(err, "Failed to add %s to milestone %s", issueID, milestone.Name)
+			return false
+		}
+		res.Milestoned++
+
+	case mutationLabel:
+		// Re-released issues also get the re-release label
+		var add []string
+		versioned := plan.label != nil && !issue.hasLabel(plan.label.Name)
+		if versioned {
+			add = append(add, plan.label.ID)
+		}
+		if li.reReleased != "" && plan.reReleaseLabel != nil && !issue.hasLabel(plan.reReleaseLabel.Name) {
+			add = append(add, plan.reReleaseLabel.ID)
+		}
+		if len(add) == 0 {
+			return true
+		}
+		if err := client.UpdateIssueLabels(ctx, issue.ID, append(issue.labelIDs(), add...)); err != nil {
+			res.warn(err, "Failed to label %s", issueID)
+			return false
+		}
+		if versioned {
+			res.Labelled++
+		}
+
+	case mutationCycle:
+		// Cycles belong to a team, so only issues of the primary team join
+		if plan.cycle == nil || issue.cycleID() == plan.cycle.ID {
+			return true
+		}
+		if tp := plan.teamFor(issue); tp == nil || tp.teamID != plan.primary.teamID {
+			return true
+		}
+		if err := client.UpdateIssueCycle(ctx, issue.ID, plan.cycle.ID); err != nil {
+			res.warn(err, "Failed to add %s to cycle %s", issueID, plan.cycle)
+			return false
+		}
+		res.Cycled++
+
+	case mutationLinks:
+		if !attachPullRequests(ctx, client, plan, issue, issueID, res) {
+			return false
+		}
+		if len(plan.links) == 0 {
+			return true
+		}
+		attached := true
+		for _, link := range plan.links {
+			err := client.CreateAttachment(ctx, AttachmentInput{
+				IssueID:  issue.ID,
+				URL:      link.URL,
+				Title:    link.Title,
+				Subtitle: releaseLinksSubtitle,
+				IconURL:  cfg.ReleaseLinkIconURL,
+			})
+			if err != nil {
+				res.warn(err, "Failed to attach %s to %s", link.Title, issueID)
+				attached = false
+			}
+		}
+		if !attached {
+			return false
+		}
+		res.Attached++
+	}
+	return true
+}
+
+// recordTransition records a state update whose outcome was verified with
+// err.
+func (r *linkedIssueResults) recordTransition(t stateTransition, issueID string, err error) {
+	r.Transitions = append(r.Transitions, t)
+	switch {
+	case err != nil:
+		r.Updated++
+		r.Errors = append(r.Errors, fmt.Sprintf("Could not verify the state of %s: %v", issueID, err))
+	case t.Outcome == transitionDiverted:
+		r.Errors = append(r.Errors, t.String())
+	default:
+		r.Updated++
+	}
+}
+
+// attachPullRequests links the pull requests of the commits referencing an
+// issue, unless a link to the pull request is already attached (e.g. by
+// Linear's GitHub integration).
+func attachPullRequests(ctx context.Context, client *LinearClient, plan *linkedIssuePlan, issue *Issue, issueID string, res *linkedIssueResults) bool {
+	ok := true
+	for _, url := range plan.pullRequests[issueID] {
+		if issue.hasAttachment(url) {
+			continue
+		}
+		err := client.CreateAttachment(ctx, AttachmentInput{
+			IssueID:  issue.ID,
+			URL:      url,
+			Title:    pullRequestTitle(url),
+			Subtitle: pullRequestLinksSubtitle,
+		})
+		if err != nil {
+			res.warn(err, "Failed to attach %s to %s", url, issueID)
+			ok = false
+			continue
+		}
+		res.PullRequests[issue.Identifier] = append(res.PullRequests[issue.Identifier], url)
+	}
+	return ok
+}
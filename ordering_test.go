@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseMutationOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"default", nil, []string{"state", "comment", "links", "milestone", "label", "cycle"}},
+		{"comment first", []string{"comment"}, []string{"comment", "state", "links", "milestone", "label", "cycle"}},
+		{"full order", []string{"cycle", "label", "milestone", "links", "comment", "state"}, []string{"cycle", "label", "milestone", "links", "comment", "state"}},
+		{"unknown and repeated", []string{"assignee", "comment", "comment"}, []string{"comment", "state", "links", "milestone", "label", "cycle"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMutationOrder(tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMutationOrder(%v) = %v, want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessLinkedIssuesMutationOrder(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name, field string) func(map[string]any) any {
+		return func(map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name)
+			return map[string]any{field: map[string]any{"success": true}}
+		}
+	}
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-done"}},
+		}),
+		"UpdateIssueState": record("state", "issueUpdate"),
+		"AddComment":       record("comment", "commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"mutation_order": []any{"comment", "state"}})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1"})
+
+	if !reflect.DeepEqual(calls, []string{"comment", "state"}) {
+		t.Errorf("expected comment before state, got %v", calls)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+const (
+	// pageSize is how many nodes a paginated query requests per page.
+	pageSize = 100
+
+	// maxPages bounds paginated queries, so a server that keeps reporting
+	// further pages cannot keep a run busy forever.
+	maxPages = 100
+)
+
+// pageInfo is the pagination state of a connection.
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// paginate calls fetch for one page after another, starting after the
+// given cursor ("" for the first page), until a page reports no further
+// page. fetch returns an empty pageInfo to stop early.
+func paginate(after string, fetch func(after string) (pageInfo, error)) error {
+	for range maxPages {
+		page, err := fetch(after)
+		if err != nil {
+			return err
+		}
+		if !page.HasNextPage || page.EndCursor == "" || page.EndCursor == after {
+			return nil
+		}
+		after = page.EndCursor
+	}
+	return fmt.Errorf("more than %d pages of results", maxPages)
+}
+
+// cursor returns the after variable of a page query: null for the first
+// page.
+func cursor(after string) any {
+	if after == "" {
+		return nil
+	}
+	return after
+}
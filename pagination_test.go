@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// pagedHandler answers a paginated query from pages of nodes, under field,
+// with cursors "page-1", "page-2", ...
+func pagedHandler(field string, pages ...[]any) func(map[string]any) any {
+	return func(vars map[string]any) any {
+		i := 0
+		if after, ok := vars["after"].(string); ok {
+			for n := range pages {
+				if after == pageCursor(n) {
+					i = n + 1
+				}
+			}
+		}
+		return map[string]any{field: map[string]any{
+			"nodes":    pages[i],
+			"pageInfo": map[string]any{"hasNextPage": i < len(pages)-1, "endCursor": pageCursor(i)},
+		}}
+	}
+}
+
+func pageCursor(i int) string {
+	return fmt.Sprintf("page-%d", i+1)
+}
+
+func TestGetTeamByKeyPaginates(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeams": pagedHandler("teams",
+			[]any{map[string]any{"id": "team-ops", "key": "OPS"}},
+			[]any{map[string]any{"id": "team-eng", "key": "ENG", "states": map[string]any{
+				"nodes":    []any{map[string]any{"id": "state-1", "name": "Todo"}},
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "states-1"},
+			}}},
+			[]any{map[string]any{"id": "team-web", "key": "WEB"}},
+		),
+		"GetTeamStates": func(vars map[string]any) any {
+			if vars["id"] != "team-eng" || vars["after"] != "states-1" {
+				t.Errorf("unexpected states query %v", vars)
+			}
+			return map[string]any{"team": map[string]any{"states": map[string]any{
+				"nodes": []any{map[string]any{"id": "state-2", "name": "Done"}},
+			}}}
+		},
+	})
+
+	team, err := fake.client().GetTeam(context.Background(), "", "ENG")
+	if err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+	if team.ID != "team-eng" || len(team.States) != 2 || team.States[1].Name != "Done" {
+		t.Errorf("GetTeam() = %+v", team)
+	}
+	// The search stops at the page with the team
+	if n := fake.callCount("GetTeams"); n != 2 {
+		t.Errorf("expected 2 pages fetched, got %d", n)
+	}
+}
+
+func TestListTeamKeysPaginates(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"ListTeamKeys": pagedHandler("teams",
+			[]any{map[string]any{"key": "ENG"}, map[string]any{"key": "OPS"}},
+			[]any{map[string]any{"key": "WEB"}},
+		),
+	})
+
+	keys, err := fake.client().ListTeamKeys(context.Background())
+	if err != nil || !reflect.DeepEqual(keys, []string{"ENG", "OPS", "WEB"}) {
+		t.Errorf("ListTeamKeys() = %v, %v", keys, err)
+	}
+}
+
+func TestFindIssueLabelPaginates(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssueLabel": pagedHandler("issueLabels",
+			[]any{map[string]any{"id": "label-ws", "name": "bug"}},
+			[]any{map[string]any{"id": "label-team", "name": "bug", "team": map[string]any{"id": "team-1"}}},
+		),
+	})
+
+	label, err := fake.client().FindIssueLabel(context.Background(), "team-1", "bug")
+	if err != nil || label == nil || label.ID != "label-team" {
+		t.Errorf("FindIssueLabel() = %+v, %v, want the team's label from the second page", label, err)
+	}
+}
+
+func TestPaginateStops(t *testing.T) {
+	calls := 0
+	err := paginate("", func(after string) (pageInfo, error) {
+		calls++
+		return pageInfo{HasNextPage: true, EndCursor: "same"}, nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("expected a repeated cursor to stop after 2 pages, got %d pages, %v", calls, err)
+	}
+
+	calls = 0
+	err = paginate("", func(after string) (pageInfo, error) {
+		calls++
+		return pageInfo{HasNextPage: true, EndCursor: after + "x"}, nil
+	})
+	if err == nil || calls != maxPages {
+		t.Errorf("expected an error after %d pages, got %d pages, %v", maxPages, calls, err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// parseTemplatePartials parses the named partials that templates can include
+// with {{template "name" .}}.
+func parseTemplatePartials(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	partials := make(map[string]string, len(raw))
+	for name, v := range raw {
+		if s, ok := v.(string); ok {
+			partials[name] = s
+		}
+	}
+	return partials
+}
+
+// newTemplate parses tmplStr together with the partials it may include,
+// with templateFuncs available to both.
+func newTemplate(tmplStr string, partials map[string]string) (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(templateFuncs).Parse(tmplStr)
+	if err != nil {
+		return nil, err
+	}
+	for name, body := range partials {
+		if _, err := tmpl.New(name).Parse(body); err != nil {
+			return nil, fmt.Errorf("partial %q: %w", name, err)
+		}
+	}
+	return tmpl, nil
+}
+
+// templateError is a template that fails to parse.
+type templateError struct {
+	Option string
+	Err    error
+}
+
+// configuredTemplates returns the configured templates by option name.
+func configuredTemplates(cfg *Config) map[string]string {
+	templates := map[string]string{
+		"release_issue.title":       cfg.ReleaseIssue.Title,
+		"release_issue.description": cfg.ReleaseIssue.Description,
+		"comment_template":          cfg.CommentTemplate,
+		"promotion_template":        cfg.PromotionTemplate,
+		"re_release_template":       cfg.ReReleaseTemplate,
+		"digest.title":              cfg.Digest.Title,
+		"release_link_url":          cfg.ReleaseLinkURL,
+		"version_label_template":    cfg.VersionLabelTemplate,
+		"back_merge.title":          cfg.BackMerge.Title,
+		"verification.title":        cfg.Verification.Title,
+	}
+	for locale, tmpl := range cfg.CommentTemplates {
+		templates["comment_templates."+locale] = tmpl
+	}
+	return templates
+}
+
+// templateOptions returns the option names of the templates, sorted.
+func templateOptions(templates map[string]string) []string {
+	options := make([]string, 0, len(templates))
+	for option := range templates {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	return options
+}
+
+// templateErrors parses each configured template with the partials and
+// returns the failures in option order.
+func templateErrors(cfg *Config) []templateError {
+	templates := configuredTemplates(cfg)
+	options := templateOptions(templates)
+
+	var errs []templateError
+	for _, option := range options {
+		if _, err := newTemplate(templates[option], cfg.TemplatePartials); err != nil {
+			errs = append(errs, templateError{Option: option, Err: err})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderTemplateWithPartials(t *testing.T) {
+	partials := map[string]string{
+		"footer": "See {{.TagName}}",
+	}
+
+	got, err := renderTemplate(`Released {{.Version}}. {{template "footer" .}}`,
+		plugin.ReleaseContext{Version: "1.2.0", TagName: "v1.2.0"}, partials)
+	if err != nil {
+		t.Fatalf("renderTemplate() error = %v", err)
+	}
+	if got != "Released 1.2.0. See v1.2.0" {
+		t.Errorf("renderTemplate() = %q", got)
+	}
+}
+
+func TestRenderTemplateUnknownPartial(t *testing.T) {
+	_, err := renderTemplate(`{{template "missing" .}}`, plugin.ReleaseContext{}, nil)
+	if err == nil {
+		t.Error("expected an error for an undefined partial")
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"comment_template": `{{template "footer" .}}`,
+		"template_partials": map[string]any{
+			"footer": "{{.Version}}",
+			"broken": "{{.Version",
+		},
+	})
+
+	errs := templateErrors(cfg)
+	if len(errs) == 0 {
+		t.Fatal("expected the broken partial to be reported")
+	}
+
+	cfg.TemplatePartials = map[string]string{"footer": "{{.Version}}"}
+	if errs := templateErrors(cfg); len(errs) != 0 {
+		t.Errorf("expected no template errors, got %v", errs)
+	}
+}
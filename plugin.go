@@ -4,11 +4,15 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"path"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
-	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
@@ -22,17 +26,91 @@ type LinearPlugin struct{}
 
 // Config represents Linear plugin configuration.
 type Config struct {
-	APIKey             string             `json:"api_key"`
-	TeamID             string             `json:"team_id"`
-	TeamKey            string             `json:"team_key"`
-	ProjectID          string             `json:"project_id,omitempty"`
-	IssuePrefix        string             `json:"issue_prefix"`
-	ReleasedState      string             `json:"released_state"`
-	CreateReleaseIssue bool               `json:"create_release_issue"`
-	ReleaseIssue       ReleaseIssueConfig `json:"release_issue"`
-	UpdateLinkedIssues bool               `json:"update_linked_issues"`
-	AddReleaseComment  bool               `json:"add_release_comment"`
-	CommentTemplate    string             `json:"comment_template"`
+	APIKey                 string                `json:"api_key"`
+	TeamID                 string                `json:"team_id"`
+	TeamKey                string                `json:"team_key"`
+	ProjectID              string                `json:"project_id,omitempty"`
+	IssuePrefix            string                `json:"issue_prefix"`
+	Teams                  []TeamConfig          `json:"teams,omitempty"`
+	LearnPrefixes          PrefixLearning        `json:"learn_prefixes"`
+	KnownPrefixes          []string              `json:"-"`
+	IssuePattern           string                `json:"issue_pattern,omitempty"`
+	BuiltinDenylist        bool                  `json:"builtin_denylist"`
+	Denylist               []string              `json:"denylist,omitempty"`
+	MagicWords             MagicWordConfig       `json:"magic_words"`
+	Cycle                  CycleConfig           `json:"cycle"`
+	Credentials            CredentialsConfig     `json:"credentials"`
+	Rollback               RollbackConfig        `json:"rollback"`
+	BackMerge              BackMergeConfig       `json:"back_merge"`
+	Verification           VerificationConfig    `json:"verification"`
+	FreezeWindows          []FreezeWindow        `json:"freeze_windows,omitempty"`
+	FreezeException        FreezeExceptionConfig `json:"freeze_exception"`
+	ReleasedState          string                `json:"released_state"`
+	CreateReleaseIssue     bool                  `json:"create_release_issue"`
+	ReleaseIssue           ReleaseIssueConfig    `json:"release_issue"`
+	UpdateLinkedIssues     bool                  `json:"update_linked_issues"`
+	AddReleaseComment      bool                  `json:"add_release_comment"`
+	CommentTemplate        string                `json:"comment_template"`
+	CommentMode            string                `json:"comment_mode"`
+	MaxCommentLength       int                   `json:"max_comment_length"`
+	CommentOverflow        string                `json:"comment_overflow"`
+	Concurrency            int                   `json:"concurrency"`
+	CommentTemplates       map[string]string     `json:"comment_templates,omitempty"`
+	Locale                 string                `json:"locale,omitempty"`
+	TemplatePartials       map[string]string     `json:"template_partials,omitempty"`
+	EnrichReleaseNotes     bool                  `json:"enrich_release_notes"`
+	AddReleaseLinks        bool                  `json:"add_release_links"`
+	ReleaseLinkIconURL     string                `json:"release_link_icon_url,omitempty"`
+	ReleaseLinkURL         string                `json:"release_link_url,omitempty"`
+	SkipPreviouslyReleased bool                  `json:"skip_previously_released"`
+	PromotionComments      bool                  `json:"promotion_comments"`
+	PromotionTemplate      string                `json:"promotion_template"`
+	ReReleaseComments      bool                  `json:"re_release_comments"`
+	ReReleaseTemplate      string                `json:"re_release_template"`
+	ReReleaseLabel         string                `json:"re_release_label"`
+	LinkUpstreamReleases   bool                  `json:"link_upstream_releases"`
+	LinkPullRequests       bool                  `json:"link_pull_requests"`
+	BranchIssues           bool                  `json:"branch_issues"`
+	EditReleaseComments    bool                  `json:"edit_release_comments"`
+	StateMap               map[string]string     `json:"state_map,omitempty"`
+	PrereleaseState        string                `json:"prerelease_state,omitempty"`
+	TransitionClosedIssues bool                  `json:"transition_closed_issues"`
+	ReleasedStateFallback  string                `json:"released_state_fallback"`
+	ThreadReleaseComments  bool                  `json:"thread_release_comments"`
+	ReleaseThreadTitle     string                `json:"release_thread_title"`
+	ExcludeViews           []string              `json:"exclude_views,omitempty"`
+	Milestone              MilestoneConfig       `json:"milestone"`
+	VersionLabelTemplate   string                `json:"version_label_template,omitempty"`
+	VersionLabelGroup      string                `json:"version_label_group,omitempty"`
+	ProjectHealth          ProjectHealthConfig   `json:"project_health"`
+	ExpectedOrganization   string                `json:"expected_organization,omitempty"`
+	ExportDataset          bool                  `json:"export_dataset"`
+	ReleaseBodyLinks       bool                  `json:"release_body_links"`
+	Sandbox                SandboxConfig         `json:"sandbox"`
+	ExecutionDeadline      time.Duration         `json:"execution_deadline,omitempty"`
+	// RetryBudget caps the resends across all calls of one execution;
+	// negative means unlimited.
+	RetryBudget          int                    `json:"retry_budget"`
+	Digest               DigestConfig           `json:"digest"`
+	UnarchiveIssues      bool                   `json:"unarchive_issues"`
+	PreflightPermissions bool                   `json:"preflight_permissions"`
+	Canary               CanaryConfig           `json:"canary"`
+	Naming               NamingConfig           `json:"naming"`
+	Quiet                QuietConfig            `json:"quiet"`
+	VerifyTransitions    TransitionVerification `json:"verify_transitions"`
+	Tracing              TracingConfig          `json:"tracing"`
+	// Debug logs every Linear request with its variables, response status
+	// and timing to stderr, with secrets redacted.
+	Debug             bool                 `json:"debug"`
+	RequestSigning    RequestSigningConfig `json:"request_signing"`
+	Network           NetworkConfig        `json:"network"`
+	ReleaseNotes      ReleaseNotesConfig   `json:"release_notes"`
+	ReportFile        string               `json:"report_file,omitempty"`
+	MutationOrder     []string             `json:"mutation_order"`
+	PriorityGuardrail PriorityGuardrail    `json:"priority_guardrail"`
+	// WorkspaceConfig locates shared defaults kept in Linear; repository
+	// settings take precedence over them.
+	WorkspaceConfig WorkspaceConfigSource `json:"workspace_config"`
 }
 
 // ReleaseIssueConfig contains settings for release tracking issues.
@@ -40,8 +118,16 @@ type ReleaseIssueConfig struct {
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	Labels      []string `json:"labels"`
-	Priority    int      `json:"priority"`
-	Assignee    string   `json:"assignee,omitempty"`
+	// Priority is taken from the team's default issue template when
+	// unset.
+	Priority *int   `json:"priority,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+	// Sections, when set, replaces the description template with sections
+	// assembled by the plugin from release and Linear data.
+	Sections []string `json:"sections,omitempty"`
+	// RefreshDescription updates the description of an existing release
+	// issue when a re-publish changes it.
+	RefreshDescription bool `json:"refresh_description,omitempty"`
 }
 
 // GetInfo returns plugin metadata.
@@ -53,7 +139,9 @@ func (p *LinearPlugin) GetInfo() plugin.Info {
 		Author:      "Relicta",
 		Hooks: []plugin.Hook{
 			plugin.HookPostPlan,
+			plugin.HookPrePublish,
 			plugin.HookPostPublish,
+			plugin.HookOnSuccess,
 			plugin.HookOnError,
 		},
 	}
@@ -61,20 +149,188 @@ func (p *LinearPlugin) GetInfo() plugin.Info {
 
 // Execute handles plugin execution for the specified hook.
 func (p *LinearPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
-	cfg := p.parseConfig(req.Config)
+	ctx, retries := withRetryLog(ctx)
+	ctx, rc := withResultCollector(ctx)
 
+	// A dry run must never change anything in Linear, whatever code path
+	// it takes
+	var guard *readOnlyGuard
+	if req.DryRun {
+		ctx, guard = withReadOnly(ctx)
+	}
+
+	rawConfig := req.Config
+	cfg := p.parseConfig(rawConfig)
+
+	// Trace every Linear call of the run, including the workspace lookup
+	var tracer *slogTracer
+	if cfg.Tracing.Enabled {
+		t, closeTrace, err := openTracer(cfg.Tracing)
+		if err != nil {
+			rc.warn("tracing", "Failed to open trace file %s: %v", cfg.Tracing.File, err)
+		} else {
+			defer func() { _ = closeTrace() }()
+			tracer = t
+			ctx = withTracer(ctx, tracer)
+		}
+	}
+
+	if cfg.Debug {
+		ctx = withDebugLogger(ctx, cfg.debugLogger())
+	}
+
+	// Share one retry budget across every call of the execution
+	var retryLimit *retryBudget
+	if cfg.RetryBudget >= 0 {
+		ctx, retryLimit = withRetryBudget(ctx, cfg.RetryBudget)
+	}
+
+	// Route rehearsals to the sandbox before anything is read from Linear,
+	// so no production workspace settings or teams leak into them
+	sandbox := applySandbox(cfg, req.Context)
+	switch {
+	case sandbox && cfg.WorkspaceConfig.enabled():
+		rc.skip("workspace_config", "Sandbox release: not loading workspace config from %s in the production workspace", cfg.WorkspaceConfig)
+	case cfg.WorkspaceConfig.enabled() && cfg.hasCredentials():
+		// Apply workspace defaults kept in Linear before the hook reads the
+		// config
+		merged, err := loadWorkspaceConfig(ctx, cfg.client().ReadOnly(), cfg.WorkspaceConfig, rawConfig)
+		if err != nil {
+			rc.warn("workspace_config", "Could not load workspace config from %s, using repository config only: %v", cfg.WorkspaceConfig, err)
+		} else {
+			rawConfig = merged
+			cfg = p.parseConfig(rawConfig)
+			rc.output("workspace_config", cfg.WorkspaceConfig.String())
+		}
+	}
+
+	// Select per-version behavior before quiet mode adjusts it
+	if raw, matched := applyVersionRules(rawConfig, req.Context.Version); len(matched) > 0 {
+		cfg = p.parseConfig(raw)
+		rc.output("version_rules", matched)
+	}
+	// Reparsing dropped the sandbox routing; rules cannot change it
+	if sandbox {
+		applySandbox(cfg, req.Context)
+	}
+	if applyQuiet(cfg, req.Context) {
+		rc.skip("quiet", "Quiet release: only attaching release links")
+		rc.output("quiet", true)
+	}
+	if applyPrereleaseState(cfg, req.Context) {
+		rc.output("prerelease_state", cfg.PrereleaseState)
+	}
+	if replaced, err := applyReleaseNotesSource(cfg, &req.Context); err != nil {
+		rc.warn("release_notes", "Using the host's release notes: %v", err)
+	} else if replaced {
+		rc.output("release_notes_source", cfg.ReleaseNotes.Source)
+	}
+
+	// Only extract identifiers whose prefix is a real team key
+	if cfg.LearnPrefixes.Enabled && cfg.hasCredentials() {
+		keys, learned, err := learnPrefixes(ctx, cfg.client().ReadOnly(), cfg.LearnPrefixes.File, req.DryRun, time.Now())
+		switch {
+		case err != nil:
+			rc.warn("learn_prefixes", "Extracting issues without known team keys: %v", err)
+		case learned:
+			rc.success("learn_prefixes", "Learned %d team key(s) and recorded them in %s", len(keys), cfg.LearnPrefixes.File)
+		}
+		if err == nil {
+			cfg.KnownPrefixes = keys
+			rc.output("known_prefixes", keys)
+		}
+	}
+
+	// Degrade gracefully on hosts that send less context than features need
+	if req.Hook == plugin.HookPostPublish {
+		if disabled := disableMissingContextFeatures(cfg, req.Context); len(disabled) > 0 {
+			for _, r := range featureRequirements {
+				if needs, ok := disabled[r.Option]; ok {
+					rc.warn("compatibility", "%s disabled: the host did not provide the %s", r.Option, needs)
+				}
+			}
+			rc.output("disabled_features", disabled)
+		}
+	}
+
+	if err := p.dispatch(ctx, cfg, req); err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		rc.output("dry_run", true)
+		if blocked := guard.Blocked(); len(blocked) > 0 {
+			rc.warn("dry_run", "Blocked %d mutation(s) during the dry run: %s", len(blocked), strings.Join(blocked, ", "))
+		}
+	}
+
+	if sandbox {
+		rc.output("sandbox", true)
+	}
+
+	// Surface retries so flaky-API trends are visible across releases
+	if records := retries.Records(); len(records) > 0 {
+		rc.warn("retries", "%s", retries.message())
+		rc.output("retries", records)
+	}
+	if retryLimit != nil && retryLimit.exhausted() {
+		rc.warn("retry_budget", "%s", retryLimit.message())
+		rc.output("retry_budget", retryLimit.summary())
+	}
+	// Expose the API key's remaining budget so runs nearing the hourly
+	// limit are noticed before requests get rejected
+	if cfg.hasCredentials() {
+		if budget, ok := cfg.client().rateLimit.Budget(); ok {
+			rc.output("rate_limit", budget)
+			if budget.Requests.low() {
+				rc.warn("rate_limit", "Linear API request budget low, throttling requests: %s", budget.Requests)
+			}
+			if budget.Complexity.low() {
+				rc.warn("rate_limit", "Linear API complexity budget low, throttling requests: %s", budget.Complexity)
+			}
+		}
+	}
+	if tracer != nil {
+		if slow := tracer.Slow(); len(slow) > 0 {
+			rc.warn("tracing", "%d Linear call(s) took longer than %s", len(slow), tracer.threshold)
+			rc.output("slow_calls", slow)
+		}
+	}
+
+	if cfg.ReportFile != "" {
+		if err := rc.writeReport(cfg.ReportFile, req.Hook, req.Context); err != nil {
+			rc.warn("report_file", "Failed to write report to %s: %v", cfg.ReportFile, err)
+		}
+	}
+
+	resp := rc.response()
+	if sandbox {
+		resp.Message = "[sandbox] " + resp.Message
+	}
+	return resp, nil
+}
+
+// dispatch routes execution to the handler for the requested hook. Handlers
+// receive the hook's run, which reports into the collector attached to ctx.
+func (p *LinearPlugin) dispatch(ctx context.Context, cfg *Config, req plugin.ExecuteRequest) error {
+	run := newReleaseRun(ctx, cfg, req)
 	switch req.Hook {
 	case plugin.HookPostPlan:
-		return p.handlePostPlan(ctx, cfg, req.Context, req.DryRun)
+		return p.handlePostPlan(ctx, run)
+	case plugin.HookPrePublish:
+		return p.handlePrePublish(ctx, run)
 	case plugin.HookPostPublish:
-		return p.handlePostPublish(ctx, cfg, req.Context, req.DryRun)
+		return p.handlePostPublish(ctx, run)
+	case plugin.HookOnSuccess:
+		return p.handleOnSuccess(ctx, run)
 	case plugin.HookOnError:
-		return p.handleOnError(ctx, cfg, req.Context, req.DryRun)
+		return p.handleOnError(ctx, run)
 	default:
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: fmt.Sprintf("Hook %s not implemented", req.Hook),
-		}, nil
+		if req.DryRun {
+			resultsFrom(ctx).skip("hook", "Would take no Linear action: hook %s not implemented", req.Hook)
+			return nil
+		}
+		resultsFrom(ctx).skip("hook", "Hook %s not implemented", req.Hook)
+		return nil
 	}
 }
 
@@ -83,36 +339,273 @@ func (p *LinearPlugin) Validate(ctx context.Context, config map[string]any) (*pl
 	vb := helpers.NewValidationBuilder()
 	cfg := p.parseConfig(config)
 
-	// Validate API key
-	if cfg.APIKey == "" {
+	// Validate credentials
+	if !cfg.hasCredentials() {
 		vb.AddError("api_key", "Linear API key is required")
 		return vb.Build(), nil
 	}
+	credentialsOK := cfg.Credentials.validate(vb)
+	cfg.RequestSigning.validate(vb)
+	cfg.Network.validate(vb)
+	cfg.ReleaseNotes.validate(vb)
 
 	// Validate team configuration
 	if cfg.TeamID == "" && cfg.TeamKey == "" {
 		vb.AddError("team_id", "Either team_id or team_key is required")
 	}
 
+	// Validate issue pattern
+	if cfg.IssuePattern != "" {
+		if re, err := regexp.Compile(cfg.IssuePattern); err != nil {
+			vb.AddError("issue_pattern", fmt.Sprintf("Invalid issue pattern: %v", err))
+		} else if re.NumSubexp() == 0 {
+			vb.AddError("issue_pattern", "Issue pattern needs a capture group around the identifier")
+		}
+	}
+
+	// Validate denylist patterns
+	for _, pattern := range cfg.Denylist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			vb.AddError("denylist", fmt.Sprintf("Invalid denylist pattern %q: %v", pattern, err))
+		}
+	}
+
+	// Validate magic word mutations
+	for _, class := range []string{referenceClosing, referenceRelated, referenceUnmarked} {
+		for _, kind := range cfg.MagicWords.mutations(class) {
+			if !isKnownMutation(kind) {
+				vb.AddError("magic_words."+class, fmt.Sprintf("Unknown mutation %q (must be one of %s)", kind, strings.Join(defaultMutationOrder, ", ")))
+			}
+		}
+	}
+
+	// Validate rollback
+	switch cfg.Rollback.ReleaseIssue {
+	case rollbackCancel, rollbackDelete, rollbackKeep:
+	default:
+		vb.AddError("rollback.release_issue", fmt.Sprintf("Unknown release issue action %q (must be cancel, delete or keep)", cfg.Rollback.ReleaseIssue))
+	}
+
+	// Validate teams
+	for i, t := range cfg.Teams {
+		if t.Key == "" {
+			vb.AddError(fmt.Sprintf("teams[%d].key", i), "Each team needs the key its issues are prefixed with")
+		}
+	}
+
+	// Validate sandbox configuration
+	if cfg.Sandbox.Enabled {
+		if cfg.Sandbox.APIKey == "" {
+			vb.AddError("sandbox.api_key", "Sandbox API key is required when sandbox routing is enabled")
+		}
+		if cfg.Sandbox.TeamID == "" && cfg.Sandbox.TeamKey == "" {
+			vb.AddError("sandbox.team_id", "Either sandbox.team_id or sandbox.team_key is required")
+		}
+	}
+
+	// Validate execution deadline
+	if raw := helpers.NewConfigParser(config).GetString("execution_deadline", "", ""); raw != "" {
+		if d, err := time.ParseDuration(raw); err != nil || d < 0 {
+			vb.AddError("execution_deadline", "Execution deadline must be a positive duration such as \"5m\"")
+		}
+	}
+
+	// Validate transition verification delay
+	if raw := helpers.NewConfigParser(helpers.NewConfigParser(config).GetMap("verify_transitions")).GetString("delay", "", ""); raw != "" {
+		if d, err := time.ParseDuration(raw); err != nil || d < 0 {
+			vb.AddError("verify_transitions.delay", "Verification delay must be a positive duration such as \"30s\"")
+		}
+	}
+
+	// Validate tracing threshold
+	if raw := helpers.NewConfigParser(helpers.NewConfigParser(config).GetMap("tracing")).GetString("slow_threshold", "", ""); raw != "" {
+		if d, err := time.ParseDuration(raw); err != nil || d < 0 {
+			vb.AddError("tracing.slow_threshold", "Slow call threshold must be a positive duration such as \"2s\"")
+		}
+	}
+
+	// Validate released state fallback
+	if cfg.ReleasedStateFallback != noStateFallback && !slices.Contains(workflowStateTypes, cfg.ReleasedStateFallback) {
+		vb.AddError("released_state_fallback", fmt.Sprintf("Fallback must be a workflow state type (%s) or '%s'", strings.Join(workflowStateTypes, ", "), noStateFallback))
+	}
+
+	// Validate state map
+	validateStateMap(vb, cfg.StateMap)
+
+	// Validate comment mode
+	if !isValidCommentMode(cfg.CommentMode) {
+		vb.AddError("comment_mode", "Comment mode must be 'per_issue' or 'per_assignee'")
+	}
+	if cfg.CommentMode == commentModeAssignee && cfg.marksComments() {
+		vb.AddError("comment_mode", "per_assignee comments carry no per-issue release markers, so they cannot be combined with skip_previously_released, promotion_comments or edit_release_comments")
+	}
+	if cfg.CommentMode == commentModeAssignee && cfg.ThreadReleaseComments {
+		vb.AddError("thread_release_comments", "Release threads collect per-issue release comments, so they cannot be combined with per_assignee comments")
+	}
+
+	// Validate comment length safeguard
+	if cfg.MaxCommentLength < 0 {
+		vb.AddError("max_comment_length", "Max comment length must not be negative")
+	}
+	if !isValidCommentOverflow(cfg.CommentOverflow) {
+		vb.AddError("comment_overflow", "Comment overflow must be 'link' or 'document'")
+	} else if cfg.CommentOverflow == commentOverflowDocument && cfg.ProjectID == "" {
+		vb.AddError("comment_overflow", "Release notes documents require project_id")
+	}
+
+	// Validate back-merge reminders
+	for _, pattern := range cfg.BackMerge.Branches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			vb.AddError("back_merge.branches", fmt.Sprintf("Invalid branch pattern %q: %v", pattern, err))
+		}
+	}
+	if cfg.BackMerge.DueInDays < 0 {
+		vb.AddError("back_merge.due_in_days", "Due window must not be negative")
+	}
+
+	// Validate freeze windows
+	for i, w := range cfg.FreezeWindows {
+		if err := w.validate(); err != nil {
+			vb.AddError(fmt.Sprintf("freeze_windows[%d]", i), fmt.Sprintf("Invalid freeze window %s: %v", w, err))
+		}
+	}
+
+	// Validate the verification checklist
+	if cfg.Verification.Enabled && len(cfg.Verification.Checklist) == 0 {
+		vb.AddError("verification.checklist", "Verification checklist must not be empty")
+	}
+
+	// Validate concurrency
+	if cfg.Concurrency < 1 || cfg.Concurrency > maxConcurrency {
+		vb.AddError("concurrency", fmt.Sprintf("Concurrency must be between 1 and %d", maxConcurrency))
+	}
+
+	// Validate milestone configuration
+	if cfg.Milestone.Enabled && cfg.ProjectID == "" {
+		vb.AddError("milestone", "Milestone assignment requires project_id")
+	}
+
+	// Validate project health configuration
+	if cfg.ProjectHealth.Enabled && cfg.ProjectID == "" {
+		vb.AddError("project_health", "Project health requires project_id")
+	}
+	if cfg.ProjectHealth.AppendToUpdate && (!cfg.Digest.Enabled || cfg.Digest.Target != digestTargetProjectUpdate) {
+		vb.AddError("project_health.append_to_update", "Appending project health requires a digest posted as a project_update")
+	}
+
+	// Validate digest configuration
+	if cfg.Digest.Enabled {
+		if cfg.ProjectID == "" {
+			vb.AddError("digest", "Release digests require project_id")
+		}
+		if cfg.Digest.Target != digestTargetProjectUpdate && cfg.Digest.Target != digestTargetDocument {
+			vb.AddError("digest.target", "Digest target must be 'project_update' or 'document'")
+		}
+		if !cfg.Digest.runsOn(plugin.HookPostPublish) && !cfg.Digest.runsOn(plugin.HookOnSuccess) {
+			vb.AddError("digest.hook", "Digest hook must be 'post-publish' or 'on-success'")
+		}
+	}
+
+	// Validate release issue sections
+	for _, section := range cfg.ReleaseIssue.Sections {
+		if !isKnownSection(section) {
+			vb.AddError("release_issue.sections", fmt.Sprintf("Unknown section %q (supported: %s)", section, strings.Join(knownSections, ", ")))
+		}
+	}
+
+	// Validate templates and the partials they include
+	for _, e := range templateErrors(cfg) {
+		vb.AddError(e.Option, fmt.Sprintf("Invalid template: %v", e.Err))
+	}
+
+	// Validate mutation order
+	for _, kind := range helpers.NewConfigParser(config).GetStringSlice("mutation_order", nil) {
+		if !isKnownMutation(kind) {
+			vb.AddError("mutation_order", fmt.Sprintf("Unknown mutation %q (supported: %s)", kind, strings.Join(defaultMutationOrder, ", ")))
+		}
+	}
+
+	// Validate version rules
+	for _, rule := range parseVersionRules(config) {
+		if !isValidVersionRuleMatch(rule.Match) {
+			vb.AddError("version_rules", fmt.Sprintf("Invalid version rule match %q (e.g. \"*.*.0\" or \">=2.0.0 <3.0.0\")", rule.Match))
+		}
+	}
+
+	// Validate quiet release patterns
+	for _, pattern := range cfg.Quiet.FullReleases {
+		if !isValidVersionPattern(pattern) {
+			vb.AddError("quiet.full_releases", fmt.Sprintf("Invalid version pattern %q (e.g. \"*.*.0\")", pattern))
+		}
+	}
+
+	// Validate naming prefix
+	if prefix := cfg.Naming.Prefix; prefix != "" && !namingPrefixPattern.MatchString(prefix) {
+		vb.AddError("naming.prefix", "Naming prefix may only contain lowercase letters, digits, '-' and '_'")
+	}
+
+	// Validate priority guardrail
+	if g := cfg.PriorityGuardrail; g.Threshold < priorityNone || g.Threshold > priorityLow {
+		vb.AddError("priority_guardrail.threshold", "Priority guardrail threshold must be between 0 and 4")
+	}
+
 	// Validate priority range
-	if cfg.ReleaseIssue.Priority < 0 || cfg.ReleaseIssue.Priority > 4 {
+	if p := cfg.ReleaseIssue.Priority; p != nil && (*p < 0 || *p > 4) {
 		vb.AddError("release_issue.priority", "Priority must be between 0 and 4")
 	}
 
 	// Validate API key format (Linear API keys start with "lin_api_")
-	if cfg.APIKey != "" && !strings.HasPrefix(cfg.APIKey, "lin_api_") {
+	static := cfg.Credentials.Provider == credentialStatic
+	if static && !strings.HasPrefix(cfg.APIKey, "lin_api_") {
 		vb.AddError("api_key", "Invalid Linear API key format (should start with 'lin_api_')")
 	}
 
-	// Test API connectivity if key is provided
-	if cfg.APIKey != "" && strings.HasPrefix(cfg.APIKey, "lin_api_") {
-		client := NewLinearClient(cfg.APIKey)
-		if _, err := client.GetViewer(ctx); err != nil {
-			vb.AddError("api_key", fmt.Sprintf("Failed to authenticate with Linear: %v", err))
+	// Test API connectivity if the credentials look usable
+	var token *tokenInfo
+	if static && strings.HasPrefix(cfg.APIKey, "lin_api_") || !static && credentialsOK {
+		client := cfg.client().ReadOnly()
+		if viewer, err := client.GetViewer(ctx); err != nil {
+			problem := diagnoseConnectivity(err)
+			vb.AddErrorWithCode("api_key", problem.format(err), problem.Code)
+		} else {
+			info := newTokenInfo(viewer)
+			token = &info
+			if err := checkOrganization(viewer, cfg.ExpectedOrganization); err != nil {
+				vb.AddErrorWithCode("expected_organization", err.Error(), "wrong_organization")
+			}
+			if err := checkTeamAccess(ctx, client, cfg, info); err != nil {
+				field := "team_key"
+				if cfg.TeamID != "" {
+					field = "team_id"
+				}
+				vb.AddErrorWithCode(field, err.Error(), "team_not_in_organization")
+			}
+			if cfg.WorkspaceConfig.enabled() {
+				if _, err := loadWorkspaceConfig(ctx, client, cfg.WorkspaceConfig, config); err != nil {
+					vb.AddError("workspace_config", fmt.Sprintf("Failed to load workspace config from %s: %v", cfg.WorkspaceConfig, err))
+				}
+			}
+			if cfg.PreflightPermissions {
+				// The probes are mutations meant to fail, so they need the
+				// full client
+				for _, m := range checkPermissions(ctx, cfg.client(), requiredProbes(cfg)) {
+					vb.AddErrorWithCode(m.Option, m.String(), "permission_denied")
+				}
+			}
 		}
 	}
 
-	return vb.Build(), nil
+	// ValidateResponse has no field for notes, so who the key authenticates
+	// as is reported as a coded entry that leaves the result's validity alone
+	resp := vb.Build()
+	if token != nil {
+		resp.Errors = append(resp.Errors, plugin.ValidationError{
+			Field:   "api_key",
+			Message: "Authenticated as " + token.String(),
+			Code:    validationCodeTokenInfo,
+		})
+	}
+	return resp, nil
 }
 
 // parseConfig parses and applies defaults to the configuration.
@@ -120,26 +613,72 @@ func (p *LinearPlugin) parseConfig(raw map[string]any) *Config {
 	parser := helpers.NewConfigParser(raw)
 
 	cfg := &Config{
-		APIKey:             parser.GetString("api_key", "LINEAR_API_KEY", ""),
-		TeamID:             parser.GetString("team_id", "LINEAR_TEAM_ID", ""),
-		TeamKey:            parser.GetString("team_key", "", ""),
-		ProjectID:          parser.GetString("project_id", "", ""),
-		IssuePrefix:        parser.GetString("issue_prefix", "", ""),
-		ReleasedState:      parser.GetString("released_state", "", "Done"),
-		CreateReleaseIssue: parser.GetBool("create_release_issue", true),
-		UpdateLinkedIssues: parser.GetBool("update_linked_issues", true),
-		AddReleaseComment:  parser.GetBool("add_release_comment", true),
-		CommentTemplate:    parser.GetString("comment_template", "", "Released in {{.Version}}"),
+		APIKey:                 parser.GetString("api_key", "LINEAR_API_KEY", ""),
+		TeamID:                 parser.GetString("team_id", "LINEAR_TEAM_ID", ""),
+		TeamKey:                parser.GetString("team_key", "", ""),
+		ProjectID:              parser.GetString("project_id", "", ""),
+		IssuePrefix:            parser.GetString("issue_prefix", "", ""),
+		IssuePattern:           parser.GetString("issue_pattern", "", ""),
+		BuiltinDenylist:        parser.GetBool("builtin_denylist", true),
+		Denylist:               parser.GetStringSlice("denylist", nil),
+		ReleasedState:          parser.GetString("released_state", "", "Done"),
+		CreateReleaseIssue:     parser.GetBool("create_release_issue", true),
+		UpdateLinkedIssues:     parser.GetBool("update_linked_issues", true),
+		AddReleaseComment:      parser.GetBool("add_release_comment", true),
+		CommentTemplate:        parser.GetString("comment_template", "", "Released in {{.Version}}"),
+		CommentMode:            parser.GetString("comment_mode", "", commentModeIssue),
+		MaxCommentLength:       parser.GetInt("max_comment_length", defaultMaxCommentLength),
+		CommentOverflow:        parser.GetString("comment_overflow", "", commentOverflowLink),
+		Concurrency:            parser.GetInt("concurrency", defaultConcurrency),
+		CommentTemplates:       parseCommentTemplates(parser.GetMap("comment_templates")),
+		TemplatePartials:       parseTemplatePartials(parser.GetMap("template_partials")),
+		Locale:                 parser.GetString("locale", "", ""),
+		EnrichReleaseNotes:     parser.GetBool("enrich_release_notes", false),
+		AddReleaseLinks:        parser.GetBool("add_release_links", false),
+		ReleaseLinkIconURL:     parser.GetString("release_link_icon_url", "", ""),
+		ReleaseLinkURL:         parser.GetString("release_link_url", "", ""),
+		SkipPreviouslyReleased: parser.GetBool("skip_previously_released", false),
+		PromotionComments:      parser.GetBool("promotion_comments", false),
+		PromotionTemplate:      parser.GetString("promotion_template", "", "Promoted from {{.PromotedFrom}} to {{.Version}}"),
+		ReReleaseComments:      parser.GetBool("re_release_comments", false),
+		ReReleaseTemplate:      parser.GetString("re_release_template", "", "Released again in {{.Version}} after {{.PreviousVersion}}, likely as a regression fix"),
+		ReReleaseLabel:         parser.GetString("re_release_label", "", "re-released"),
+		LinkUpstreamReleases:   parser.GetBool("link_upstream_releases", false),
+		LinkPullRequests:       parser.GetBool("link_pull_requests", false),
+		BranchIssues:           parser.GetBool("branch_issues", false),
+		EditReleaseComments:    parser.GetBool("edit_release_comments", false),
+		StateMap:               parseStateMap(parser.GetMap("state_map")),
+		PrereleaseState:        parser.GetString("prerelease_state", "", ""),
+		TransitionClosedIssues: parser.GetBool("transition_closed_issues", false),
+		ReleasedStateFallback:  strings.ToLower(parser.GetString("released_state_fallback", "", "completed")),
+		ThreadReleaseComments:  parser.GetBool("thread_release_comments", false),
+		ReleaseThreadTitle:     parser.GetString("release_thread_title", "", "Release history"),
+		ExcludeViews:           parser.GetStringSlice("exclude_views", nil),
+		VersionLabelTemplate:   parser.GetString("version_label_template", "", ""),
+		VersionLabelGroup:      parser.GetString("version_label_group", "", ""),
+		ExpectedOrganization:   parser.GetString("expected_organization", "", ""),
+		ExportDataset:          parser.GetBool("export_dataset", false),
+		ReleaseBodyLinks:       parser.GetBool("release_body_links", false),
+		UnarchiveIssues:        parser.GetBool("unarchive_issues", false),
+		ReportFile:             parser.GetString("report_file", "", ""),
+		MutationOrder:          parseMutationOrder(parser.GetStringSlice("mutation_order", nil)),
+		PreflightPermissions:   parser.GetBool("preflight_permissions", true),
 	}
 
 	// Parse release issue config
 	if releaseIssue, ok := raw["release_issue"].(map[string]any); ok {
 		riParser := helpers.NewConfigParser(releaseIssue)
 		cfg.ReleaseIssue = ReleaseIssueConfig{
-			Title:       riParser.GetString("title", "", "Release {{.Version}}"),
-			Description: riParser.GetString("description", "", defaultReleaseDescription),
-			Priority:    riParser.GetInt("priority", 4),
-			Assignee:    riParser.GetString("assignee", "", ""),
+			Title:              riParser.GetString("title", "", "Release {{.Version}}"),
+			Description:        riParser.GetString("description", "", defaultReleaseDescription),
+			Assignee:           riParser.GetString("assignee", "", ""),
+			Sections:           riParser.GetStringSlice("sections", nil),
+			RefreshDescription: riParser.GetBool("refresh_description", false),
+		}
+		// An explicit null leaves the priority to the team's default
+		if v, ok := releaseIssue["priority"]; ok && v != nil {
+			priority := riParser.GetInt("priority", priorityLow)
+			cfg.ReleaseIssue.Priority = &priority
 		}
 		if labels, ok := releaseIssue["labels"].([]any); ok {
 			for _, l := range labels {
@@ -152,13 +691,49 @@ func (p *LinearPlugin) parseConfig(raw map[string]any) *Config {
 		cfg.ReleaseIssue = ReleaseIssueConfig{
 			Title:       "Release {{.Version}}",
 			Description: defaultReleaseDescription,
-			Priority:    4,
 			Labels:      []string{"release"},
 		}
 	}
 
-	// Use team key as issue prefix if not specified
-	if cfg.IssuePrefix == "" && cfg.TeamKey != "" {
+	cfg.Sandbox = parseSandboxConfig(parser.GetMap("sandbox"))
+	cfg.Digest = parseDigestConfig(parser.GetMap("digest"))
+	cfg.Canary = parseCanaryConfig(parser.GetMap("canary"))
+	cfg.Naming = parseNamingConfig(parser.GetMap("naming"))
+	cfg.Quiet = parseQuietConfig(parser.GetMap("quiet"))
+	cfg.VerifyTransitions = parseTransitionVerification(parser.GetMap("verify_transitions"))
+	cfg.Tracing = parseTracingConfig(parser.GetMap("tracing"))
+	cfg.Debug = parser.GetBool("debug", false)
+	cfg.RequestSigning = parseRequestSigningConfig(parser.GetMap("request_signing"))
+	cfg.Network = parseNetworkConfig(parser.GetMap("network"))
+	cfg.ReleaseNotes = parseReleaseNotesConfig(parser.GetMap("release_notes"))
+	cfg.Milestone = parseMilestoneConfig(parser.GetMap("milestone"))
+	cfg.Teams = parseTeamConfigs(raw)
+	cfg.LearnPrefixes = parsePrefixLearning(parser.GetMap("learn_prefixes"))
+	cfg.MagicWords = parseMagicWordConfig(parser.GetMap("magic_words"))
+	cfg.Cycle = parseCycleConfig(parser.GetMap("cycle"))
+	cfg.Credentials = parseCredentialsConfig(parser.GetMap("credentials"))
+	cfg.Rollback = parseRollbackConfig(parser.GetMap("rollback"))
+	cfg.BackMerge = parseBackMergeConfig(parser.GetMap("back_merge"))
+	cfg.Verification = parseVerificationConfig(parser.GetMap("verification"))
+	cfg.FreezeWindows = parseFreezeWindows(raw)
+	cfg.FreezeException = parseFreezeExceptionConfig(parser.GetMap("freeze_exception"))
+	cfg.ProjectHealth = parseProjectHealthConfig(parser.GetMap("project_health"))
+	cfg.PriorityGuardrail = parsePriorityGuardrail(parser.GetMap("priority_guardrail"))
+	cfg.WorkspaceConfig = parseWorkspaceConfigSource(parser.GetMap("workspace_config"))
+
+	cfg.RetryBudget = parser.GetInt("retry_budget", defaultRetryBudget)
+	if d, err := time.ParseDuration(parser.GetString("execution_deadline", "", "0")); err == nil {
+		cfg.ExecutionDeadline = d
+	}
+
+	// The first of several teams is the primary team unless one is set
+	if cfg.TeamID == "" && cfg.TeamKey == "" && len(cfg.Teams) > 0 {
+		cfg.TeamID, cfg.TeamKey = cfg.Teams[0].ID, cfg.Teams[0].Key
+	}
+
+	// Use team key as issue prefix if not specified; with several teams
+	// extraction is restricted to their keys instead
+	if cfg.IssuePrefix == "" && cfg.TeamKey != "" && len(cfg.Teams) == 0 {
 		cfg.IssuePrefix = cfg.TeamKey
 	}
 
@@ -174,243 +749,1209 @@ const defaultReleaseDescription = `## Release {{.Version}}
 ### Changes
 {{.ReleaseNotes}}`
 
+// issueRegexp returns the pattern identifiers are extracted from commits
+// with. An invalid issue_pattern, reported by Validate, falls back to the
+// default.
+func (c *Config) issueRegexp() *regexp.Regexp {
+	if c.IssuePattern == "" {
+		return issuePattern
+	}
+	re, err := regexp.Compile(c.IssuePattern)
+	if err != nil {
+		return issuePattern
+	}
+	return re
+}
+
+// updatesLinkedIssues reports whether any action applies to linked issues.
+func (c *Config) updatesLinkedIssues() bool {
+	return c.UpdateLinkedIssues || c.AddReleaseComment || c.AddReleaseLinks ||
+		(c.Milestone.Enabled && c.ProjectID != "") || c.VersionLabelTemplate != "" ||
+		c.Cycle.movesLinkedIssues()
+}
+
 // handlePostPlan extracts linked issues from commits.
-func (p *LinearPlugin) handlePostPlan(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+func (p *LinearPlugin) handlePostPlan(ctx context.Context, run *releaseRun) error {
+	cfg, rc := run.cfg, run.results
+
 	// Extract issues from commit messages
-	var commitMessages []string
-	if releaseCtx.Changes != nil {
-		for _, c := range releaseCtx.Changes.Features {
-			commitMessages = append(commitMessages, c.Description)
-		}
-		for _, c := range releaseCtx.Changes.Fixes {
-			commitMessages = append(commitMessages, c.Description)
-		}
-		for _, c := range releaseCtx.Changes.Breaking {
-			commitMessages = append(commitMessages, c.Description)
-		}
-		for _, c := range releaseCtx.Changes.Other {
-			commitMessages = append(commitMessages, c.Description)
-		}
+	issues := linkedIssueIDs(cfg, run.release)
+	rc.output("diagnostics", extractionDiagnostics(cfg, run.release))
+
+	if len(issues) == 0 {
+		rc.skip("linked_issues", "No linked Linear issues found in commits")
+		rc.output("linked_issues", []string{})
+		return nil
 	}
 
-	issues := extractIssues(commitMessages, cfg.IssuePrefix)
+	// Extraction is read-only, so a dry run reports the same result
+	rc.success("linked_issues", "Found %d linked Linear issues: %s", len(issues), strings.Join(issues, ", "))
+	rc.output("linked_issues", issues)
 
-	if len(issues) == 0 {
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "No linked Linear issues found in commits",
-			Outputs: map[string]any{
-				"linked_issues": []string{},
-			},
-		}, nil
-	}
-
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: fmt.Sprintf("Found %d linked Linear issues: %s", len(issues), strings.Join(issues, ", ")),
-		Outputs: map[string]any{
-			"linked_issues": issues,
-		},
-	}, nil
+	// Flag issues an earlier version already released, likely regressions
+	if cfg.ReReleaseComments && cfg.hasCredentials() {
+		reReleased, errs := detectReReleases(ctx, run.client.ReadOnly(), cfg, run.release.Version, issues)
+		for _, e := range errs {
+			rc.warn("re_release", "%s", e)
+		}
+		if len(reReleased) > 0 {
+			rc.warn("re_release", "%d linked issue(s) were already released by an earlier version, likely regression fixes: %s",
+				len(reReleased), describeReReleases(reReleased))
+			rc.output("re_released_issues", reReleased)
+		}
+	}
+	return nil
 }
 
 // handlePostPublish creates release issue and updates linked issues.
-func (p *LinearPlugin) handlePostPublish(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	var results []string
+func (p *LinearPlugin) handlePostPublish(ctx context.Context, run *releaseRun) error {
+	cfg, rc := run.cfg, run.results
 
-	if dryRun {
+	if run.dryRun {
 		if cfg.CreateReleaseIssue {
-			title, _ := renderTemplate(cfg.ReleaseIssue.Title, releaseCtx)
-			results = append(results, fmt.Sprintf("Would create release issue: %s", title))
+			title, _ := run.render(ctx, cfg.ReleaseIssue.Title)
+			rc.success("release_issue", "Would create release issue: %s", cfg.Naming.title(title))
+			if cfg.ReleaseIssue.Priority == nil && cfg.hasCredentials() {
+				planReleaseIssueDefaults(ctx, run)
+			}
 		}
-		if cfg.UpdateLinkedIssues {
-			results = append(results, fmt.Sprintf("Would update linked issues to state: %s", cfg.ReleasedState))
+		if cfg.EnrichReleaseNotes {
+			rc.success("enrich", "Would enrich release notes with issue titles")
 		}
-		if cfg.AddReleaseComment {
-			comment, _ := renderTemplate(cfg.CommentTemplate, releaseCtx)
-			results = append(results, fmt.Sprintf("Would add comment to linked issues: %s", comment))
+		if cfg.Digest.runsOn(plugin.HookPostPublish) {
+			rc.success("digest", "Would publish a release digest as a %s if due", cfg.Digest.Target)
+		}
+		if cfg.Canary.enabled() {
+			rc.success("canary", "Would perform at most %d mutation(s) (canary)", cfg.Canary.MaxMutations)
 		}
+		if upstreams := upstreamReleases(run.release); cfg.LinkUpstreamReleases && cfg.CreateReleaseIssue && len(upstreams) > 0 {
+			rc.success("upstream_releases", "Would link %d upstream release(s) to the release issue", len(upstreams))
+		}
+		if prs := pullRequestsByIssue(run.release, cfg.issueRegexp(), cfg.IssuePrefix); cfg.LinkPullRequests && len(prs) > 0 {
+			rc.success("pull_request_links", "Would attach pull request links to %d issue(s) unless already linked", len(prs))
+		}
+		if cfg.VerifyTransitions.Enabled && cfg.UpdateLinkedIssues {
+			rc.success("verify_transitions", "Would re-check issue states %s after the update", cfg.VerifyTransitions.Delay)
+		}
+		if cfg.Milestone.Enabled && cfg.ProjectID != "" {
+			rc.success("milestone", "Would add linked issues to project milestone %q", cfg.Milestone.Name)
+		}
+		if cfg.Cycle.Enabled && cfg.Cycle.ReleaseIssue && cfg.CreateReleaseIssue {
+			rc.success("cycle", "Would add the release issue to the team's active cycle")
+		}
+		if cfg.Cycle.movesLinkedIssues() {
+			rc.success("cycle", "Would add linked issues to the team's active cycle")
+		}
+		if cfg.ProjectHealth.Enabled && cfg.ProjectID != "" {
+			rc.success("project_health", "Would report the project's open and closed issue counts")
+		}
+		if cfg.ExportDataset {
+			rc.success("release_dataset", "Would export the release dataset")
+		}
+		if cfg.AddReleaseComment && cfg.CommentMode == commentModeAssignee {
+			rc.success("assignee_comments", "Would post one summary comment per assignee instead of commenting every issue")
+		}
+		if cfg.ReleaseBodyLinks {
+			rc.success("release_body_links", "Would output a Linear issues block for the VCS release body")
+		}
+		if window := cfg.activeFreezeWindow(time.Now()); window != nil {
+			rc.output("freeze_window", window.String())
+			rc.success("freeze_exception", "Would flag the release issue as a freeze exception (freeze window %s)", window)
+		}
+		if cfg.Verification.Enabled {
+			rc.success("verification", "Would create a verification issue with %d checklist item(s)", len(cfg.Verification.Checklist))
+		}
+		if cfg.BackMerge.appliesTo(run.release.Branch) {
+			rc.success("back_merge", "Would create a reminder to merge %s back into %s", run.release.Branch, cfg.BackMerge.Target)
+		}
+
+		// Tabulate per-issue changes so the plan reads well in the UI
+		if cfg.VersionLabelTemplate != "" {
+			name, _ := renderTemplate(cfg.VersionLabelTemplate, run.release, cfg.TemplatePartials)
+			rc.success("version_label", "Would label linked issues %s", strings.TrimSpace(name))
+		}
+		if cfg.updatesLinkedIssues() {
+			client := run.client.ReadOnly()
+			issues := linkedIssueIDs(cfg, run.release)
+			if viewer, err := client.GetViewer(ctx); err == nil {
+				var foreign []string
+				if issues, foreign = splitForeignIssues(cfg, run.release, workspaceKey(viewer), issues); len(foreign) > 0 {
+					rc.skip(issueForeignWorkspace, "Would skip %d issue(s) referenced by URL in another Linear workspace: %s", len(foreign), strings.Join(foreign, ", "))
+				}
+			}
+			if len(cfg.ExcludeViews) > 0 {
+				ex, err := loadExclusions(ctx, client, cfg.ExcludeViews)
+				if err != nil {
+					rc.warn("exclude_views", "%v; a real run would fail without touching any issue", err)
+				} else {
+					var excluded []string
+					if issues, excluded = ex.filter(issues); len(excluded) > 0 {
+						rc.skip("exclude_views", "Would leave %d issue(s) in exclusion views untouched: %s", len(excluded), strings.Join(excluded, ", "))
+					}
+				}
+			}
+			if len(issues) == 0 {
+				rc.skip("linked_issues", "No linked issues to update")
+			} else {
+				// Resolve the teams and released states as a real run would
+				var plan *linkedIssuePlan
+				if team, err := run.resolveTeam(ctx); err != nil {
+					rc.warn("team", "Failed to get team, a real run would fail: %v", err)
+				} else {
+					primary, teams, errs := resolveTeamPlans(ctx, client, cfg, team)
+					for _, e := range errs {
+						rc.warn("released_state", "%s; issues would not be transitioned", e)
+					}
+					plan = &linkedIssuePlan{primary: primary, teams: teams}
+					if len(cfg.StateMap) > 0 {
+						plan.categories = issueCategories(cfg, run.release)
+					}
+				}
+
+				rows := planLinkedIssues(ctx, client, cfg, run.release, plan, issues)
+				rc.attach(renderPlanTable(rows))
+				rc.output("plan", rows)
+				if plan != nil {
+					counts := planOutcomes(rows)
+					msg := "Would transition %d issue(s), leave %d unchanged and fail on %d"
+					if counts[outcomeFail] > 0 {
+						rc.warn("linked_issues", msg, counts[outcomeTransition], counts[outcomeUnchanged], counts[outcomeFail])
+					} else {
+						rc.success("linked_issues", msg, counts[outcomeTransition], counts[outcomeUnchanged], counts[outcomeFail])
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	client := run.client
 
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: strings.Join(results, "; "),
-		}, nil
+	// Bound the whole hook, not just individual requests
+	if cfg.ExecutionDeadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.ExecutionDeadline)
+		defer cancel()
 	}
 
-	client := NewLinearClient(cfg.APIKey)
+	// Limit mutations when trialing the plugin
+	var budget *mutationBudget
+	if cfg.Canary.enabled() {
+		ctx, budget = withMutationBudget(ctx, cfg.Canary.MaxMutations)
+	}
+
+	// Fail fast on bad credentials or network before touching any issue
+	viewer, err := probeConnectivity(ctx, client.ReadOnly())
+	if err != nil {
+		rc.fail("connectivity", "%v", err)
+		return nil
+	}
+	rc.output("linear_token", newTokenInfo(viewer))
+
+	// Never touch a workspace other than the one the config was written for
+	if err := checkOrganization(viewer, cfg.ExpectedOrganization); err != nil {
+		rc.fail("expected_organization", "%v", err)
+		return nil
+	}
 
 	// Get team info
-	team, err := client.GetTeam(ctx, cfg.TeamID, cfg.TeamKey)
+	team, err := run.resolveTeam(ctx)
 	if err != nil {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   fmt.Sprintf("Failed to get team: %v", err),
-		}, nil
+		rc.fail("team", "Failed to get team: %v", err)
+		return nil
 	}
 
-	// Create release issue
-	if cfg.CreateReleaseIssue {
-		issue, err := p.createReleaseIssue(ctx, client, cfg, releaseCtx, team)
+	issues := linkedIssueIDs(cfg, run.release)
+
+	// Issue URLs of other workspaces cannot be resolved with this API key
+	issues, foreign := splitForeignIssues(cfg, run.release, workspaceKey(viewer), issues)
+	if len(foreign) > 0 {
+		rc.skip(issueForeignWorkspace, "Skipped %d issue(s) referenced by URL in another Linear workspace: %s", len(foreign), strings.Join(foreign, ", "))
+		rc.output("foreign_issues", foreign)
+	}
+
+	// Leave issues curated into exclusion views in Linear alone
+	var excluded []string
+	if len(cfg.ExcludeViews) > 0 {
+		ex, err := loadExclusions(ctx, client.ReadOnly(), cfg.ExcludeViews)
 		if err != nil {
-			return &plugin.ExecuteResponse{
-				Success: false,
-				Error:   fmt.Sprintf("Failed to create release issue: %v", err),
-			}, nil
+			rc.fail("exclude_views", "%v", err)
+			return nil
 		}
-		results = append(results, fmt.Sprintf("Created release issue: %s (%s)", issue.Identifier, issue.URL))
+		ctx = withExclusions(ctx, ex)
+		issues, excluded = ex.filter(issues)
 	}
 
-	// Extract and update linked issues
-	if cfg.UpdateLinkedIssues || cfg.AddReleaseComment {
-		var commitMessages []string
-		if releaseCtx.Changes != nil {
-			for _, c := range releaseCtx.Changes.Features {
-				commitMessages = append(commitMessages, c.Description)
+	// Enrich release notes before any template sees them
+	if cfg.EnrichReleaseNotes {
+		notes, errs := enrichReleaseNotes(ctx, client.ReadOnly(), cfg, run.release.ReleaseNotes)
+		run.release.ReleaseNotes = notes
+		rc.output("release_notes", notes)
+		for _, e := range errs {
+			rc.warn("enrich", "%s", e)
+		}
+	}
+
+	// Create release issue
+	var releaseIssue *Issue
+	journal := releaseJournal{Version: run.release.Version}
+	if cfg.CreateReleaseIssue {
+		var linked []*Issue
+		if sectionsNeedIssues(cfg.ReleaseIssue.Sections) {
+			var errs []string
+			linked, errs = fetchIssues(ctx, client, issues)
+			for _, e := range errs {
+				rc.warn("release_issue", "%s", e)
 			}
-			for _, c := range releaseCtx.Changes.Fixes {
-				commitMessages = append(commitMessages, c.Description)
+		}
+
+		issue, outcome, err := p.ensureReleaseIssue(ctx, run, linked)
+		switch {
+		case errors.Is(err, errMutationSkipped):
+			// Reported in the canary summary
+		case err != nil:
+			rc.fail("release_issue", "Failed to create release issue: %v", err)
+			return nil
+		case outcome == releaseIssueCreated:
+			rc.success("release_issue", "Created release issue: %s (%s)", issue.Identifier, issue.URL)
+			rc.output("release_issue", issue.Identifier)
+			journal.ReleaseIssue = &journalIssue{ID: issue.ID, Identifier: issue.Identifier}
+		case outcome == releaseIssueRefreshed:
+			rc.success("release_issue", "Release issue %s already exists for %s; refreshed its description", issue.Identifier, run.release.Version)
+			rc.output("release_issue", issue.Identifier)
+		default:
+			rc.success("release_issue", "Release issue %s already exists for %s; added update comment", issue.Identifier, run.release.Version)
+			rc.output("release_issue", issue.Identifier)
+		}
+		releaseIssue = issue
+
+		// Schedule the release issue into the team's active cycle
+		if cfg.Cycle.Enabled && cfg.Cycle.ReleaseIssue && issue != nil {
+			cycle, err := client.GetActiveCycle(ctx, team.ID)
+			switch {
+			case err != nil:
+				rc.warn("cycle", "Failed to get active cycle: %v", err)
+			case cycle == nil:
+				rc.skip("cycle", "Team %s has no active cycle", team.Key)
+			default:
+				rc.output("cycle", cycle)
+				if err := client.UpdateIssueCycle(ctx, issue.ID, cycle.ID); err != nil {
+					if !errors.Is(err, errMutationSkipped) {
+						rc.warn("cycle", "Failed to add %s to cycle %s: %v", issue.Identifier, cycle, err)
+					}
+				} else {
+					rc.success("cycle", "Added release issue %s to cycle %s", issue.Identifier, cycle)
+				}
 			}
-			for _, c := range releaseCtx.Changes.Breaking {
-				commitMessages = append(commitMessages, c.Description)
+		}
+
+		// Relate the release to the upstream releases it depends on
+		if upstreams := upstreamReleases(run.release); cfg.LinkUpstreamReleases && issue != nil && len(upstreams) > 0 {
+			linked, warnings := linkUpstreamReleases(ctx, client, issue, upstreams)
+			if len(linked) > 0 {
+				rc.success("upstream_releases", "Linked %d upstream release(s)", len(linked))
+				rc.output("upstream_releases", linked)
 			}
-			for _, c := range releaseCtx.Changes.Other {
-				commitMessages = append(commitMessages, c.Description)
+			for _, w := range warnings {
+				rc.warn("upstream_releases", "%s", w)
 			}
 		}
+	}
 
-		issues := extractIssues(commitMessages, cfg.IssuePrefix)
-		if len(issues) > 0 {
-			updated, commented, errs := p.processLinkedIssues(ctx, client, cfg, releaseCtx, team, issues)
-			if updated > 0 {
-				results = append(results, fmt.Sprintf("Updated %d issue(s) to '%s'", updated, cfg.ReleasedState))
+	// Escalate releases published during a freeze window
+	if window := cfg.activeFreezeWindow(time.Now()); window != nil {
+		rc.output("freeze_window", window.String())
+		if releaseIssue == nil {
+			rc.warn("freeze_exception", "Released during freeze window %s, but there is no release issue to flag", window)
+		} else {
+			err := escalateFreezeException(ctx, run, releaseIssue, window)
+			switch {
+			case errors.Is(err, errMutationSkipped):
+			case err != nil:
+				rc.warn("freeze_exception", "Failed to flag %s as a freeze exception: %v", releaseIssue.Identifier, err)
+			default:
+				rc.success("freeze_exception", "Flagged release issue %s as a freeze exception (freeze window %s)", releaseIssue.Identifier, window)
 			}
-			if commented > 0 {
-				results = append(results, fmt.Sprintf("Added release comment to %d issue(s)", commented))
+		}
+	}
+
+	// Track post-release verification apart from the release record
+	if cfg.Verification.Enabled {
+		issue, created, err := ensureVerificationIssue(ctx, run, releaseIssue)
+		switch {
+		case errors.Is(err, errMutationSkipped):
+		case err != nil && issue == nil:
+			rc.warn("verification", "Failed to create verification issue: %v", err)
+		case created:
+			rc.success("verification", "Created verification issue: %s (%s)", issue.Identifier, issue.URL)
+			rc.output("verification_issue", issue.Identifier)
+			if err != nil {
+				rc.warn("verification", "%v", err)
 			}
-			if len(errs) > 0 {
-				for _, e := range errs {
-					results = append(results, fmt.Sprintf("Warning: %s", e))
-				}
+		default:
+			rc.skip("verification", "Verification issue %s already exists for %s", issue.Identifier, run.release.Version)
+			rc.output("verification_issue", issue.Identifier)
+		}
+	}
+
+	// Remind the team to merge the release branch back
+	if cfg.BackMerge.appliesTo(run.release.Branch) {
+		issue, created, err := ensureBackMergeReminder(ctx, run)
+		switch {
+		case errors.Is(err, errMutationSkipped):
+		case err != nil:
+			rc.warn("back_merge", "Failed to create back-merge reminder: %v", err)
+		case created:
+			rc.success("back_merge", "Created back-merge reminder: %s (%s)", issue.Identifier, issue.URL)
+			rc.output("back_merge_issue", issue.Identifier)
+		default:
+			rc.skip("back_merge", "Back-merge reminder %s already exists for %s", issue.Identifier, run.release.Version)
+			rc.output("back_merge_issue", issue.Identifier)
+		}
+	}
+
+	// Extract and update linked issues
+	var shipped []*Issue
+	processed := cfg.updatesLinkedIssues() && len(issues) > 0
+	if processed {
+		res := p.processLinkedIssues(ctx, run, issues)
+		res.report(rc, cfg)
+		shipped = res.Shipped
+		journal.Transitions = res.Transitions
+		excluded = append(excluded, res.Excluded...)
+
+		// Summarize per assignee instead of commenting every issue
+		if cfg.AddReleaseComment && cfg.CommentMode == commentModeAssignee && len(shipped) > 0 {
+			posted, errs := postAssigneeComments(ctx, client, cfg, run.release, releaseIssue, shipped)
+			if len(posted) > 0 {
+				rc.success("assignee_comments", "Posted %d summary comment(s), one per assignee", len(posted))
+				rc.output("assignee_comments", posted)
+			}
+			for _, e := range errs {
+				rc.warn("assignee_comments", "%s", e)
 			}
 		}
 	}
+	if len(excluded) > 0 {
+		excluded = sortedIssueIDs(excluded)
+		rc.skip("exclude_views", "Left %d issue(s) in exclusion views untouched: %s", len(excluded), strings.Join(excluded, ", "))
+		rc.output("excluded_issues", excluded)
+	}
+
+	// Record the changes so a rollback can revert them
+	if cfg.Rollback.Enabled {
+		journal.RecordedAt = time.Now().UTC()
+		if err := writeJournal(cfg.Rollback.Journal, journal); err != nil {
+			rc.warn("rollback", "Failed to write rollback journal: %v", err)
+		} else {
+			rc.output("rollback_journal", cfg.Rollback.Journal)
+		}
+	}
+
+	if cfg.ExportDataset {
+		rc.output("release_dataset", buildReleaseDataset(run.release, shipped, time.Now()))
+	}
+
+	// Link the VCS release back to Linear
+	if cfg.ReleaseBodyLinks {
+		linked := shipped
+		if !processed {
+			var errs []string
+			linked, errs = fetchIssues(ctx, client, issues)
+			for _, e := range errs {
+				rc.warn("release_body_links", "%s", e)
+			}
+		}
+		if len(linked) > 0 {
+			rc.output("release_body_links", renderLinkback(linked))
+		}
+	}
+
+	// Snapshot project progress now that this release's issues have moved
+	var healthReport string
+	if cfg.ProjectHealth.Enabled && cfg.ProjectID != "" {
+		health, err := loadProjectHealth(ctx, client.ReadOnly(), cfg.ProjectID)
+		if err != nil {
+			rc.warn("project_health", "%v", err)
+		} else {
+			rc.success("project_health", "Project health: %s", health)
+			rc.output("project_health", health)
+			if cfg.ProjectHealth.AppendToUpdate {
+				healthReport = health.markdown()
+			}
+		}
+	}
+
+	// Publish the periodic digest once this release is recorded
+	if cfg.Digest.runsOn(plugin.HookPostPublish) {
+		msg, err := publishDigest(ctx, client, cfg, run.release, team, time.Now(), healthReport)
+		switch {
+		case errors.Is(err, errMutationSkipped):
+		case err != nil:
+			rc.warn("digest", "%v", err)
+		default:
+			rc.success("digest", "%s", msg)
+		}
+	}
 
-	if len(results) == 0 {
-		results = append(results, "No actions taken")
+	if budget != nil {
+		rc.success("canary", "%s", budget.message())
+		rc.output("canary", budget.summary())
 	}
 
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: strings.Join(results, "; "),
-	}, nil
+	return nil
 }
 
-// handleOnError handles release failure notifications.
-func (p *LinearPlugin) handleOnError(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	// For now, just log that an error occurred
-	// Could be extended to create a failure tracking issue
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: "Release failure noted (no Linear action taken)",
-	}, nil
+// handleOnSuccess publishes the release digest when it is bound to this hook.
+func (p *LinearPlugin) handleOnSuccess(ctx context.Context, run *releaseRun) error {
+	cfg, rc := run.cfg, run.results
+
+	if !cfg.Digest.runsOn(plugin.HookOnSuccess) {
+		rc.skip("digest", "No Linear action configured for on-success")
+		return nil
+	}
+
+	if run.dryRun {
+		rc.success("digest", "Would publish a release digest as a %s if due", cfg.Digest.Target)
+		return nil
+	}
+
+	client := run.client
+	if err := verifyOrganization(ctx, client.ReadOnly(), cfg); err != nil {
+		rc.fail("expected_organization", "%v", err)
+		return nil
+	}
+
+	team, err := run.resolveTeam(ctx)
+	if err != nil {
+		rc.fail("team", "Failed to get team: %v", err)
+		return nil
+	}
+
+	var healthReport string
+	if cfg.ProjectHealth.Enabled && cfg.ProjectHealth.AppendToUpdate && cfg.ProjectID != "" {
+		health, err := loadProjectHealth(ctx, client.ReadOnly(), cfg.ProjectID)
+		if err != nil {
+			rc.warn("project_health", "%v", err)
+		} else {
+			healthReport = health.markdown()
+		}
+	}
+
+	msg, err := publishDigest(ctx, client, cfg, run.release, team, time.Now(), healthReport)
+	if err != nil {
+		rc.fail("digest", "%v", err)
+		return nil
+	}
+
+	rc.success("digest", "%s", msg)
+	return nil
+}
+
+// handleOnError handles release failure notifications. The SDK has no
+// rollback hook, so when rollback is enabled the Linear changes of the
+// release are rolled back on any failure reported to OnError.
+func (p *LinearPlugin) handleOnError(ctx context.Context, run *releaseRun) error {
+	if run.cfg.Rollback.Enabled {
+		return p.rollbackOnError(ctx, run)
+	}
+	if run.dryRun {
+		run.results.skip("on_error", "Would take no Linear action on release failure")
+		return nil
+	}
+	run.results.skip("on_error", "Release failure noted (no Linear action taken)")
+	return nil
+}
+
+// renderReleaseIssue renders the release issue title and description. The
+// description carries a release marker so re-published versions can find it.
+func renderReleaseIssue(ctx context.Context, run *releaseRun, linked []*Issue) (title, description string, err error) {
+	cfg, releaseCtx := run.cfg, run.release
+	title, err = run.render(ctx, cfg.ReleaseIssue.Title)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render title template: %w", err)
+	}
+
+	if len(cfg.ReleaseIssue.Sections) > 0 {
+		description = renderSections(cfg.ReleaseIssue.Sections, releaseCtx, linked)
+	} else {
+		description, err = run.render(ctx, cfg.ReleaseIssue.Description)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to render description template: %w", err)
+		}
+	}
+
+	return cfg.Naming.title(title), appendMarker(description, cfg.Naming.marker(markerRelease), releaseCtx.Version), nil
 }
 
 // createReleaseIssue creates a new issue for tracking the release.
-func (p *LinearPlugin) createReleaseIssue(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, team *Team) (*Issue, error) {
-	title, err := renderTemplate(cfg.ReleaseIssue.Title, releaseCtx)
+func (p *LinearPlugin) createReleaseIssue(ctx context.Context, run *releaseRun, linked []*Issue) (*Issue, error) {
+	cfg := run.cfg
+	title, description, err := renderReleaseIssue(ctx, run, linked)
 	if err != nil {
-		return nil, fmt.Errorf("failed to render title template: %w", err)
+		return nil, err
 	}
 
-	description, err := renderTemplate(cfg.ReleaseIssue.Description, releaseCtx)
+	defaults, err := resolveReleaseIssueDefaults(ctx, run.client.ReadOnly(), cfg, run.team.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to render description template: %w", err)
+		run.results.warn("release_issue_defaults", "%v; using %s", err, defaults)
 	}
+	run.results.output("release_issue_defaults", defaults)
 
 	input := CreateIssueInput{
-		TeamID:      team.ID,
+		TeamID:      run.team.ID,
 		Title:       title,
 		Description: description,
-		Priority:    cfg.ReleaseIssue.Priority,
+		Priority:    Set(defaults.Priority),
+		TemplateID:  defaults.TemplateID,
 	}
 
 	if cfg.ProjectID != "" {
 		input.ProjectID = cfg.ProjectID
 	}
 
-	return client.CreateIssue(ctx, input)
+	return run.client.CreateIssue(ctx, input)
 }
 
-// processLinkedIssues updates state and adds comments to linked issues.
-func (p *LinearPlugin) processLinkedIssues(ctx context.Context, client *LinearClient, cfg *Config, releaseCtx plugin.ReleaseContext, team *Team, issueIDs []string) (updated int, commented int, errs []string) {
-	// Find the released state ID
-	var releasedStateID string
-	if cfg.UpdateLinkedIssues && cfg.ReleasedState != "" {
-		for _, state := range team.States {
-			if strings.EqualFold(state.Name, cfg.ReleasedState) {
-				releasedStateID = state.ID
-				break
-			}
-		}
-		if releasedStateID == "" {
-			errs = append(errs, fmt.Sprintf("State '%s' not found in team workflow", cfg.ReleasedState))
-		}
+// linkedIssueResults summarizes the actions taken on linked issues.
+type linkedIssueResults struct {
+	Updated    int
+	Commented  int
+	Attached   int
+	Milestoned int
+	Labelled   int
+	// Milestone is the project milestone of the release, if assigned.
+	Milestone *ProjectMilestone
+	// Label is the version label of the release, if added.
+	Label *Label
+	// Cycled counts issues added to the active cycle.
+	Cycled int
+	// Cycle is the team's active cycle, if issues were added to it.
+	Cycle *Cycle
+	// CommentTruncated reports that the release comment exceeded
+	// max_comment_length and was cut short, linking to CommentLinkout.
+	CommentTruncated bool
+	CommentLinkout   string
+	// References maps issues to the classes of the commit references to
+	// them, with magic_words.
+	References map[string][]string
+	// PreviouslyReleased maps skipped issues to the version they were
+	// already released in.
+	PreviouslyReleased map[string]string
+	// Promoted maps issues to the prerelease they were promoted from.
+	Promoted map[string]string
+	// ReReleased maps issues an earlier version already released to that
+	// version.
+	ReReleased map[string]string
+	// Unprocessed lists issues left untouched because the execution
+	// deadline was reached.
+	Unprocessed []string
+	// Unavailable groups issues that could not be processed by reason
+	// (archived, deleted, access_denied).
+	Unavailable map[string][]string
+	// Unarchived lists archived issues restored before processing.
+	Unarchived []string
+	// Excluded lists issues found in exclude_views only after lookup.
+	Excluded []string
+	// Protected lists issues left untouched by the priority guardrail.
+	Protected []string
+	// Closed maps issues left in their completed or canceled state to
+	// that state.
+	Closed map[string]string
+	// Renamed maps extracted identifiers to the canonical identifiers Linear
+	// resolved them to.
+	Renamed map[string]string
+	// ReleasedState is the primary team's released state as resolved,
+	// which differs from released_state after a fallback.
+	ReleasedState string
+	// StateFallbacks maps team keys to the state used in place of a
+	// missing released state.
+	StateFallbacks map[string]string
+	// Transitions records the verified state change of each updated issue.
+	Transitions []stateTransition
+	// EditedComments lists issues whose release comment for this version
+	// was edited in place.
+	EditedComments []string
+	// PullRequests maps issues to the pull request links attached to them.
+	PullRequests map[string][]string
+	// Workload aggregates shipped issues per assignee.
+	Workload workloadTracker
+	// Shipped holds the issues released by this version.
+	Shipped []*Issue
+	Errors  []string
+}
+
+// newLinkedIssueResults creates empty results.
+func newLinkedIssueResults() *linkedIssueResults {
+	return &linkedIssueResults{
+		PreviouslyReleased: make(map[string]string),
+		Promoted:           make(map[string]string),
+		ReReleased:         make(map[string]string),
+		Renamed:            make(map[string]string),
+		Closed:             make(map[string]string),
+		PullRequests:       make(map[string][]string),
+		Unavailable:        make(map[string][]string),
+		Workload:           make(workloadTracker),
 	}
+}
+
+// processLinkedIssues updates state and adds comments to linked issues.
+func (p *LinearPlugin) processLinkedIssues(ctx context.Context, run *releaseRun, issueIDs []string) *linkedIssueResults {
+	cfg, client, team := run.cfg, run.client, run.team
+	res := newLinkedIssueResults()
+
+	// Find the released state ID, per team when several are configured
+	primary, teams, errs := resolveTeamPlans(ctx, client.ReadOnly(), cfg, team)
+	res.Errors = append(res.Errors, errs...)
+	res.ReleasedState = primary.state
+	res.StateFallbacks = stateFallbacks(primary, teams, team)
 
 	// Render comment template
 	var comment string
 	if cfg.AddReleaseComment {
 		var err error
-		comment, err = renderTemplate(cfg.CommentTemplate, releaseCtx)
+		comment, err = run.render(ctx, commentTemplate(cfg, run.release))
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("Failed to render comment template: %v", err))
+			res.Errors = append(res.Errors, fmt.Sprintf("Failed to render comment template: %v", err))
 			cfg.AddReleaseComment = false
 		}
+		var marker string
+		if cfg.marksComments() {
+			marker = appendMarker("", cfg.Naming.marker(markerReleased), run.release.Version)
+		}
+		if cfg.AddReleaseComment {
+			comment = fitComment(ctx, run, comment, utf8.RuneCountInString(marker), res)
+		}
+		comment += marker
 	}
 
-	for _, issueID := range issueIDs {
-		// Get issue details
-		issue, err := client.GetIssueByIdentifier(ctx, issueID)
+	var links []releaseLink
+	if cfg.AddReleaseLinks {
+		var err error
+		links, err = releaseLinks(cfg, run.release)
+		switch {
+		case err != nil:
+			res.Errors = append(res.Errors, fmt.Sprintf("Release links skipped: %v", err))
+		case len(links) == 0:
+			res.Errors = append(res.Errors, "Release links skipped: repository URL or tag name missing from release context")
+		}
+	}
+
+	plan := &linkedIssuePlan{version: run.release.Version, primary: primary, teams: teams, comment: comment, links: links}
+	if cfg.MagicWords.Enabled {
+		plan.references = issueReferences(cfg, run.release)
+		res.References = plan.references
+	}
+	if len(cfg.StateMap) > 0 {
+		plan.categories = issueCategories(cfg, run.release)
+	}
+	if cfg.LinkPullRequests {
+		plan.pullRequests = pullRequestsByIssue(run.release, cfg.issueRegexp(), cfg.IssuePrefix)
+	}
+	if cfg.Milestone.Enabled && cfg.ProjectID != "" {
+		plan.milestones = make(map[string]*ProjectMilestone)
+		for _, projectID := range plan.projects() {
+			milestone, err := ensureMilestone(ctx, client, cfg, run.release, projectID)
+			if err != nil {
+				res.warn(err, "Milestone assignment skipped")
+			}
+			plan.milestones[projectID] = milestone
+		}
+		res.Milestone = plan.milestones[cfg.ProjectID]
+	}
+	if cfg.Cycle.movesLinkedIssues() {
+		cycle, err := client.GetActiveCycle(ctx, team.ID)
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("Issue %s not found: %v", issueID, err))
-			continue
+			res.warn(err, "Cycle assignment skipped")
+		}
+		plan.cycle = cycle
+		res.Cycle = cycle
+	}
+	if cfg.VersionLabelTemplate != "" {
+		label, err := ensureVersionLabel(ctx, client, cfg, run.release, team)
+		if err != nil {
+			res.warn(err, "Version label skipped")
 		}
+		plan.label = label
+		res.Label = label
+	}
+	if cfg.ReReleaseComments && cfg.ReReleaseLabel != "" {
+		label, err := ensureLabel(ctx, client, team.ID, cfg.ReReleaseLabel)
+		if err != nil {
+			res.warn(err, "Re-release label skipped")
+		}
+		plan.reReleaseLabel = label
+	}
 
-		// Update state
-		if cfg.UpdateLinkedIssues && releasedStateID != "" {
-			if err := client.UpdateIssueState(ctx, issue.ID, releasedStateID); err != nil {
-				errs = append(errs, fmt.Sprintf("Failed to update %s: %v", issueID, err))
-			} else {
-				updated++
+	// Report in a stable order so re-runs produce the same logs and plans
+	issueIDs = sortedIssueIDs(issueIDs)
+
+	// Look the issues up in a few batched requests rather than one each
+	if ctx.Err() == nil {
+		issues, err := prefetchIssues(ctx, client, issueIDs)
+		if err != nil && ctx.Err() == nil {
+			res.warn(err, "Batched issue lookup failed, looking issues up one by one")
+		}
+		plan.issues = issues
+	}
+
+	// Process several issues at once, each recording into its own results,
+	// merged in issue order so reports do not depend on timing
+	results := make([]*linkedIssueResults, len(issueIDs))
+	unprocessed := make([]bool, len(issueIDs))
+	looked := make([]*Issue, len(issueIDs))
+	forEachIssue(issueIDs, cfg.linkedIssueWorkers(), func(i int) {
+		// Once the execution deadline is exhausted, leave the rest
+		// (including a partially processed issue) for a retry pass
+		if ctx.Err() != nil {
+			unprocessed[i] = true
+			return
+		}
+		results[i] = newLinkedIssueResults()
+		var ok bool
+		looked[i], ok = lookupLinkedIssue(ctx, run, plan, issueIDs[i], results[i])
+		if !ok && ctx.Err() != nil {
+			unprocessed[i] = true
+		}
+	})
+
+	// Hand issues referenced more than once to their first reference, only
+	// once all lookups are done, so the choice does not depend on timing
+	claims := claimIssues(looked)
+	prepared := make([]*linkedIssue, len(issueIDs))
+	forEachIssue(issueIDs, cfg.linkedIssueWorkers(), func(i int) {
+		if looked[i] == nil || unprocessed[i] || !claims.owns(looked[i].ID, i) {
+			return
+		}
+		if ctx.Err() != nil {
+			unprocessed[i] = true
+			return
+		}
+		var ok bool
+		prepared[i], ok = p.prepareLinkedIssue(ctx, run, plan, issueIDs[i], looked[i], results[i])
+		if !ok && ctx.Err() != nil {
+			unprocessed[i] = true
+		}
+	})
+	apply := func(kinds []string) {
+		forEachIssue(issueIDs, cfg.linkedIssueWorkers(), func(i int) {
+			if prepared[i] == nil || unprocessed[i] {
+				return
+			}
+			if ctx.Err() != nil {
+				unprocessed[i] = true
+				return
 			}
+			if !applyMutations(ctx, run, plan, prepared[i], kinds, results[i]) && ctx.Err() != nil {
+				unprocessed[i] = true
+			}
+		})
+	}
+
+	// Move issues in bulk, one mutation per released state, at the point
+	// of the mutation order state updates are configured for
+	if cfg.batchesStateUpdates() {
+		before, after := splitMutationOrder(cfg.MutationOrder, mutationState)
+		apply(before)
+		batchUpdateStates(ctx, run, plan, prepared, results, unprocessed)
+		apply(after)
+	} else {
+		apply(cfg.MutationOrder)
+	}
+	for i, issueResults := range results {
+		if issueResults != nil {
+			res.merge(issueResults)
 		}
+		if unprocessed[i] {
+			res.Unprocessed = append(res.Unprocessed, issueIDs[i])
+		}
+	}
 
-		// Add comment
-		if cfg.AddReleaseComment && comment != "" {
-			if err := client.AddComment(ctx, issue.ID, comment); err != nil {
-				errs = append(errs, fmt.Sprintf("Failed to add comment to %s: %v", issueID, err))
-			} else {
-				commented++
+	// Catch workflow automations that move issues on after a while
+	if cfg.VerifyTransitions.Enabled && len(res.Transitions) > 0 {
+		for _, err := range recheckTransitions(ctx, client, cfg.VerifyTransitions.Delay, res.Transitions) {
+			res.Errors = append(res.Errors, err.Error())
+		}
+	}
+
+	return res
+}
+
+// report adds the linked issue outcomes to the collector.
+func (r *linkedIssueResults) report(rc *resultCollector, cfg *Config) {
+	if r.Updated > 0 {
+		if len(cfg.Teams) > 0 {
+			rc.success("update_state", "Updated %d issue(s) to their team's released state", r.Updated)
+		} else {
+			rc.success("update_state", "Updated %d issue(s) to '%s'", r.Updated, r.releasedState(cfg))
+		}
+	}
+	if len(r.StateFallbacks) > 0 {
+		rc.output("released_state_fallback", r.StateFallbacks)
+	}
+	if r.Commented > 0 {
+		rc.success("comment", "Added release comment to %d issue(s)", r.Commented)
+	}
+	if r.CommentTruncated {
+		rc.skip("comment_length", "Truncated the release comment to %d characters", cfg.MaxCommentLength)
+		rc.output("comment_linkout", r.CommentLinkout)
+	}
+	if r.Milestoned > 0 {
+		rc.success("milestone", "Added %d issue(s) to milestone %s", r.Milestoned, r.Milestone.Name)
+	}
+	if r.Milestone != nil {
+		rc.output("milestone", r.Milestone)
+	}
+	if r.Labelled > 0 {
+		rc.success("version_label", "Labelled %d issue(s) %s", r.Labelled, r.Label.Name)
+	}
+	if r.Label != nil {
+		rc.output("version_label", r.Label.Name)
+	}
+	if r.Cycled > 0 {
+		rc.success("cycle", "Added %d issue(s) to cycle %s", r.Cycled, r.Cycle)
+	}
+	if r.Cycle != nil {
+		rc.output("cycle", r.Cycle)
+	}
+	if len(r.References) > 0 {
+		rc.output("issue_references", r.References)
+	}
+	if len(r.EditedComments) > 0 {
+		rc.success("comment", "Updated the release comment on %d issue(s)", len(r.EditedComments))
+		rc.output("edited_comments", r.EditedComments)
+	}
+	if r.Attached > 0 {
+		rc.success("release_links", "Attached release links to %d issue(s)", r.Attached)
+	}
+	if len(r.PullRequests) > 0 {
+		rc.success("pull_request_links", "Attached pull request links to %d issue(s)", len(r.PullRequests))
+		rc.output("pull_request_links", r.PullRequests)
+	}
+	if len(r.PreviouslyReleased) > 0 {
+		rc.skip("previously_released", "Skipped %d issue(s) already released in an earlier version", len(r.PreviouslyReleased))
+		rc.output("previously_released", r.PreviouslyReleased)
+	}
+	for _, reason := range []string{issueArchived, issueDeleted, issueAccessDenied} {
+		if ids := r.Unavailable[reason]; len(ids) > 0 {
+			rc.skip("unavailable_issues", "Skipped %d %s issue(s): %s",
+				len(ids), strings.ReplaceAll(reason, "_", " "), strings.Join(ids, ", "))
+		}
+	}
+	if len(r.Unavailable) > 0 {
+		rc.output("unavailable_issues", r.Unavailable)
+	}
+	if len(r.Unarchived) > 0 {
+		rc.success("unarchive", "Unarchived %d issue(s)", len(r.Unarchived))
+		rc.output("unarchived_issues", r.Unarchived)
+	}
+	if len(r.Protected) > 0 {
+		rc.skip("priority_guardrail", "Left %d high-priority issue(s) untouched: %s",
+			len(r.Protected), strings.Join(r.Protected, ", "))
+		rc.output("protected_issues", r.Protected)
+	}
+	if len(r.Closed) > 0 {
+		rc.skip("closed_issues", "Left %d issue(s) already completed or canceled in their state", len(r.Closed))
+		rc.output("closed_issues", r.Closed)
+	}
+	if len(r.Renamed) > 0 {
+		rc.output("renamed_issues", r.Renamed)
+	}
+	if len(r.Transitions) > 0 {
+		rc.output("state_transitions", r.Transitions)
+	}
+	bounced := make(map[string]string)
+	for _, t := range r.Transitions {
+		if t.Outcome == transitionBounced {
+			bounced[t.Issue] = t.To
+		}
+	}
+	if len(bounced) > 0 {
+		ids := make([]string, 0, len(bounced))
+		for id := range bounced {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		state := "'" + r.releasedState(cfg) + "'"
+		if len(cfg.Teams) > 0 {
+			state = "their released state"
+		}
+		rc.warn("verify_transitions", "%d issue(s) left %s after the release: %s",
+			len(bounced), state, strings.Join(ids, ", "))
+		rc.output("bounced_issues", bounced)
+	}
+	for _, t := range r.Transitions {
+		rc.audit(auditEntry{Issue: t.Issue, Field: "state", Before: t.From, After: t.To, Outcome: t.Outcome})
+	}
+	if len(r.Workload) > 0 {
+		rc.output("assignees", r.Workload.summary())
+	}
+	if len(r.Promoted) > 0 {
+		rc.success("promotion", "Promoted %d issue(s) from a prerelease", len(r.Promoted))
+		rc.output("promoted_issues", r.Promoted)
+	}
+	if len(r.ReReleased) > 0 {
+		rc.success("re_release", "Re-released %d issue(s) shipped by an earlier version: %s", len(r.ReReleased), describeReReleases(r.ReReleased))
+		rc.output("re_released_issues", r.ReReleased)
+	}
+	if len(r.Unprocessed) > 0 {
+		rc.skip("execution_deadline", "Execution deadline of %s reached; %d issue(s) left for a retry pass: %s",
+			cfg.ExecutionDeadline, len(r.Unprocessed), strings.Join(r.Unprocessed, ", "))
+		rc.output("unprocessed_issues", r.Unprocessed)
+	}
+	for _, e := range r.Errors {
+		rc.warn("linked_issues", "%s", e)
+	}
+}
+
+// warn records a failed action unless the canary withheld it, in which case
+// it is reported in the canary summary instead.
+func (r *linkedIssueResults) warn(err error, format string, args ...any) {
+	if errors.Is(err, errMutationSkipped) {
+		return
+	}
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...)+fmt.Sprintf(": %v", err))
+}
+
+// linkedIssuePlan holds the per-release values applied to every linked issue.
+type linkedIssuePlan struct {
+	version string
+	comment string
+	links   []releaseLink
+	// primary holds the released state of the primary team, used for all
+	// issues unless several teams are configured.
+	primary *teamPlan
+	// teams holds the plans of the configured teams by team key.
+	teams map[string]*teamPlan
+	// milestones maps project IDs to the milestones issues are assigned to.
+	milestones map[string]*ProjectMilestone
+	// label is the version label added to issues, if any.
+	label *Label
+	// reReleaseLabel is the label added to re-released issues, if any.
+	reReleaseLabel *Label
+	// cycle is the active cycle issues of the primary team join, if any.
+	cycle *Cycle
+	// references maps extracted identifiers to the classes of the commit
+	// references to them, with magic_words.
+	references map[string][]string
+	// pullRequests maps extracted identifiers to the pull requests of the
+	// commits referencing them.
+	pullRequests map[string][]string
+	// categories maps extracted identifiers to the commit category that
+	// selects their state, with state_map.
+	categories map[string]string
+	// issues holds the linked issues looked up in batches up front.
+	issues prefetchedIssues
+}
+
+// teamFor returns the plan of the issue's team, or nil if several teams
+// are configured and the issue belongs to none of them.
+func (p *linkedIssuePlan) teamFor(issue *Issue) *teamPlan {
+	if p.teams == nil {
+		return p.primary
+	}
+	return p.teams[issueKey(issue)]
+}
+
+// projects lists the projects whose milestones issues are assigned to.
+func (p *linkedIssuePlan) projects() []string {
+	seen := map[string]bool{p.primary.projectID: true}
+	var extra []string
+	for _, tp := range p.teams {
+		if tp.projectID != "" && !seen[tp.projectID] {
+			seen[tp.projectID] = true
+			extra = append(extra, tp.projectID)
+		}
+	}
+	sort.Strings(extra)
+	return append([]string{p.primary.projectID}, extra...)
+}
+
+// linkedIssue is a linked issue the release actions apply to.
+type linkedIssue struct {
+	issue *Issue
+	// issueID is the canonical identifier of the issue.
+	issueID string
+	// extractedID is the identifier the release referenced the issue by.
+	extractedID string
+	// comment is the release comment for the issue.
+	comment string
+	// reReleased is the earlier version that already released the issue,
+	// with re_release_comments.
+	reReleased string
+}
+
+// lookupLinkedIssue looks up a referenced issue, recording unavailable
+// issues and renamed identifiers in res. It returns nil for issues that
+// cannot be processed, and reports whether the lookup succeeded.
+func lookupLinkedIssue(ctx context.Context, run *releaseRun, plan *linkedIssuePlan, issueID string, res *linkedIssueResults) (*Issue, bool) {
+	issue, err := plan.issues.lookup(ctx, run.client, issueID)
+	if err != nil {
+		if reason := classifyIssueError(err); reason != "" {
+			res.Unavailable[reason] = append(res.Unavailable[reason], issueID)
+			return nil, true
+		}
+		res.Errors = append(res.Errors, fmt.Sprintf("Issue %s not found: %v", issueID, err))
+		return nil, false
+	}
+	if issue.Identifier != "" && !strings.EqualFold(issue.Identifier, issueID) {
+		res.Renamed[issueID] = issue.Identifier
+	}
+	return issue, true
+}
+
+// prepareLinkedIssue decides whether the release actions apply to a looked
+// up issue, recording skipped issues in res. It returns nil for skipped
+// issues, and reports whether the checks succeeded.
+func (p *LinearPlugin) prepareLinkedIssue(ctx context.Context, run *releaseRun, plan *linkedIssuePlan, issueID string, issue *Issue, res *linkedIssueResults) (*linkedIssue, bool) {
+	cfg, client := run.cfg, run.client
+
+	// Continue under the canonical identifier if the issue moved teams or
+	// its team key changed
+	extractedID := issueID
+	if issue.Identifier != "" && !strings.EqualFold(issue.Identifier, issueID) {
+		issueID = issue.Identifier
+	}
+
+	// Catch excluded issues referenced under an old identifier
+	if exclusionsFrom(ctx).excludes(issue.ID, issue.Identifier) {
+		res.Excluded = append(res.Excluded, issueID)
+		return nil, true
+	}
+
+	// Never touch urgent issues such as live incidents unless allowed
+	if cfg.PriorityGuardrail.protects(issue) {
+		res.Protected = append(res.Protected, issueID)
+		return nil, true
+	}
+
+	// Archived issues are skipped unless configured to restore them
+	switch issueAvailability(issue) {
+	case issueArchived:
+		if !cfg.UnarchiveIssues {
+			res.Unavailable[issueArchived] = append(res.Unavailable[issueArchived], issueID)
+			return nil, true
+		}
+		if err := client.UnarchiveIssue(ctx, issue.ID); err != nil {
+			res.warn(err, "Failed to unarchive %s", issueID)
+			return nil, false
+		}
+		res.Unarchived = append(res.Unarchived, issueID)
+	case issueDeleted:
+		res.Unavailable[issueDeleted] = append(res.Unavailable[issueDeleted], issueID)
+		return nil, true
+	}
+
+	// Consult the issue's release history: prereleases of this version are
+	// promoted, issues shipped by an earlier release are re-released or
+	// skipped
+	comment, reReleased := plan.comment, ""
+	if cfg.tracksReleases() {
+		versions, err := releasedVersions(ctx, client, issue.ID, cfg.Naming.marker(markerReleased))
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("Could not check release history of %s: %v", issueID, err))
+		}
+
+		from := ""
+		if cfg.PromotionComments {
+			from = promotedFrom(versions, run.release.Version)
+		}
+		previous := ""
+		if cfg.ReReleaseComments {
+			previous = reReleasedFrom(versions, run.release.Version)
+		}
+
+		switch latest := latestVersion(versions); {
+		case from != "":
+			if comment != "" {
+				comment, err = renderPromotionComment(ctx, run, from)
+				if err != nil {
+					res.Errors = append(res.Errors, fmt.Sprintf("Failed to render promotion comment for %s: %v", issueID, err))
+					comment = plan.comment
+				}
 			}
+			res.Promoted[issueID] = from
+		case previous != "":
+			if comment != "" {
+				comment, err = renderReReleaseComment(ctx, run, previous)
+				if err != nil {
+					res.Errors = append(res.Errors, fmt.Sprintf("Failed to render re-release comment for %s: %v", issueID, err))
+					comment = plan.comment
+				}
+			}
+			res.ReReleased[issueID] = previous
+			reReleased = previous
+		case cfg.SkipPreviouslyReleased && latest != "" && compareVersions(latest, run.release.Version) < 0:
+			res.PreviouslyReleased[issueID] = latest
+			return nil, true
+		}
+	}
+
+	res.Shipped = append(res.Shipped, issue)
+	return &linkedIssue{issue: issue, issueID: issueID, extractedID: extractedID, comment: comment, reReleased: reReleased}, true
+}
+
+// applyMutations applies the given kinds of release actions to a prepared
+// issue in order, limited to those the issue's references call for. It
+// reports whether every action succeeded.
+func applyMutations(ctx context.Context, run *releaseRun, plan *linkedIssuePlan, li *linkedIssue, kinds []string, res *linkedIssueResults) bool {
+	ok := true
+	for _, kind := range kinds {
+		if !run.cfg.MagicWords.allowsMutation(plan.references[li.extractedID], kind) {
+			continue
+		}
+		if !applyMutation(ctx, run, plan, kind, li, res) {
+			ok = false
 		}
 	}
+	return ok
+}
 
-	return updated, commented, errs
+// commitMessages returns the descriptions of the commits in the release.
+func commitMessages(releaseCtx plugin.ReleaseContext) []string {
+	var messages []string
+	if releaseCtx.Changes != nil {
+		for _, c := range releaseCtx.Changes.Features {
+			messages = append(messages, c.Description)
+		}
+		for _, c := range releaseCtx.Changes.Fixes {
+			messages = append(messages, c.Description)
+		}
+		for _, c := range releaseCtx.Changes.Breaking {
+			messages = append(messages, c.Description)
+		}
+		for _, c := range releaseCtx.Changes.Other {
+			messages = append(messages, c.Description)
+		}
+	}
+	return messages
 }
 
 // issuePattern matches Linear issue identifiers like ENG-123, TEAM-456.
-var issuePattern = regexp.MustCompile(`\b([A-Z]{2,10})-(\d+)\b`)
+var issuePattern = regexp.MustCompile(`\b([A-Z]{2,10}-\d+)\b`)
 
 // extractIssues extracts Linear issue identifiers from commit messages.
 func extractIssues(commits []string, prefix string) []string {
+	return extractIssuesMatching(issuePattern, commits, prefix)
+}
+
+// extractIssuesMatching extracts issue identifiers with pattern, whose first
+// capture group is the identifier, keeping those with the given prefix.
+func extractIssuesMatching(pattern *regexp.Regexp, commits []string, prefix string) []string {
 	seen := make(map[string]bool)
 	var issues []string
 
 	for _, commit := range commits {
-		matches := issuePattern.FindAllStringSubmatch(commit, -1)
+		matches := pattern.FindAllStringSubmatch(commit, -1)
 		for _, match := range matches {
-			if prefix == "" || strings.EqualFold(match[1], prefix) {
-				id := match[0]
+			id := match[0]
+			if len(match) > 1 {
+				id = match[1]
+			}
+			id = strings.ToUpper(id)
+			key, _, _ := strings.Cut(id, "-")
+			if prefix == "" || strings.EqualFold(key, prefix) {
 				if !seen[id] {
 					seen[id] = true
 					issues = append(issues, id)
@@ -423,32 +1964,73 @@ func extractIssues(commits []string, prefix string) []string {
 
 // templateData provides data for template rendering.
 type templateData struct {
-	Version      string
-	TagName      string
-	Branch       string
-	ReleaseType  string
-	ReleaseNotes string
-	Date         string
-	CommitSHA    string
+	Version       string
+	TagName       string
+	Branch        string
+	ReleaseType   string
+	ReleaseNotes  string
+	Date          string
+	CommitSHA     string
+	RepositoryURL string
+	// PromotedFrom is the prerelease an issue was promoted from; it is only
+	// set for promotion comments.
+	PromotedFrom string
+	// PreviousVersion is the version released before this one; in
+	// re-release comments, the earlier version that released the issue.
+	PreviousVersion string
+
+	// Features, Fixes and Breaking are the categorized commits of the
+	// release, with their counts.
+	Features      []plugin.ConventionalCommit
+	Fixes         []plugin.ConventionalCommit
+	Breaking      []plugin.ConventionalCommit
+	FeatureCount  int
+	FixCount      int
+	BreakingCount int
+	// FormattedChanges is the categorized changes rendered as Markdown by
+	// the plugin, independent of the host's changelog.
+	FormattedChanges string
+
+	// Team and Project are the names of the primary team and the
+	// configured project, where a hook has looked them up.
+	Team    string
+	Project string
+}
+
+// newTemplateData builds template data from the release context.
+func newTemplateData(ctx plugin.ReleaseContext) templateData {
+	data := templateData{
+		Version:         ctx.Version,
+		TagName:         ctx.TagName,
+		Branch:          ctx.Branch,
+		ReleaseType:     ctx.ReleaseType,
+		ReleaseNotes:    ctx.ReleaseNotes,
+		Date:            time.Now().Format("2006-01-02"),
+		CommitSHA:       ctx.CommitSHA,
+		RepositoryURL:   ctx.RepositoryURL,
+		PreviousVersion: ctx.PreviousVersion,
+	}
+	if c := ctx.Changes; c != nil {
+		data.Features, data.Fixes, data.Breaking = c.Features, c.Fixes, c.Breaking
+		data.FeatureCount, data.FixCount, data.BreakingCount = len(c.Features), len(c.Fixes), len(c.Breaking)
+		data.FormattedChanges = formatChanges(ctx)
+	}
+	return data
 }
 
 // renderTemplate renders a Go template with release context.
-func renderTemplate(tmplStr string, ctx plugin.ReleaseContext) (string, error) {
-	tmpl, err := template.New("").Parse(tmplStr)
+func renderTemplate(tmplStr string, ctx plugin.ReleaseContext, partials map[string]string) (string, error) {
+	return renderTemplateData(tmplStr, newTemplateData(ctx), partials)
+}
+
+// renderTemplateData renders a Go template with the given data. Named
+// partials can be included with {{template "name" .}}.
+func renderTemplateData(tmplStr string, data templateData, partials map[string]string) (string, error) {
+	tmpl, err := newTemplate(tmplStr, partials)
 	if err != nil {
 		return "", err
 	}
 
-	data := templateData{
-		Version:      ctx.Version,
-		TagName:      ctx.TagName,
-		Branch:       ctx.Branch,
-		ReleaseType:  ctx.ReleaseType,
-		ReleaseNotes: ctx.ReleaseNotes,
-		Date:         time.Now().Format("2006-01-02"),
-		CommitSHA:    ctx.CommitSHA,
-	}
-
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return "", err
@@ -125,6 +125,48 @@ func TestExtractIssues(t *testing.T) {
 	}
 }
 
+func TestExtractIssuesCustomPattern(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"issue_pattern": `\[([A-Za-z]+-\d+)\]`})
+	commits := []string{"feat: [eng-12] add export, see ENG-13", "fix: [OPS-4] SHA-256 checksums"}
+
+	got := extractIssuesMatching(cfg.issueRegexp(), commits, "")
+	want := []string{"ENG-12", "OPS-4"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("extractIssuesMatching() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateIssuePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{`\[([A-Z]+-\d+)\]`, true},
+		{`[A-Z]+-\d+`, false},
+		{`([A-Z`, false},
+	}
+
+	p := &LinearPlugin{}
+	for _, tt := range tests {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"api_key":       "lin_api_test",
+			"team_id":       "team-1",
+			"issue_pattern": tt.pattern,
+		})
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		invalid := false
+		for _, e := range resp.Errors {
+			invalid = invalid || e.Field == "issue_pattern"
+		}
+		if invalid == tt.valid {
+			t.Errorf("issue_pattern %q: valid = %v, want %v", tt.pattern, !invalid, tt.valid)
+		}
+	}
+}
+
 func TestRenderTemplate(t *testing.T) {
 	releaseCtx := plugin.ReleaseContext{
 		Version:      "1.2.3",
@@ -168,7 +210,7 @@ func TestRenderTemplate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := renderTemplate(tt.template, releaseCtx)
+			result, err := renderTemplate(tt.template, releaseCtx, nil)
 			if err != nil {
 				t.Fatalf("renderTemplate() error = %v", err)
 			}
@@ -247,7 +289,7 @@ func TestParseConfig(t *testing.T) {
 			},
 			check: func(cfg *Config) bool {
 				return cfg.ReleaseIssue.Title == "Custom Release {{.Version}}" &&
-					cfg.ReleaseIssue.Priority == 2 &&
+					cfg.ReleaseIssue.Priority != nil && *cfg.ReleaseIssue.Priority == 2 &&
 					len(cfg.ReleaseIssue.Labels) == 2
 			},
 		},
@@ -592,3 +634,128 @@ func TestLinearClientGetTeamByKey(t *testing.T) {
 		t.Errorf("Expected 3 states, got %d", len(team.States))
 	}
 }
+
+func TestLinearClientCreateAttachment(t *testing.T) {
+	var gotInput map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotInput, _ = req.Variables["input"].(map[string]any)
+
+		response := map[string]any{
+			"data": map[string]any{
+				"attachmentCreate": map[string]any{
+					"success": true,
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		endpoint:   server.URL,
+		apiKey:     "lin_api_test",
+		httpClient: http.DefaultClient,
+	}
+
+	err := client.CreateAttachment(context.Background(), AttachmentInput{
+		IssueID:  "issue-123",
+		URL:      "https://github.com/acme/app/releases/tag/v1.0.0",
+		Title:    "Release v1.0.0",
+		Subtitle: "Releases",
+	})
+	if err != nil {
+		t.Fatalf("CreateAttachment() error = %v", err)
+	}
+
+	if gotInput["subtitle"] != "Releases" {
+		t.Errorf("Expected subtitle 'Releases', got %v", gotInput["subtitle"])
+	}
+	if _, ok := gotInput["iconUrl"]; ok {
+		t.Error("Expected iconUrl to be omitted when empty")
+	}
+}
+
+func TestProcessLinkedIssuesSkipsPreviouslyReleased(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"GetIssueComments": func(vars map[string]any) any {
+			var nodes []map[string]any
+			if vars["id"] == "uuid-1" {
+				nodes = append(nodes, map[string]any{"id": "c1", "body": "Released in 1.4.0\n\n`relicta:released=1.4.0`"})
+			}
+			return map[string]any{"issue": map[string]any{"comments": map[string]any{"nodes": nodes}}}
+		},
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":     false,
+		"skip_previously_released": true,
+	})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.4.1"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+	if res.PreviouslyReleased["ENG-1"] != "1.4.0" {
+		t.Errorf("expected ENG-1 to be skipped as released in 1.4.0, got %v", res.PreviouslyReleased)
+	}
+	if res.Commented != 1 || fake.callCount("AddComment") != 1 {
+		t.Errorf("expected only ENG-2 to be commented, got %d", res.Commented)
+	}
+}
+
+func TestProcessLinkedIssuesStopsAtDeadline(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := p.processLinkedIssues(ctx, fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"ENG-1", "ENG-2"})
+
+	if len(res.Unprocessed) != 2 {
+		t.Errorf("expected both issues left for retry, got %v", res.Unprocessed)
+	}
+	if fake.callCount("GetIssue") != 0 {
+		t.Errorf("expected no API calls after the deadline, got %d", fake.callCount("GetIssue"))
+	}
+}
+
+func TestProcessLinkedIssuesUsesCanonicalIdentifier(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"OLD-7": {"id": "uuid-7", "identifier": "ENG-7"},
+			"ENG-7": {"id": "uuid-7", "identifier": "ENG-7"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"OLD-7", "ENG-7"})
+
+	if res.Renamed["OLD-7"] != "ENG-7" {
+		t.Errorf("expected OLD-7 reported as renamed to ENG-7, got %v", res.Renamed)
+	}
+	if fake.callCount("AddComment") != 1 {
+		t.Errorf("expected the issue to be commented once, got %d", fake.callCount("AddComment"))
+	}
+	if w := res.Workload.summary(); len(w) != 1 || w[0].Issues[0] != "ENG-7" {
+		t.Errorf("expected workload under the canonical identifier, got %+v", w)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// PrefixLearning restricts issue extraction to the team keys that exist in
+// the workspace, learned once and recorded in File, so look-alikes such as
+// ISO-8601 or SHA-256 are not taken for issues. Delete the file to relearn.
+type PrefixLearning struct {
+	Enabled bool   `json:"enabled"`
+	File    string `json:"file"`
+}
+
+// parsePrefixLearning parses the learn_prefixes block.
+func parsePrefixLearning(raw map[string]any) PrefixLearning {
+	parser := helpers.NewConfigParser(raw)
+	return PrefixLearning{
+		Enabled: parser.GetBool("enabled", false),
+		File:    parser.GetString("file", "", ".relicta/linear-prefixes.json"),
+	}
+}
+
+// knownPrefixes is the record of learned team keys.
+type knownPrefixes struct {
+	Keys      []string  `json:"keys"`
+	LearnedAt time.Time `json:"learned_at"`
+}
+
+// learnPrefixes returns the team keys recorded in the learning file. Without
+// a record the keys are queried from the workspace and, unless dryRun,
+// recorded. It reports whether the keys were learned by this call.
+func learnPrefixes(ctx context.Context, client *ReadOnlyLinearClient, path string, dryRun bool, now time.Time) ([]string, bool, error) {
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var record knownPrefixes
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return record.Keys, false, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, false, err
+	}
+
+	keys, err := client.ListTeamKeys(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list team keys: %w", err)
+	}
+	for i, k := range keys {
+		keys[i] = strings.ToUpper(k)
+	}
+	sort.Strings(keys)
+	if dryRun {
+		return keys, true, nil
+	}
+
+	data, err = json.MarshalIndent(knownPrefixes{Keys: keys, LearnedAt: now.UTC()}, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, false, err
+	}
+	return keys, true, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLearnPrefixes(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"ListTeamKeys": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []any{
+				map[string]any{"key": "OPS"}, map[string]any{"key": "eng"},
+			}}}
+		},
+	})
+	path := filepath.Join(t.TempDir(), ".relicta", "linear-prefixes.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	keys, learned, err := learnPrefixes(context.Background(), fake.client().ReadOnly(), path, false, now)
+	if err != nil {
+		t.Fatalf("learnPrefixes() error = %v", err)
+	}
+	if !learned || !reflect.DeepEqual(keys, []string{"ENG", "OPS"}) {
+		t.Errorf("first run = %v, learned %v", keys, learned)
+	}
+
+	// Later runs use the record without asking Linear again
+	keys, learned, err = learnPrefixes(context.Background(), fake.client().ReadOnly(), path, false, now)
+	if err != nil {
+		t.Fatalf("learnPrefixes() error = %v", err)
+	}
+	if learned || !reflect.DeepEqual(keys, []string{"ENG", "OPS"}) || fake.callCount("ListTeamKeys") != 1 {
+		t.Errorf("second run = %v, learned %v, %d queries", keys, learned, fake.callCount("ListTeamKeys"))
+	}
+}
+
+func TestLearnPrefixesDryRunRecordsNothing(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"ListTeamKeys": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []any{map[string]any{"key": "ENG"}}}}
+		},
+	})
+	path := filepath.Join(t.TempDir(), "prefixes.json")
+
+	if _, _, err := learnPrefixes(context.Background(), fake.client().ReadOnly(), path, true, time.Now()); err != nil {
+		t.Fatalf("learnPrefixes() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no record after a dry run, got %v", err)
+	}
+}
+
+func TestLinkedIssueIDsKnownPrefixes(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_id": "team-1"})
+	cfg.KnownPrefixes = []string{"ENG"}
+	releaseCtx := plugin.ReleaseContext{Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "ENG-1 hash with SHA-256 and ISO-8601 dates"}},
+	}}
+
+	if got := linkedIssueIDs(cfg, releaseCtx); !reflect.DeepEqual(got, []string{"ENG-1"}) {
+		t.Errorf("linkedIssueIDs() = %v", got)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// nilID is a syntactically valid ID that never matches an entity, so probe
+// mutations fail on lookup without changing anything.
+const nilID = "00000000-0000-0000-0000-000000000000"
+
+// permissionProbe is a harmless mutation used to check that the token may
+// perform an action the configuration requires.
+type permissionProbe struct {
+	Action string
+	Option string
+	Query  string
+	Vars   map[string]any
+}
+
+// requiredProbes returns the probes for the behaviors enabled in cfg.
+func requiredProbes(cfg *Config) []permissionProbe {
+	var probes []permissionProbe
+
+	if cfg.CreateReleaseIssue {
+		probes = append(probes, permissionProbe{
+			Action: "create issues",
+			Option: "create_release_issue",
+			Query:  `mutation ProbeIssueCreate($input: IssueCreateInput!) { issueCreate(input: $input) { success } }`,
+			Vars:   map[string]any{"input": map[string]any{"teamId": nilID, "title": "relicta permission probe"}},
+		})
+	}
+	if cfg.UpdateLinkedIssues {
+		probes = append(probes, permissionProbe{
+			Action: "update issues",
+			Option: "update_linked_issues",
+			Query:  `mutation ProbeIssueUpdate($id: String!) { issueUpdate(id: $id, input: {}) { success } }`,
+			Vars:   map[string]any{"id": nilID},
+		})
+	}
+	if cfg.AddReleaseComment || cfg.CreateReleaseIssue {
+		probes = append(probes, permissionProbe{
+			Action: "create comments",
+			Option: "add_release_comment",
+			Query:  `mutation ProbeCommentCreate($input: CommentCreateInput!) { commentCreate(input: $input) { success } }`,
+			Vars:   map[string]any{"input": map[string]any{"issueId": nilID, "body": "relicta permission probe"}},
+		})
+	}
+	if cfg.AddReleaseLinks {
+		probes = append(probes, permissionProbe{
+			Action: "create attachments",
+			Option: "add_release_links",
+			Query:  `mutation ProbeAttachmentCreate($input: AttachmentCreateInput!) { attachmentCreate(input: $input) { success } }`,
+			Vars:   map[string]any{"input": map[string]any{"issueId": nilID, "url": "https://example.invalid/relicta", "title": "relicta permission probe"}},
+		})
+	}
+	if cfg.Digest.Enabled {
+		if cfg.Digest.Target == digestTargetDocument {
+			probes = append(probes, permissionProbe{
+				Action: "create documents",
+				Option: "digest",
+				Query:  `mutation ProbeDocumentCreate($input: DocumentCreateInput!) { documentCreate(input: $input) { success } }`,
+				Vars:   map[string]any{"input": map[string]any{"projectId": nilID, "title": "relicta permission probe"}},
+			})
+		} else {
+			probes = append(probes, permissionProbe{
+				Action: "post project updates",
+				Option: "digest",
+				Query:  `mutation ProbeProjectUpdateCreate($input: ProjectUpdateCreateInput!) { projectUpdateCreate(input: $input) { success } }`,
+				Vars:   map[string]any{"input": map[string]any{"projectId": nilID, "body": "relicta permission probe"}},
+			})
+		}
+	}
+
+	return probes
+}
+
+// missingPermission describes an action the token is not allowed to perform.
+type missingPermission struct {
+	Action string
+	Option string
+	Reason string
+}
+
+// checkPermissions runs the probes and returns the actions that were denied.
+// Probes failing for any other reason (e.g. the expected "not found") count
+// as allowed.
+func checkPermissions(ctx context.Context, client *LinearClient, probes []permissionProbe) []missingPermission {
+	var missing []missingPermission
+	for _, probe := range probes {
+		_, err := client.execute(ctx, probe.Query, probe.Vars)
+		if err == nil || !isPermissionError(err) {
+			continue
+		}
+		missing = append(missing, missingPermission{
+			Action: probe.Action,
+			Option: probe.Option,
+			Reason: err.Error(),
+		})
+	}
+	return missing
+}
+
+// isPermissionError reports whether err signals a missing permission or
+// scope rather than a failed lookup.
+func isPermissionError(err error) bool {
+	if classifyIssueError(err) == issueAccessDenied {
+		return true
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, e := range apiErr.Errors {
+		msg := strings.ToLower(e.Message + " " + e.Extensions.UserPresentableMessage)
+		if strings.Contains(msg, "scope") || strings.Contains(msg, "permission") || strings.Contains(msg, "insufficient") {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the missing permission for validation output.
+func (m missingPermission) String() string {
+	return fmt.Sprintf("API key cannot %s, required by %s: %s", m.Action, m.Option, m.Reason)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"ProbeIssueCreate": func(map[string]any) any {
+			return fakeErrors{{"message": "Entity not found: Team"}}
+		},
+		"ProbeIssueUpdate": func(map[string]any) any {
+			return fakeErrors{{"message": "Entity not found: Issue"}}
+		},
+		"ProbeCommentCreate": func(map[string]any) any {
+			return fakeErrors{{"message": "Invalid scope: write access required", "extensions": map[string]any{"code": "FORBIDDEN"}}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{})
+
+	missing := checkPermissions(context.Background(), fake.client(), requiredProbes(cfg))
+	if len(missing) != 1 {
+		t.Fatalf("expected 1 missing permission, got %d: %v", len(missing), missing)
+	}
+	if missing[0].Action != "create comments" || missing[0].Option != "add_release_comment" {
+		t.Errorf("unexpected missing permission: %+v", missing[0])
+	}
+}
+
+func TestRequiredProbesFollowConfig(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"create_release_issue": false,
+		"update_linked_issues": false,
+		"add_release_comment":  false,
+		"add_release_links":    true,
+	})
+
+	probes := requiredProbes(cfg)
+	if len(probes) != 1 || probes[0].Option != "add_release_links" {
+		t.Errorf("expected only the attachment probe, got %+v", probes)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// isPrereleaseRelease reports whether the release is a prerelease: its
+// version carries a prerelease tag such as 1.2.0-rc.1, or the host reports
+// the release type "prerelease".
+func isPrereleaseRelease(releaseCtx plugin.ReleaseContext) bool {
+	return isPrerelease(releaseCtx.Version) || strings.EqualFold(releaseCtx.ReleaseType, "prerelease")
+}
+
+// applyPrereleaseState moves the issues of a prerelease to
+// prerelease_state instead of the released states, so they only reach
+// released_state when the stable version ships. It replaces the released
+// state of every team and drops the state map. It reports whether it
+// applied.
+func applyPrereleaseState(cfg *Config, releaseCtx plugin.ReleaseContext) bool {
+	if cfg.PrereleaseState == "" || !isPrereleaseRelease(releaseCtx) {
+		return false
+	}
+
+	cfg.ReleasedState = cfg.PrereleaseState
+	for i := range cfg.Teams {
+		cfg.Teams[i].ReleasedState = cfg.PrereleaseState
+	}
+	cfg.StateMap = nil
+	return true
+}
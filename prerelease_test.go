@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestIsPrereleaseRelease(t *testing.T) {
+	tests := []struct {
+		release plugin.ReleaseContext
+		want    bool
+	}{
+		{plugin.ReleaseContext{Version: "1.4.0-rc.1"}, true},
+		{plugin.ReleaseContext{Version: "v2.0.0-beta"}, true},
+		{plugin.ReleaseContext{Version: "1.4.0", ReleaseType: "Prerelease"}, true},
+		{plugin.ReleaseContext{Version: "1.4.0", ReleaseType: "minor"}, false},
+		{plugin.ReleaseContext{Version: "1.4.0+build.5"}, false},
+	}
+	for _, tt := range tests {
+		if got := isPrereleaseRelease(tt.release); got != tt.want {
+			t.Errorf("isPrereleaseRelease(%+v) = %v, want %v", tt.release, got, tt.want)
+		}
+	}
+}
+
+func TestApplyPrereleaseState(t *testing.T) {
+	p := &LinearPlugin{}
+	raw := map[string]any{
+		"released_state":   "Done",
+		"prerelease_state": "Released (beta)",
+		"state_map":        map[string]any{"features": "Released"},
+		"teams": []any{
+			map[string]any{"key": "ENG"},
+			map[string]any{"key": "OPS", "released_state": "Deployed"},
+		},
+	}
+
+	cfg := p.parseConfig(raw)
+	if applyPrereleaseState(cfg, plugin.ReleaseContext{Version: "1.4.0"}) {
+		t.Fatal("expected a stable release to keep the released states")
+	}
+	if cfg.ReleasedState != "Done" || cfg.Teams[1].ReleasedState != "Deployed" || len(cfg.StateMap) != 1 {
+		t.Error("expected the stable configuration to be unchanged")
+	}
+
+	cfg = p.parseConfig(raw)
+	if !applyPrereleaseState(cfg, plugin.ReleaseContext{Version: "1.4.0-rc.1"}) {
+		t.Fatal("expected a release candidate to use the prerelease state")
+	}
+	if cfg.ReleasedState != "Released (beta)" || cfg.StateMap != nil {
+		t.Errorf("ReleasedState = %q, StateMap = %v", cfg.ReleasedState, cfg.StateMap)
+	}
+	for _, team := range cfg.Teams {
+		if team.ReleasedState != "Released (beta)" {
+			t.Errorf("team %s moves issues to %q", team.Key, team.ReleasedState)
+		}
+	}
+
+	// Without prerelease_state, prereleases move issues to released_state
+	cfg = p.parseConfig(map[string]any{"released_state": "Done"})
+	if applyPrereleaseState(cfg, plugin.ReleaseContext{Version: "1.4.0-rc.1"}) || cfg.ReleasedState != "Done" {
+		t.Errorf("expected no prerelease state, got %q", cfg.ReleasedState)
+	}
+}
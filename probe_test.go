@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestProbeConnectivity(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{"id": "user-1"}}
+		},
+	})
+
+	if _, err := probeConnectivity(context.Background(), fake.client().ReadOnly()); err != nil {
+		t.Errorf("probeConnectivity() error = %v", err)
+	}
+}
+
+func TestProbeConnectivityInvalidToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_bad", httpClient: http.DefaultClient}
+	if _, err := probeConnectivity(context.Background(), client.ReadOnly()); !errors.Is(err, errInvalidToken) {
+		t.Errorf("expected invalid token error, got %v", err)
+	}
+}
+
+func TestProbeConnectivityAuthenticationError(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return fakeErrors{{"message": "Authentication required", "extensions": map[string]any{"code": "AUTHENTICATION_ERROR"}}}
+		},
+	})
+
+	if _, err := probeConnectivity(context.Background(), fake.client().ReadOnly()); !errors.Is(err, errInvalidToken) {
+		t.Errorf("expected invalid token error, got %v", err)
+	}
+}
+
+func TestProbeConnectivityUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient}
+	if _, err := probeConnectivity(context.Background(), client.ReadOnly()); !errors.Is(err, errLinearUnreachable) {
+		t.Errorf("expected unreachable error, got %v", err)
+	}
+}
+
+func TestDiagnoseConnectivity(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+	_, tlsErr := http.Get(tlsServer.URL)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unauthorized", &StatusError{StatusCode: http.StatusUnauthorized}, "invalid_token"},
+		{"proxy status", &StatusError{StatusCode: http.StatusProxyAuthRequired}, "proxy_auth_required"},
+		{"proxy connect", &url.Error{Op: "Post", URL: linearAPIEndpoint, Err: errors.New("Proxy Authentication Required")}, "proxy_auth_required"},
+		{"dns", &url.Error{Op: "Post", URL: linearAPIEndpoint, Err: &net.DNSError{Err: "no such host", Name: "api.linear.app", IsNotFound: true}}, "dns_failure"},
+		{"tls", tlsErr, "tls_interception"},
+		{"other", errors.New("connection reset"), "unreachable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := diagnoseConnectivity(tt.err)
+			if problem.Code != tt.want {
+				t.Errorf("diagnoseConnectivity(%v) = %q, want %q", tt.err, problem.Code, tt.want)
+			}
+			if tt.want != "unreachable" && !strings.Contains(problem.format(tt.err), "Hint:") {
+				t.Errorf("expected a remediation hint, got %q", problem.format(tt.err))
+			}
+		})
+	}
+}
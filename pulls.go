@@ -0,0 +1,86 @@
+package main
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// pullRequestsEnvVar lists the pull request of each commit as "sha=url"
+// pairs separated by commas. It is provided by the host in the release
+// environment; abbreviated hashes are accepted.
+const pullRequestsEnvVar = "LINEAR_PULL_REQUESTS"
+
+// pullRequestLinksSubtitle groups pull request attachments in the issue
+// sidebar.
+const pullRequestLinksSubtitle = "Pull requests"
+
+// pullRequestURLs parses the commit to pull request mapping from the release
+// environment.
+func pullRequestURLs(releaseCtx plugin.ReleaseContext) map[string]string {
+	urls := make(map[string]string)
+	for _, part := range strings.Split(releaseCtx.Environment[pullRequestsEnvVar], ",") {
+		sha, url, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || sha == "" || url == "" {
+			continue
+		}
+		urls[strings.ToLower(sha)] = url
+	}
+	return urls
+}
+
+// pullRequestsByIssue maps each issue referenced by a commit to the pull
+// requests of the commits referencing it.
+func pullRequestsByIssue(releaseCtx plugin.ReleaseContext, pattern *regexp.Regexp, prefix string) map[string][]string {
+	urls := pullRequestURLs(releaseCtx)
+	if len(urls) == 0 || releaseCtx.Changes == nil {
+		return nil
+	}
+
+	byIssue := make(map[string][]string)
+	changes := releaseCtx.Changes
+	for _, group := range [][]plugin.ConventionalCommit{changes.Features, changes.Fixes, changes.Breaking, changes.Other} {
+		for _, c := range group {
+			url := commitPullRequest(urls, c.Hash)
+			if url == "" {
+				continue
+			}
+			for _, id := range extractIssuesMatching(pattern, []string{c.Description}, prefix) {
+				if !slices.Contains(byIssue[id], url) {
+					byIssue[id] = append(byIssue[id], url)
+				}
+			}
+		}
+	}
+	return byIssue
+}
+
+// commitPullRequest finds the pull request of a commit, matching full or
+// abbreviated hashes in either direction.
+func commitPullRequest(urls map[string]string, hash string) string {
+	hash = strings.ToLower(hash)
+	if hash == "" {
+		return ""
+	}
+	if url, ok := urls[hash]; ok {
+		return url
+	}
+	for sha, url := range urls {
+		if strings.HasPrefix(hash, sha) || strings.HasPrefix(sha, hash) {
+			return url
+		}
+	}
+	return ""
+}
+
+// pullRequestTitle names a pull request link after the last path segment of
+// its URL, e.g. "Pull request #42".
+func pullRequestTitle(url string) string {
+	if n := path.Base(strings.TrimSuffix(url, "/")); n != "" && n != "." && n != "/" {
+		return "Pull request #" + n
+	}
+	return "Pull request"
+}
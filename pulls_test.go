@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPullRequestsByIssue(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{
+		Environment: map[string]string{
+			pullRequestsEnvVar: "abc1234=https://github.com/acme/web/pull/12, def5678901=https://github.com/acme/web/pull/13",
+		},
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Hash: "abc1234ffff", Description: "add export ENG-1"}},
+			Fixes: []plugin.ConventionalCommit{
+				{Hash: "def5678", Description: "fix export ENG-1 ENG-2"},
+				{Hash: "0000000", Description: "unrelated ENG-3"},
+			},
+		},
+	}
+
+	got := pullRequestsByIssue(releaseCtx, issuePattern, "ENG")
+	want := map[string][]string{
+		"ENG-1": {"https://github.com/acme/web/pull/12", "https://github.com/acme/web/pull/13"},
+		"ENG-2": {"https://github.com/acme/web/pull/13"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pullRequestsByIssue() = %v, want %v", got, want)
+	}
+
+	if pullRequestTitle("https://github.com/acme/web/pull/12") != "Pull request #12" {
+		t.Errorf("unexpected title %q", pullRequestTitle("https://github.com/acme/web/pull/12"))
+	}
+}
+
+func TestProcessLinkedIssuesAttachesMissingPullRequests(t *testing.T) {
+	var mu sync.Mutex
+	var attached []string
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {
+				"id": "uuid-1", "identifier": "ENG-1",
+				"state": map[string]any{"id": "state-review", "name": "In Review"},
+				// Already linked by the GitHub integration
+				"attachments": map[string]any{"nodes": []any{map[string]any{"url": "https://github.com/acme/web/pull/12"}}},
+			},
+		}),
+		"CreateAttachment": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			attached = append(attached, vars["input"].(map[string]any)["url"].(string))
+			return map[string]any{"attachmentCreate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues": false,
+		"add_release_comment":  false,
+		"link_pull_requests":   true,
+		"issue_prefix":         "ENG",
+	})
+	releaseCtx := plugin.ReleaseContext{
+		Version: "1.0.0",
+		Environment: map[string]string{
+			pullRequestsEnvVar: "aaa1111=https://github.com/acme/web/pull/12,bbb2222=https://github.com/acme/web/pull/14",
+		},
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{
+				{Hash: "aaa1111", Description: "add export ENG-1"},
+				{Hash: "bbb2222", Description: "polish export ENG-1"},
+			},
+		},
+	}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, releaseCtx, &Team{}), []string{"ENG-1"})
+
+	if !reflect.DeepEqual(attached, []string{"https://github.com/acme/web/pull/14"}) {
+		t.Errorf("attached = %v, want only the pull request not linked yet", attached)
+	}
+	if got := res.PullRequests["ENG-1"]; len(got) != 1 {
+		t.Errorf("PullRequests = %v", res.PullRequests)
+	}
+}
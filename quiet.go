@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// QuietConfig reduces the plugin to lightweight annotations for repositories
+// that release many times a day. Only releases matching FullReleases get
+// the full treatment.
+type QuietConfig struct {
+	Enabled bool `json:"enabled"`
+	// FullReleases are version patterns (e.g. "*.*.0") of milestone
+	// releases that still get release issues, comments and state changes.
+	FullReleases []string `json:"full_releases,omitempty"`
+}
+
+// parseQuietConfig parses the quiet block.
+func parseQuietConfig(raw map[string]any) QuietConfig {
+	parser := helpers.NewConfigParser(raw)
+	return QuietConfig{
+		Enabled:      parser.GetBool("enabled", false),
+		FullReleases: parser.GetStringSlice("full_releases", nil),
+	}
+}
+
+// appliesTo reports whether the release gets the quiet treatment.
+func (q QuietConfig) appliesTo(releaseCtx plugin.ReleaseContext) bool {
+	if !q.Enabled {
+		return false
+	}
+	for _, pattern := range q.FullReleases {
+		if matchVersionPattern(pattern, releaseCtx.Version) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyQuiet limits a quiet release to attaching release links to linked
+// issues: no release issue, comments, state changes or digest. It reports
+// whether the release is quiet.
+func applyQuiet(cfg *Config, releaseCtx plugin.ReleaseContext) bool {
+	if !cfg.Quiet.appliesTo(releaseCtx) {
+		return false
+	}
+
+	cfg.CreateReleaseIssue = false
+	cfg.UpdateLinkedIssues = false
+	cfg.AddReleaseComment = false
+	cfg.Digest.Enabled = false
+	cfg.AddReleaseLinks = true
+	return true
+}
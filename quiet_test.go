@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMatchVersionPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		version string
+		want    bool
+	}{
+		{"*.*.0", "1.4.0", true},
+		{"*.*.0", "v1.4.0", true},
+		{"*.*.0", "1.4.2", false},
+		{"*.*.0", "1.4.0-rc.1", false},
+		{"*.*.*-rc.*", "1.4.0-rc.1", true},
+		{"2.x", "2.1.3", true},
+		{"2.x", "3.0.0", false},
+		{"1.2.3", "1.2.3+build.5", true},
+		{"1.2.3", "1.2", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchVersionPattern(tt.pattern, tt.version); got != tt.want {
+			t.Errorf("matchVersionPattern(%q, %q) = %v, want %v", tt.pattern, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidVersionPattern(t *testing.T) {
+	for _, pattern := range []string{"*.*.0", "v2.x", "*.*.*-rc.*"} {
+		if !isValidVersionPattern(pattern) {
+			t.Errorf("expected %q to be valid", pattern)
+		}
+	}
+	for _, pattern := range []string{"1.a.0", "*.*.0-", "1.*-[", ""} {
+		if isValidVersionPattern(pattern) {
+			t.Errorf("expected %q to be invalid", pattern)
+		}
+	}
+}
+
+func TestApplyQuiet(t *testing.T) {
+	p := &LinearPlugin{}
+	raw := map[string]any{
+		"create_release_issue": true,
+		"quiet": map[string]any{
+			"enabled":       true,
+			"full_releases": []any{"*.*.0"},
+		},
+	}
+
+	cfg := p.parseConfig(raw)
+	if applyQuiet(cfg, plugin.ReleaseContext{Version: "1.4.0"}) {
+		t.Fatal("expected milestone release to get the full treatment")
+	}
+	if !cfg.CreateReleaseIssue || !cfg.UpdateLinkedIssues || !cfg.AddReleaseComment {
+		t.Error("expected full release configuration to be unchanged")
+	}
+
+	cfg = p.parseConfig(raw)
+	if !applyQuiet(cfg, plugin.ReleaseContext{Version: "1.4.7"}) {
+		t.Fatal("expected patch release to be quiet")
+	}
+	if cfg.CreateReleaseIssue || cfg.UpdateLinkedIssues || cfg.AddReleaseComment || cfg.Digest.Enabled {
+		t.Error("expected quiet release to skip release issue, comments, state changes and digest")
+	}
+	if !cfg.AddReleaseLinks {
+		t.Error("expected quiet release to attach release links")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitReserve is the share of a rate limit window below which
+	// requests are spread evenly over the time left until the reset, so a
+	// large release does not exhaust the workspace's hourly budget.
+	rateLimitReserve = 0.1
+
+	// maxThrottleDelay bounds the pause before a request while budget
+	// remains. An exhausted window waits for its reset instead.
+	maxThrottleDelay = 10 * time.Second
+)
+
+// rateLimitWindow is one of Linear's rate limits as last reported.
+type rateLimitWindow struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// known reports whether Linear reported the window.
+func (w rateLimitWindow) known() bool {
+	return w.Limit > 0
+}
+
+// low reports whether less than the reserve of the window remains.
+func (w rateLimitWindow) low() bool {
+	return w.known() && float64(w.Remaining) < float64(w.Limit)*rateLimitReserve
+}
+
+// delay returns the pause that spreads the remaining budget of the window,
+// at cost per request, over the time until its reset.
+func (w rateLimitWindow) delay(cost int, now time.Time) time.Duration {
+	left := w.Reset.Sub(now)
+	if !w.low() || left <= 0 {
+		return 0
+	}
+	if w.Remaining <= 0 {
+		return left
+	}
+	return min(left*time.Duration(max(cost, 1))/time.Duration(w.Remaining), maxThrottleDelay)
+}
+
+// String formats the window for messages.
+func (w rateLimitWindow) String() string {
+	return fmt.Sprintf("%d of %d left until %s", w.Remaining, w.Limit, w.Reset.UTC().Format(time.RFC3339))
+}
+
+// rateLimitBudget is the remaining request and complexity budget of an API
+// key, as reported by the X-RateLimit headers of Linear's responses.
+type rateLimitBudget struct {
+	Requests   rateLimitWindow `json:"requests"`
+	Complexity rateLimitWindow `json:"complexity"`
+	// LastComplexity is the complexity of the last request, the cost
+	// assumed for the next one.
+	LastComplexity int `json:"last_complexity,omitempty"`
+}
+
+// rateLimiter tracks the budget of one client and throttles its requests
+// before Linear starts rejecting them.
+type rateLimiter struct {
+	mu     sync.Mutex
+	budget rateLimitBudget
+}
+
+// observe records the rate limit headers of a response.
+func (l *rateLimiter) observe(h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	readWindow(h, "Requests", &l.budget.Requests)
+	readWindow(h, "Complexity", &l.budget.Complexity)
+	if n, err := strconv.Atoi(h.Get("X-Complexity")); err == nil {
+		l.budget.LastComplexity = n
+	}
+}
+
+// readWindow updates w from the X-RateLimit-<kind>-* headers present in h.
+// Resets are sent as UTC epoch milliseconds.
+func readWindow(h http.Header, kind string, w *rateLimitWindow) {
+	if n, err := strconv.Atoi(h.Get("X-RateLimit-" + kind + "-Limit")); err == nil {
+		w.Limit = n
+	}
+	if n, err := strconv.Atoi(h.Get("X-RateLimit-" + kind + "-Remaining")); err == nil {
+		w.Remaining = n
+	}
+	if ms, err := strconv.ParseInt(h.Get("X-RateLimit-"+kind+"-Reset"), 10, 64); err == nil {
+		w.Reset = time.UnixMilli(ms)
+	}
+}
+
+// Budget returns the last reported budget and whether any was reported.
+func (l *rateLimiter) Budget() (rateLimitBudget, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.budget, l.budget.Requests.known() || l.budget.Complexity.known()
+}
+
+// delay returns the pause before the next request: the longer of the
+// requests and complexity windows' pauses.
+func (l *rateLimiter) delay(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return max(l.budget.Requests.delay(1, now), l.budget.Complexity.delay(l.budget.LastComplexity, now))
+}
+
+// wait pauses before a request while the budget is low. It returns how
+// long it waited.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	d := l.delay(time.Now())
+	if d <= 0 {
+		return 0, nil
+	}
+	select {
+	case <-ctx.Done():
+		return 0, fmt.Errorf("failed to wait for the Linear rate limit: %w", ctx.Err())
+	case <-time.After(d):
+		return d, nil
+	}
+}
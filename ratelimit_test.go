@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitWindowDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name   string
+		window rateLimitWindow
+		cost   int
+		want   time.Duration
+	}{
+		{"unknown", rateLimitWindow{}, 1, 0},
+		{"plenty left", rateLimitWindow{Limit: 1500, Remaining: 1000, Reset: reset}, 1, 0},
+		{"low", rateLimitWindow{Limit: 1500, Remaining: 100, Reset: reset}, 1, 6 * time.Second},
+		{"low and costly", rateLimitWindow{Limit: 1500, Remaining: 100, Reset: reset}, 5, maxThrottleDelay},
+		{"exhausted", rateLimitWindow{Limit: 1500, Remaining: 0, Reset: reset}, 1, 10 * time.Minute},
+		{"reset passed", rateLimitWindow{Limit: 1500, Remaining: 0, Reset: now.Add(-time.Second)}, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.delay(tt.cost, now); got != tt.want {
+				t.Errorf("delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	h := http.Header{}
+	h.Set("X-RateLimit-Requests-Limit", "1500")
+	h.Set("X-RateLimit-Requests-Remaining", "1499")
+	h.Set("X-RateLimit-Requests-Reset", strconv.FormatInt(reset.UnixMilli(), 10))
+	h.Set("X-RateLimit-Complexity-Limit", "250000")
+	h.Set("X-RateLimit-Complexity-Remaining", "249990")
+	h.Set("X-Complexity", "10")
+
+	var l rateLimiter
+	if _, ok := l.Budget(); ok {
+		t.Fatal("expected no budget before any response")
+	}
+	l.observe(h)
+
+	budget, ok := l.Budget()
+	if !ok {
+		t.Fatal("expected a budget after observing headers")
+	}
+	if budget.Requests.Remaining != 1499 || !budget.Requests.Reset.Equal(reset) {
+		t.Errorf("unexpected requests window %+v", budget.Requests)
+	}
+	if budget.Complexity.Limit != 250000 || budget.LastComplexity != 10 {
+		t.Errorf("unexpected complexity budget %+v", budget)
+	}
+}
+
+func TestExecuteThrottlesLowBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Requests-Limit", "1500")
+		w.Header().Set("X-RateLimit-Requests-Remaining", "10")
+		w.Header().Set("X-RateLimit-Requests-Reset", strconv.FormatInt(time.Now().Add(2*time.Second).UnixMilli(), 10))
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient}
+
+	// Query directly, since the client caches the viewer
+	query := `query GetViewer { viewer { id name } }`
+	if _, err := client.execute(context.Background(), query, nil); err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+	start := time.Now()
+	if _, err := client.execute(context.Background(), query, nil); err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+	if waited := time.Since(start); waited < 100*time.Millisecond {
+		t.Errorf("expected the second request to be throttled, it took %v", waited)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errReadOnly is returned for mutations attempted through a read-only
+// context, such as during a dry run.
+var errReadOnly = errors.New("mutation blocked: client is read-only")
+
+// readOnlyGuard rejects every mutation of a run and records the attempts,
+// which would indicate a dry-run code path that is not side-effect free.
+type readOnlyGuard struct {
+	mu      sync.Mutex
+	blocked []string
+}
+
+type readOnlyKey struct{}
+
+// withReadOnly returns a context in which the client refuses all mutations.
+// Queries go through the shared client, so they are subject to the same
+// retries and rate handling as a real run.
+func withReadOnly(ctx context.Context) (context.Context, *readOnlyGuard) {
+	g := &readOnlyGuard{}
+	return context.WithValue(ctx, readOnlyKey{}, g), g
+}
+
+// checkReadOnly returns errReadOnly if ctx is read-only and the query is a
+// mutation, named or anonymous.
+func checkReadOnly(ctx context.Context, query string, variables map[string]any) error {
+	g, _ := ctx.Value(readOnlyKey{}).(*readOnlyGuard)
+	if g == nil || !strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		return nil
+	}
+
+	desc := describeMutation(query, variables)
+	if desc == "" {
+		desc = "anonymous mutation"
+	}
+	g.mu.Lock()
+	g.blocked = append(g.blocked, desc)
+	g.mu.Unlock()
+	return fmt.Errorf("%s: %w", desc, errReadOnly)
+}
+
+// Blocked returns the mutations that were refused.
+func (g *readOnlyGuard) Blocked() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.blocked...)
+}
+
+// readOnlyContext returns ctx with a read-only guard, reusing one already
+// attached.
+func readOnlyContext(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(readOnlyKey{}).(*readOnlyGuard); ok {
+		return ctx
+	}
+	ctx, _ = withReadOnly(ctx)
+	return ctx
+}
+
+// ReadOnlyLinearClient exposes only the query methods of a LinearClient.
+// Code paths that must not write, such as dry runs and checks, take this
+// type so a mutation does not compile; every call additionally runs under
+// a read-only guard so the client refuses mutations at runtime.
+type ReadOnlyLinearClient struct {
+	client *LinearClient
+}
+
+// ReadOnly returns a read-only view of the client.
+func (c *LinearClient) ReadOnly() *ReadOnlyLinearClient {
+	return &ReadOnlyLinearClient{client: c}
+}
+
+// GetViewer returns the authenticated user.
+func (r *ReadOnlyLinearClient) GetViewer(ctx context.Context) (*Viewer, error) {
+	return r.client.GetViewer(readOnlyContext(ctx))
+}
+
+// GetTeam returns a team by ID or key.
+func (r *ReadOnlyLinearClient) GetTeam(ctx context.Context, teamID, teamKey string) (*Team, error) {
+	return r.client.GetTeam(readOnlyContext(ctx), teamID, teamKey)
+}
+
+// GetProject returns a project by ID.
+func (r *ReadOnlyLinearClient) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	return r.client.GetProject(readOnlyContext(ctx), projectID)
+}
+
+// GetTeamIssueTemplate returns the team's default issue template.
+func (r *ReadOnlyLinearClient) GetTeamIssueTemplate(ctx context.Context, teamID string) (*IssueTemplate, error) {
+	return r.client.GetTeamIssueTemplate(readOnlyContext(ctx), teamID)
+}
+
+// GetIssueByIdentifier returns an issue by its identifier.
+func (r *ReadOnlyLinearClient) GetIssueByIdentifier(ctx context.Context, identifier string) (*Issue, error) {
+	return r.client.GetIssueByIdentifier(readOnlyContext(ctx), identifier)
+}
+
+// GetIssuesByIdentifiers looks up many issues by identifier in batches.
+func (r *ReadOnlyLinearClient) GetIssuesByIdentifiers(ctx context.Context, identifiers []string) (map[string]*Issue, error) {
+	return r.client.GetIssuesByIdentifiers(readOnlyContext(ctx), identifiers)
+}
+
+// GetIssueComments returns the most recent comments on an issue.
+func (r *ReadOnlyLinearClient) GetIssueComments(ctx context.Context, issueID string) ([]Comment, error) {
+	return r.client.GetIssueComments(readOnlyContext(ctx), issueID)
+}
+
+// FindIssuesByDescription returns a team's issues whose description
+// contains the given text.
+func (r *ReadOnlyLinearClient) FindIssuesByDescription(ctx context.Context, teamID, text string) ([]Issue, error) {
+	return r.client.FindIssuesByDescription(readOnlyContext(ctx), teamID, text)
+}
+
+// SearchIssuesByDescription returns issues in any team whose description
+// contains the given text.
+func (r *ReadOnlyLinearClient) SearchIssuesByDescription(ctx context.Context, text string) ([]Issue, error) {
+	return r.client.SearchIssuesByDescription(readOnlyContext(ctx), text)
+}
+
+// ListIssuesCreatedSince returns a team's issues containing text that were
+// created after since.
+func (r *ReadOnlyLinearClient) ListIssuesCreatedSince(ctx context.Context, teamID, text string, since time.Time) ([]Issue, error) {
+	return r.client.ListIssuesCreatedSince(readOnlyContext(ctx), teamID, text, since)
+}
+
+// GetProjectUpdates returns the recent updates of a project.
+func (r *ReadOnlyLinearClient) GetProjectUpdates(ctx context.Context, projectID string) ([]ProjectUpdate, error) {
+	return r.client.GetProjectUpdates(readOnlyContext(ctx), projectID)
+}
+
+// GetProjectDocuments returns the documents of a project.
+func (r *ReadOnlyLinearClient) GetProjectDocuments(ctx context.Context, projectID string) ([]Document, error) {
+	return r.client.GetProjectDocuments(readOnlyContext(ctx), projectID)
+}
+
+// GetDocument returns a document by ID.
+func (r *ReadOnlyLinearClient) GetDocument(ctx context.Context, id string) (*Document, error) {
+	return r.client.GetDocument(readOnlyContext(ctx), id)
+}
+
+// GetCustomViewIssues returns the issues matching a custom view's filters.
+func (r *ReadOnlyLinearClient) GetCustomViewIssues(ctx context.Context, viewID string) ([]Issue, error) {
+	return r.client.GetCustomViewIssues(readOnlyContext(ctx), viewID)
+}
+
+// GetProjectMilestones returns the milestones of a project.
+func (r *ReadOnlyLinearClient) GetProjectMilestones(ctx context.Context, projectID string) ([]ProjectMilestone, error) {
+	return r.client.GetProjectMilestones(readOnlyContext(ctx), projectID)
+}
+
+// GetProjectIssueStates returns the state types of all of a project's
+// issues.
+func (r *ReadOnlyLinearClient) GetProjectIssueStates(ctx context.Context, projectID string) ([]string, error) {
+	return r.client.GetProjectIssueStates(readOnlyContext(ctx), projectID)
+}
+
+// FindIssueLabel returns the label with the given name available to a team.
+func (r *ReadOnlyLinearClient) FindIssueLabel(ctx context.Context, teamID, name string) (*Label, error) {
+	return r.client.FindIssueLabel(readOnlyContext(ctx), teamID, name)
+}
+
+// GetActiveCycle returns the team's active cycle, or nil if it has none.
+func (r *ReadOnlyLinearClient) GetActiveCycle(ctx context.Context, teamID string) (*Cycle, error) {
+	return r.client.GetActiveCycle(readOnlyContext(ctx), teamID)
+}
+
+// ListTeamKeys returns the keys of the workspace's teams.
+func (r *ReadOnlyLinearClient) ListTeamKeys(ctx context.Context) ([]string, error) {
+	return r.client.ListTeamKeys(readOnlyContext(ctx))
+}
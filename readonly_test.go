@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestReadOnlyBlocksMutations(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+	client := fake.client()
+	ctx, guard := withReadOnly(context.Background())
+
+	if _, err := client.GetIssueByIdentifier(ctx, "ENG-1"); err != nil {
+		t.Fatalf("expected queries to pass, got %v", err)
+	}
+	if err := client.AddComment(ctx, "uuid-1", "Released"); !errors.Is(err, errReadOnly) {
+		t.Fatalf("expected errReadOnly, got %v", err)
+	}
+	if _, err := client.execute(ctx, "mutation { issueArchive(id: \"uuid-1\") { success } }", nil); !errors.Is(err, errReadOnly) {
+		t.Fatalf("expected anonymous mutations to be blocked, got %v", err)
+	}
+
+	if fake.callCount("AddComment") != 0 {
+		t.Error("expected the mutation never to reach Linear")
+	}
+	if blocked := guard.Blocked(); len(blocked) != 2 || blocked[0] != "AddComment(uuid-1)" {
+		t.Errorf("Blocked() = %v", blocked)
+	}
+}
+
+func TestReadOnlyLinearClient(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+		}),
+	})
+	ro := fake.client().ReadOnly()
+
+	issue, err := ro.GetIssueByIdentifier(context.Background(), "ENG-1")
+	if err != nil || issue.ID != "uuid-1" {
+		t.Fatalf("GetIssueByIdentifier() = %v, %v", issue, err)
+	}
+
+	// Calls run under a read-only guard, reusing one the caller attached
+	ctx, guard := withReadOnly(context.Background())
+	if got := readOnlyContext(ctx); got != ctx {
+		t.Error("expected the existing read-only context to be reused")
+	}
+	if err := checkReadOnly(readOnlyContext(context.Background()), "mutation AddComment { x }", nil); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected mutations to be refused, got %v", err)
+	}
+	if len(guard.Blocked()) != 0 {
+		t.Errorf("unexpected blocked mutations %v", guard.Blocked())
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import "sync"
+
+// clientKey identifies a shared client by API endpoint, credentials,
+// request signing and network settings. For API keys, apiKey is the key
+// itself; otherwise the provider's ID.
+type clientKey struct {
+	endpoint string
+	apiKey   string
+	signing  string
+	network  string
+}
+
+// clientRegistry hands out one LinearClient per endpoint and API key, so
+// hooks and parallel pipelines using the same credentials share connection
+// pools and any per-client state such as rate limiting, circuit breaking
+// and caches.
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients map[clientKey]*LinearClient
+}
+
+// defaultRegistry is the process-wide client registry.
+var defaultRegistry = newClientRegistry()
+
+// newClientRegistry creates an empty registry.
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: make(map[clientKey]*LinearClient)}
+}
+
+// Get returns the shared client for the API key, creating it on first use.
+func (r *clientRegistry) Get(apiKey string) *LinearClient {
+	return r.For(staticCredentials(apiKey))
+}
+
+// For returns the shared client for the credentials, creating it on first
+// use.
+func (r *clientRegistry) For(credentials CredentialProvider) *LinearClient {
+	return r.configured(credentials, nil, NetworkConfig{})
+}
+
+// configured returns the shared client for the credentials signing
+// requests with signer and connecting with the network settings, creating
+// it on first use.
+func (r *clientRegistry) configured(credentials CredentialProvider, signer *requestSigner, network NetworkConfig) *LinearClient {
+	key := clientKey{endpoint: linearAPIEndpoint, apiKey: credentials.ID(), signing: signer.ID(), network: network.ID()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[key]; ok {
+		return client
+	}
+	client := newCredentialedClient(credentials)
+	client.signer = signer
+	if network != (NetworkConfig{}) {
+		if transport, err := network.transport(); err != nil {
+			client.networkErr = err
+		} else {
+			client.httpClient.Transport = transport
+		}
+	}
+	r.clients[key] = client
+	return client
+}
+
+// Reset drops all registered clients.
+func (r *clientRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = make(map[clientKey]*LinearClient)
+}
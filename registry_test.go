@@ -0,0 +1,36 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestClientRegistryShared(t *testing.T) {
+	r := newClientRegistry()
+
+	var wg sync.WaitGroup
+	clients := make([]*LinearClient, 16)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = r.Get("lin_api_shared")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range clients {
+		if c != clients[0] {
+			t.Fatal("expected all callers to share one client")
+		}
+	}
+
+	if r.Get("lin_api_other") == clients[0] {
+		t.Error("expected a distinct client for a different API key")
+	}
+
+	r.Reset()
+	if r.Get("lin_api_shared") == clients[0] {
+		t.Error("expected a new client after Reset")
+	}
+}
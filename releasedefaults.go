@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sources of the release issue priority.
+const (
+	prioritySourceConfig   = "config"
+	prioritySourceTemplate = "team_template"
+	prioritySourceDefault  = "default"
+)
+
+// releaseIssueDefaults are the issue template and priority a release issue
+// is created with.
+type releaseIssueDefaults struct {
+	TemplateID string `json:"template_id,omitempty"`
+	Template   string `json:"template,omitempty"`
+	Priority   int    `json:"priority"`
+	// PrioritySource tells where the priority came from: config,
+	// team_template or default.
+	PrioritySource string `json:"priority_source"`
+}
+
+// String describes the defaults in messages.
+func (d releaseIssueDefaults) String() string {
+	s := fmt.Sprintf("priority %d", d.Priority)
+	switch d.PrioritySource {
+	case prioritySourceTemplate:
+		s += fmt.Sprintf(" from team template %q", d.Template)
+	case prioritySourceDefault:
+		s += " (default)"
+	}
+	if d.Template != "" && d.PrioritySource != prioritySourceTemplate {
+		s += fmt.Sprintf(", team template %q", d.Template)
+	}
+	return s
+}
+
+// resolveReleaseIssueDefaults returns the defaults of the release issue. A
+// configured release_issue.priority wins; otherwise the team's default
+// issue template is applied, with its priority if it sets one, and low
+// priority if not. On error the returned defaults are still usable.
+func resolveReleaseIssueDefaults(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, teamID string) (releaseIssueDefaults, error) {
+	if p := cfg.ReleaseIssue.Priority; p != nil {
+		return releaseIssueDefaults{Priority: *p, PrioritySource: prioritySourceConfig}, nil
+	}
+
+	defaults := releaseIssueDefaults{Priority: priorityLow, PrioritySource: prioritySourceDefault}
+	template, err := client.GetTeamIssueTemplate(ctx, teamID)
+	if err != nil {
+		return defaults, fmt.Errorf("failed to read the team's default issue template: %w", err)
+	}
+	if template == nil {
+		return defaults, nil
+	}
+	defaults.TemplateID, defaults.Template = template.ID, template.Name
+	if template.Priority != nil {
+		defaults.Priority, defaults.PrioritySource = *template.Priority, prioritySourceTemplate
+	}
+	return defaults, nil
+}
+
+// planReleaseIssueDefaults reports the team defaults a dry run would apply
+// to the release issue.
+func planReleaseIssueDefaults(ctx context.Context, run *releaseRun) {
+	rc := run.results
+	team, err := run.resolveTeam(ctx)
+	if err != nil {
+		rc.warn("release_issue_defaults", "Failed to get team: %v", err)
+		return
+	}
+	defaults, err := resolveReleaseIssueDefaults(ctx, run.client.ReadOnly(), run.cfg, team.ID)
+	if err != nil {
+		rc.warn("release_issue_defaults", "%v; would use %s", err, defaults)
+	} else {
+		rc.success("release_issue_defaults", "Would create the release issue with %s", defaults)
+	}
+	rc.output("release_issue_defaults", defaults)
+}
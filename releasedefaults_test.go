@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// teamTemplateHandler answers GetTeamIssueTemplate with the given default
+// templates for members and non-members.
+func teamTemplateHandler(members, nonMembers any) func(map[string]any) any {
+	return func(map[string]any) any {
+		return map[string]any{"team": map[string]any{
+			"defaultTemplateForMembers":    members,
+			"defaultTemplateForNonMembers": nonMembers,
+		}}
+	}
+}
+
+func TestGetTeamIssueTemplate(t *testing.T) {
+	priority := func(n int) *int { return &n }
+	tests := []struct {
+		name       string
+		members    any
+		nonMembers any
+		want       *IssueTemplate
+	}{
+		{
+			name:    "member template",
+			members: map[string]any{"id": "tpl-1", "name": "Release", "templateData": map[string]any{"priority": 2}},
+			want:    &IssueTemplate{ID: "tpl-1", Name: "Release", Priority: priority(2)},
+		},
+		{
+			name:       "encoded template data of the non-member template",
+			nonMembers: map[string]any{"id": "tpl-2", "name": "Intake", "templateData": `{"priority":3}`},
+			want:       &IssueTemplate{ID: "tpl-2", Name: "Intake", Priority: priority(3)},
+		},
+		{
+			name:    "template without a priority",
+			members: map[string]any{"id": "tpl-3", "name": "Plain", "templateData": map[string]any{"title": "x"}},
+			want:    &IssueTemplate{ID: "tpl-3", Name: "Plain"},
+		},
+		{
+			name: "no templates",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinear(t, map[string]func(map[string]any) any{
+				"GetTeamIssueTemplate": teamTemplateHandler(tt.members, tt.nonMembers),
+			})
+			got, err := fake.client().GetTeamIssueTemplate(context.Background(), "team-1")
+			if err != nil {
+				t.Fatalf("GetTeamIssueTemplate() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("GetTeamIssueTemplate() = %+v, want %+v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if got.ID != tt.want.ID || got.Name != tt.want.Name || (got.Priority == nil) != (tt.want.Priority == nil) ||
+				got.Priority != nil && *got.Priority != *tt.want.Priority {
+				t.Errorf("GetTeamIssueTemplate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateReleaseIssueAppliesTeamTemplate(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeamIssueTemplate": teamTemplateHandler(
+			map[string]any{"id": "tpl-1", "name": "Release", "templateData": map[string]any{"priority": 2}}, nil),
+		"CreateIssue": func(map[string]any) any {
+			return map[string]any{"issueCreate": map[string]any{
+				"success": true,
+				"issue":   map[string]any{"id": "uuid-1", "identifier": "ENG-1"},
+			}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	run := fake.run(p.parseConfig(map[string]any{}), plugin.ReleaseContext{Version: "1.0.0"}, &Team{ID: "team-1"})
+	if _, err := p.createReleaseIssue(context.Background(), run, nil); err != nil {
+		t.Fatalf("createReleaseIssue() error = %v", err)
+	}
+
+	input := fake.calls["CreateIssue"][0]["input"].(map[string]any)
+	if input["templateId"] != "tpl-1" || input["priority"] != float64(2) {
+		t.Errorf("expected the team template and its priority, got %v", input)
+	}
+	defaults := run.results.outputs["release_issue_defaults"].(releaseIssueDefaults)
+	if defaults.PrioritySource != prioritySourceTemplate || defaults.Template != "Release" {
+		t.Errorf("release_issue_defaults = %+v", defaults)
+	}
+}
+
+func TestResolveReleaseIssueDefaults(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeamIssueTemplate": teamTemplateHandler(nil, nil),
+	})
+	p := &LinearPlugin{}
+	client := fake.client().ReadOnly()
+
+	cfg := p.parseConfig(map[string]any{"release_issue": map[string]any{"priority": 1}})
+	defaults, err := resolveReleaseIssueDefaults(context.Background(), client, cfg, "team-1")
+	if err != nil || defaults.Priority != 1 || defaults.PrioritySource != prioritySourceConfig {
+		t.Errorf("configured priority: %+v, %v", defaults, err)
+	}
+	if fake.callCount("GetTeamIssueTemplate") != 0 {
+		t.Error("expected no template lookup with a configured priority")
+	}
+
+	defaults, err = resolveReleaseIssueDefaults(context.Background(), client, p.parseConfig(map[string]any{}), "team-1")
+	if err != nil || defaults.Priority != priorityLow || defaults.PrioritySource != prioritySourceDefault || defaults.TemplateID != "" {
+		t.Errorf("team without templates: %+v, %v", defaults, err)
+	}
+}
+
+func TestDryRunPlansReleaseIssueDefaults(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG"}}
+		},
+		"GetTeamIssueTemplate": teamTemplateHandler(
+			map[string]any{"id": "tpl-1", "name": "Release", "templateData": map[string]any{"priority": 3}}, nil),
+	})
+
+	p := &LinearPlugin{}
+	run := fake.run(p.parseConfig(map[string]any{"team_id": "team-1"}), plugin.ReleaseContext{Version: "1.0.0"}, nil)
+	planReleaseIssueDefaults(context.Background(), run)
+
+	defaults, ok := run.results.outputs["release_issue_defaults"].(releaseIssueDefaults)
+	if !ok || defaults.Priority != 3 || defaults.TemplateID != "tpl-1" {
+		t.Errorf("release_issue_defaults = %+v", run.results.outputs["release_issue_defaults"])
+	}
+	if n := len(run.results.results); n != 1 || run.results.results[0].Status != statusSuccess {
+		t.Errorf("unexpected results %+v", run.results.results)
+	}
+}
+
+func TestReleaseIssuePriorityTriState(t *testing.T) {
+	p := &LinearPlugin{}
+	priority := func(n int) *int { return &n }
+	tests := []struct {
+		name     string
+		priority any
+		want     *int
+	}{
+		{"no priority", 0, priority(priorityNone)},
+		{"null", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := p.parseConfig(map[string]any{"release_issue": map[string]any{"priority": tt.priority}})
+			got := cfg.ReleaseIssue.Priority
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("Priority = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
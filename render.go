@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// sampleReleaseContext is the synthetic release templates are rendered
+// against when no context file is given.
+func sampleReleaseContext() plugin.ReleaseContext {
+	return plugin.ReleaseContext{
+		Version:         "1.5.0",
+		PreviousVersion: "1.4.2",
+		TagName:         "v1.5.0",
+		ReleaseType:     "minor",
+		RepositoryURL:   "https://github.com/acme/app",
+		RepositoryOwner: "acme",
+		RepositoryName:  "app",
+		Branch:          "main",
+		CommitSHA:       "3f2c9a1b7d4e8f60a5b2c1d9e7f3a4b5c6d7e8f9",
+		ReleaseNotes:    "## What's Changed\n\n- Add CSV export (ENG-101)\n- Fix login redirect (ENG-102)",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Hash: "a1b2c3d4e5", Type: "feat", Scope: "export", Description: "add CSV export ENG-101"}},
+			Fixes:    []plugin.ConventionalCommit{{Hash: "b2c3d4e5f6", Type: "fix", Scope: "auth", Description: "fix login redirect ENG-102"}},
+			Breaking: []plugin.ConventionalCommit{{Hash: "c3d4e5f6a7", Type: "feat", Description: "drop the v1 API ENG-103", Breaking: true}},
+		},
+	}
+}
+
+// loadReleaseContext reads a release context from a JSON file in the
+// format Relicta sends to plugins. An empty path yields the sample release.
+func loadReleaseContext(path string) (plugin.ReleaseContext, error) {
+	if path == "" {
+		return sampleReleaseContext(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return plugin.ReleaseContext{}, fmt.Errorf("failed to read context: %w", err)
+	}
+
+	var releaseCtx plugin.ReleaseContext
+	if err := json.Unmarshal(data, &releaseCtx); err != nil {
+		return plugin.ReleaseContext{}, fmt.Errorf("failed to parse context: %w", err)
+	}
+	return releaseCtx, nil
+}
+
+// runRender renders a configured template against a release context and
+// prints it, so template authors can iterate without running releases.
+func runRender(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "path to a JSON file with the plugin configuration")
+	name := fs.String("template", "release_issue.description", "configured template to render, e.g. release_issue.title or comment_templates.de")
+	text := fs.String("text", "", "render this template text instead of a configured template")
+	contextPath := fs.String("context", "", "path to a JSON release context; a sample release is used without one")
+	team := fs.String("team", "Engineering", "team name for {{.Team}}")
+	project := fs.String("project", "", "project name for {{.Project}}")
+	promotedFrom := fs.String("promoted-from", "", "prerelease for {{.PromotedFrom}} in promotion_template")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	raw, err := loadConfigFile(*configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "render: %v\n", err)
+		return 1
+	}
+	cfg := (&LinearPlugin{}).parseConfig(raw)
+
+	tmpl := *text
+	if tmpl == "" {
+		templates := configuredTemplates(cfg)
+		var ok bool
+		if tmpl, ok = templates[*name]; !ok {
+			_, _ = fmt.Fprintf(stderr, "render: unknown template %q (one of %s)\n", *name, strings.Join(templateOptions(templates), ", "))
+			return 2
+		}
+	}
+
+	releaseCtx, err := loadReleaseContext(*contextPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "render: %v\n", err)
+		return 1
+	}
+	// Render what templates see in a release, with the configured notes
+	if _, err := applyReleaseNotesSource(cfg, &releaseCtx); err != nil {
+		_, _ = fmt.Fprintf(stderr, "render: using the context's release notes: %v\n", err)
+	}
+
+	data := newTemplateData(releaseCtx)
+	data.Team, data.Project, data.PromotedFrom = *team, *project, *promotedFrom
+	out, err := renderTemplateData(tmpl, data, cfg.TemplatePartials)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "render: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintln(stdout, out)
+	return 0
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunRender(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "linear.json")
+	data := `{"release_issue": {"title": "{{.Team}}: {{.Version}} ({{.FeatureCount}} features)"}, "comment_templates": {"de": "Veröffentlicht in {{.Version}}"}}`
+	if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	releaseCtx := filepath.Join(dir, "context.json")
+	if err := os.WriteFile(releaseCtx, []byte(`{"version": "2.0.0", "tag_name": "v2.0.0"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"sample release", []string{"--config", config, "--template", "release_issue.title"}, "Engineering: 1.5.0 (1 features)\n"},
+		{"context file", []string{"--config", config, "--template", "release_issue.title", "--context", releaseCtx, "--team", "Platform"}, "Platform: 2.0.0 (0 features)\n"},
+		{"locale template", []string{"--config", config, "--template", "comment_templates.de"}, "Veröffentlicht in 1.5.0\n"},
+		{"inline text", []string{"--text", "{{.TagName | upper}} after {{.PreviousVersion}}"}, "V1.5.0 after 1.4.2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runRender(tt.args, &stdout, &stderr); code != 0 {
+				t.Fatalf("exit code %d: %s", code, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunRenderErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runRender([]string{"--template", "release_issue.body"}, &stdout, &stderr); code != 2 {
+		t.Errorf("unknown template: exit code %d", code)
+	}
+	if !strings.Contains(stderr.String(), "release_issue.description") {
+		t.Errorf("expected the known templates to be listed, got %s", stderr.String())
+	}
+
+	stderr.Reset()
+	if code := runRender([]string{"--text", "{{.Version"}, &stdout, &stderr); code != 1 {
+		t.Errorf("invalid template: exit code %d", code)
+	}
+	if code := runRender([]string{"--context", filepath.Join(t.TempDir(), "missing.json")}, &stdout, &stderr); code != 1 {
+		t.Errorf("missing context: exit code %d", code)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Outcomes of ensureReleaseIssue.
+const (
+	releaseIssueCreated     = "created"
+	releaseIssueRepublished = "republished"
+	releaseIssueRefreshed   = "refreshed"
+)
+
+// ensureReleaseIssue returns the release issue for the version, creating it
+// unless a previous publish of the same version already did. Existing issues
+// get an "updated" comment describing what changed in the description and,
+// with release_issue.refresh_description, the changed description itself.
+func (p *LinearPlugin) ensureReleaseIssue(ctx context.Context, run *releaseRun, linked []*Issue) (issue *Issue, outcome string, err error) {
+	cfg, client := run.cfg, run.client
+	existing, err := client.FindIssuesByDescription(ctx, run.team.ID, formatMarker(cfg.Naming.marker(markerRelease), run.release.Version))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to look up existing release issue: %w", err)
+	}
+
+	if len(existing) == 0 {
+		issue, err := p.createReleaseIssue(ctx, run, linked)
+		return issue, releaseIssueCreated, err
+	}
+
+	issue = &existing[0]
+	_, description, err := renderReleaseIssue(ctx, run, linked)
+	if err != nil {
+		return nil, "", err
+	}
+
+	outcome = releaseIssueRepublished
+	changed := len(lineDelta(stripMarkers(issue.Description), stripMarkers(description))) > 0
+	if changed && cfg.ReleaseIssue.RefreshDescription {
+		if err := client.UpdateIssueDescription(ctx, issue.ID, description); err != nil {
+			return nil, "", fmt.Errorf("failed to refresh release issue %s: %w", issue.Identifier, err)
+		}
+		outcome = releaseIssueRefreshed
+	}
+
+	comment := republishComment(run.release.Version, issue.Description, description, time.Now().UTC())
+	if err := client.AddComment(ctx, issue.ID, comment); err != nil {
+		return nil, "", fmt.Errorf("failed to comment on existing release issue %s: %w", issue.Identifier, err)
+	}
+
+	issue.Description = description
+	return issue, outcome, nil
+}
+
+// republishComment describes a re-publish of an existing release, including
+// a line diff between the previous and current release descriptions.
+func republishComment(version, previous, current string, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Release %s was re-published at %s.", version, at.Format("2006-01-02 15:04 MST"))
+
+	delta := lineDelta(stripMarkers(previous), stripMarkers(current))
+	if len(delta) == 0 {
+		b.WriteString("\n\nThe release description is unchanged.")
+		return b.String()
+	}
+
+	b.WriteString("\n\nChanges to the release description:\n\n```diff\n")
+	for _, line := range delta {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("```")
+	return b.String()
+}
+
+// lineDelta lists lines removed from previous ("- ") and added in current
+// ("+ "), ignoring order and blank lines.
+func lineDelta(previous, current string) []string {
+	count := func(text string) map[string]int {
+		lines := make(map[string]int)
+		for _, l := range strings.Split(text, "\n") {
+			if l = strings.TrimRight(l, " \t"); strings.TrimSpace(l) != "" {
+				lines[l]++
+			}
+		}
+		return lines
+	}
+
+	before, after := count(previous), count(current)
+
+	var delta []string
+	for _, l := range strings.Split(previous, "\n") {
+		l = strings.TrimRight(l, " \t")
+		if before[l] > after[l] {
+			delta = append(delta, "- "+l)
+			before[l]--
+		}
+	}
+	before = count(previous)
+	for _, l := range strings.Split(current, "\n") {
+		l = strings.TrimRight(l, " \t")
+		if after[l] > before[l] {
+			delta = append(delta, "+ "+l)
+			after[l]--
+		}
+	}
+	return delta
+}
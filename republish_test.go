@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLineDelta(t *testing.T) {
+	previous := "## Release 1.0.0\n\n- Fix A\n- Fix B"
+	current := "## Release 1.0.0\n\n- Fix A\n- Fix C\n"
+
+	got := lineDelta(previous, current)
+	want := []string{"- - Fix B", "+ - Fix C"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("lineDelta() = %q, want %q", got, want)
+	}
+}
+
+func TestRepublishCommentUnchanged(t *testing.T) {
+	body := appendMarker("notes", markerRelease, "1.0.0")
+	comment := republishComment("1.0.0", body, "notes", time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC))
+
+	if !strings.Contains(comment, "re-published at 2026-01-02 03:04 UTC") {
+		t.Errorf("unexpected comment: %s", comment)
+	}
+	if !strings.Contains(comment, "unchanged") {
+		t.Errorf("expected unchanged note, got: %s", comment)
+	}
+}
+
+func TestEnsureReleaseIssueReusesExisting(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": func(vars map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []map[string]any{{
+				"id":          "uuid-100",
+				"identifier":  "ENG-100",
+				"description": "old notes\n\n`relicta:release=1.0.0`",
+			}}}}
+		},
+		"AddComment":  successHandler("commentCreate"),
+		"CreateIssue": successHandler("issueCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{})
+
+	issue, outcome, err := p.ensureReleaseIssue(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: "new notes"}, &Team{ID: "team-1"}), nil)
+	if err != nil {
+		t.Fatalf("ensureReleaseIssue() error = %v", err)
+	}
+
+	if outcome != releaseIssueRepublished || issue.Identifier != "ENG-100" {
+		t.Errorf("expected existing ENG-100 to be reused, got %s (%s)", issue.Identifier, outcome)
+	}
+	if fake.callCount("UpdateIssueDescription") != 0 {
+		t.Error("expected the description to be left alone without refresh_description")
+	}
+	if fake.callCount("CreateIssue") != 0 {
+		t.Error("expected no new release issue to be created")
+	}
+	if fake.callCount("AddComment") != 1 {
+		t.Error("expected an update comment on the existing release issue")
+	}
+}
+
+func TestEnsureReleaseIssueRefreshesDescription(t *testing.T) {
+	existing := func(description string) func(map[string]any) any {
+		return func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []map[string]any{{
+				"id":          "uuid-100",
+				"identifier":  "ENG-100",
+				"description": description,
+			}}}}
+		}
+	}
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"release_issue": map[string]any{"description": "{{.ReleaseNotes}}", "refresh_description": true},
+	})
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: "new notes"}
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": existing("old notes\n\n`relicta:release=1.0.0`"),
+		"UpdateIssueDescription":  successHandler("issueUpdate"),
+		"AddComment":              successHandler("commentCreate"),
+	})
+	_, outcome, err := p.ensureReleaseIssue(context.Background(), fake.run(cfg, releaseCtx, &Team{ID: "team-1"}), nil)
+	if err != nil {
+		t.Fatalf("ensureReleaseIssue() error = %v", err)
+	}
+	if outcome != releaseIssueRefreshed {
+		t.Errorf("outcome = %s, want %s", outcome, releaseIssueRefreshed)
+	}
+	calls := fake.calls["UpdateIssueDescription"]
+	if len(calls) != 1 {
+		t.Fatalf("expected one description update, got %d", len(calls))
+	}
+	body := calls[0]["input"].(map[string]any)["description"].(string)
+	if !strings.Contains(body, "new notes") || len(findMarkers(body, markerRelease)) != 1 {
+		t.Errorf("unexpected refreshed description %q", body)
+	}
+
+	unchanged := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": existing("new notes\n\n`relicta:release=1.0.0`"),
+		"AddComment":              successHandler("commentCreate"),
+	})
+	if _, outcome, _ := p.ensureReleaseIssue(context.Background(), unchanged.run(cfg, releaseCtx, &Team{ID: "team-1"}), nil); outcome != releaseIssueRepublished {
+		t.Errorf("expected an unchanged description not to be refreshed, got %s", outcome)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// reReleasedFrom returns the latest version in versions released before
+// current, other than a prerelease of current, e.g. "1.3.0" for an issue
+// referenced again by "1.4.0". Such issues were fixed again, which usually
+// means a regression.
+func reReleasedFrom(versions []string, current string) string {
+	core, _ := splitVersion(current)
+	var from string
+	for _, v := range versions {
+		vCore, _ := splitVersion(v)
+		if compareVersions(v, current) >= 0 || compareVersions(joinCore(vCore), joinCore(core)) == 0 {
+			continue
+		}
+		if from == "" || compareVersions(v, from) > 0 {
+			from = v
+		}
+	}
+	return from
+}
+
+// renderReReleaseComment renders the re-release comment for an issue,
+// including the release marker.
+func renderReReleaseComment(ctx context.Context, run *releaseRun, previous string) (string, error) {
+	cfg := run.cfg
+	data := run.templateData(ctx, cfg.ReReleaseTemplate)
+	data.PreviousVersion = previous
+
+	comment, err := renderTemplateData(cfg.ReReleaseTemplate, data, cfg.TemplatePartials)
+	if err != nil {
+		return "", err
+	}
+	return appendMarker(comment, cfg.Naming.marker(markerReleased), run.release.Version), nil
+}
+
+// detectReReleases reads the release history of the linked issues at plan
+// time and returns the issues an earlier version already released, mapped
+// to that version. Issues whose history cannot be read are reported in the
+// returned errors.
+func detectReReleases(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, version string, issueIDs []string) (map[string]string, []string) {
+	reReleased := make(map[string]string)
+	var errs []string
+	for _, id := range issueIDs {
+		comments, err := client.GetIssueComments(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Could not check release history of %s: %v", id, err))
+			continue
+		}
+		var versions []string
+		for _, c := range comments {
+			versions = append(versions, findMarkers(c.Body, cfg.Naming.marker(markerReleased))...)
+		}
+		if from := reReleasedFrom(versions, version); from != "" {
+			reReleased[id] = from
+		}
+	}
+	return reReleased, errs
+}
+
+// describeReReleases lists re-released issues with their earlier version,
+// e.g. "ENG-1 (1.3.0), ENG-7 (1.2.1)".
+func describeReReleases(reReleased map[string]string) string {
+	ids := make([]string, 0, len(reReleased))
+	for id := range reReleased {
+		ids = append(ids, id)
+	}
+	ids = sortedIssueIDs(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%s (%s)", id, reReleased[id]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestReReleasedFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		current  string
+		want     string
+	}{
+		{"earlier release", []string{"1.2.0", "1.3.0"}, "1.4.0", "1.3.0"},
+		{"earlier prerelease", []string{"1.3.0-rc.1"}, "1.4.0", "1.3.0-rc.1"},
+		{"prerelease of current", []string{"1.4.0-rc.1"}, "1.4.0", ""},
+		{"same version", []string{"1.4.0"}, "1.4.0", ""},
+		{"later version", []string{"1.5.0"}, "1.4.0", ""},
+		{"no history", nil, "1.4.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reReleasedFrom(tt.versions, tt.current); got != tt.want {
+				t.Errorf("reReleasedFrom(%v, %q) = %q, want %q", tt.versions, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+// releaseHistoryHandler answers GetIssueComments with released markers
+// for the given issues, looked up by ID or identifier.
+func releaseHistoryHandler(history map[string][]string) func(map[string]any) any {
+	return func(vars map[string]any) any {
+		var nodes []any
+		for _, v := range history[vars["id"].(string)] {
+			nodes = append(nodes, map[string]any{"id": "c-" + v, "body": "`relicta:released=" + v + "`"})
+		}
+		return map[string]any{"issue": map[string]any{"comments": map[string]any{"nodes": nodes}}}
+	}
+}
+
+func TestProcessLinkedIssuesReReleases(t *testing.T) {
+	comments := make(map[string]string)
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1"},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2"},
+		}),
+		"GetIssueComments": releaseHistoryHandler(map[string][]string{"uuid-1": {"1.3.0"}}),
+		"FindIssueLabel": func(map[string]any) any {
+			return map[string]any{"issueLabels": map[string]any{"nodes": []any{}}}
+		},
+		"CreateIssueLabel": func(map[string]any) any {
+			return map[string]any{"issueLabelCreate": map[string]any{
+				"success":    true,
+				"issueLabel": map[string]any{"id": "label-re", "name": "re-released"},
+			}}
+		},
+		"UpdateIssueLabels": successHandler("issueUpdate"),
+		"AddComment": func(vars map[string]any) any {
+			input := vars["input"].(map[string]any)
+			comments[input["issueId"].(string)] = input["body"].(string)
+			return map[string]any{"commentCreate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"update_linked_issues":     false,
+		"skip_previously_released": true,
+		"re_release_comments":      true,
+	})
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.4.0"}, &Team{ID: "team-1"}), []string{"ENG-1", "ENG-2"})
+
+	if res.ReReleased["ENG-1"] != "1.3.0" || len(res.PreviouslyReleased) != 0 {
+		t.Errorf("ReReleased = %v, PreviouslyReleased = %v", res.ReReleased, res.PreviouslyReleased)
+	}
+	if !strings.Contains(comments["uuid-1"], "Released again in 1.4.0 after 1.3.0") || !strings.Contains(comments["uuid-1"], formatMarker(markerReleased, "1.4.0")) {
+		t.Errorf("unexpected re-release comment %q", comments["uuid-1"])
+	}
+	if strings.Contains(comments["uuid-2"], "Released again") {
+		t.Errorf("expected a regular comment for ENG-2, got %q", comments["uuid-2"])
+	}
+
+	// Only the re-released issue is labelled, and it does not count as
+	// version labelled
+	calls := fake.calls["UpdateIssueLabels"]
+	if len(calls) != 1 || calls[0]["id"] != "uuid-1" || res.Labelled != 0 {
+		t.Errorf("unexpected label updates %v (Labelled = %d)", calls, res.Labelled)
+	}
+}
+
+func TestPostPlanReportsReReleases(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssueComments": releaseHistoryHandler(map[string][]string{"ENG-7": {"1.2.0", "1.3.1"}}),
+	})
+	fake.register(t, "lin_api_rerelease_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPlan,
+		Config: map[string]any{
+			"api_key":             "lin_api_rerelease_test",
+			"team_key":            "ENG",
+			"re_release_comments": true,
+		},
+		Context: plugin.ReleaseContext{
+			Version: "1.4.0",
+			Changes: &plugin.CategorizedChanges{Fixes: []plugin.ConventionalCommit{
+				{Description: "fix: pagination again (ENG-7)"},
+				{Description: "fix: typo (ENG-8)"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reReleased, _ := resp.Outputs["re_released_issues"].(map[string]string)
+	if len(reReleased) != 1 || reReleased["ENG-7"] != "1.3.1" {
+		t.Errorf("re_released_issues = %v (%s)", resp.Outputs["re_released_issues"], resp.Message)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// resultStatus classifies the outcome of one action.
+type resultStatus string
+
+const (
+	statusSuccess resultStatus = "success"
+	statusSkip    resultStatus = "skip"
+	statusWarn    resultStatus = "warn"
+	statusError   resultStatus = "error"
+)
+
+// actionResult is the outcome of one action, reported into a collector.
+type actionResult struct {
+	Action string       `json:"action"`
+	Status resultStatus `json:"status"`
+	Reason string       `json:"reason"`
+}
+
+// auditEntry records one change the plugin made, with the value before and
+// after, for change-management audit trails.
+type auditEntry struct {
+	Issue   string `json:"issue"`
+	Field   string `json:"field"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Outcome string `json:"outcome"`
+}
+
+// resultCollector gathers the outcome of every action in a hook and renders
+// the response message, outputs and report from them, so handlers do not
+// assemble messages by hand.
+type resultCollector struct {
+	mu       sync.Mutex
+	results  []actionResult
+	outputs  map[string]any
+	appendix []string
+	auditLog []auditEntry
+}
+
+// newResultCollector creates an empty collector.
+func newResultCollector() *resultCollector {
+	return &resultCollector{outputs: make(map[string]any)}
+}
+
+type resultCollectorKey struct{}
+
+// withResultCollector returns a context carrying a new collector for the run.
+func withResultCollector(ctx context.Context) (context.Context, *resultCollector) {
+	c := newResultCollector()
+	return context.WithValue(ctx, resultCollectorKey{}, c), c
+}
+
+// resultsFrom returns the collector attached to ctx, or a new one when the
+// handler runs outside Execute.
+func resultsFrom(ctx context.Context) *resultCollector {
+	if c, ok := ctx.Value(resultCollectorKey{}).(*resultCollector); ok {
+		return c
+	}
+	return newResultCollector()
+}
+
+// add records the outcome of an action.
+func (c *resultCollector) add(action string, status resultStatus, format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = append(c.results, actionResult{Action: action, Status: status, Reason: fmt.Sprintf(format, args...)})
+}
+
+// success records a completed action.
+func (c *resultCollector) success(action, format string, args ...any) {
+	c.add(action, statusSuccess, format, args...)
+}
+
+// skip records an action deliberately not taken.
+func (c *resultCollector) skip(action, format string, args ...any) {
+	c.add(action, statusSkip, format, args...)
+}
+
+// warn records a non-fatal failure.
+func (c *resultCollector) warn(action, format string, args ...any) {
+	c.add(action, statusWarn, format, args...)
+}
+
+// fail records a failure that fails the hook.
+func (c *resultCollector) fail(action, format string, args ...any) {
+	c.add(action, statusError, format, args...)
+}
+
+// output sets a structured output.
+func (c *resultCollector) output(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputs[key] = value
+}
+
+// audit records a change in the audit log written to the report.
+func (c *resultCollector) audit(entry auditEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auditLog = append(c.auditLog, entry)
+}
+
+// attach adds a block, such as a table, rendered after the message.
+func (c *resultCollector) attach(block string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.appendix = append(c.appendix, block)
+}
+
+// counts returns the number of results per status.
+func (c *resultCollector) counts() map[resultStatus]int {
+	counts := make(map[resultStatus]int)
+	for _, r := range c.results {
+		counts[r.Status]++
+	}
+	return counts
+}
+
+// message renders the results as a "; "-separated summary.
+func (c *resultCollector) message() string {
+	parts := make([]string, 0, len(c.results))
+	for _, r := range c.results {
+		switch r.Status {
+		case statusWarn:
+			parts = append(parts, "Warning: "+r.Reason)
+		case statusError:
+			// Reported in the response error
+		default:
+			parts = append(parts, r.Reason)
+		}
+	}
+
+	msg := strings.Join(parts, "; ")
+	if msg == "" && len(c.appendix) == 0 {
+		msg = "No actions taken"
+	}
+	for _, block := range c.appendix {
+		msg = strings.TrimSpace(msg + "\n\n" + block)
+	}
+	return msg
+}
+
+// response renders the collected results. The hook fails if any action
+// failed; the message still reports everything done before the failure.
+func (c *resultCollector) response() *plugin.ExecuteResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &plugin.ExecuteResponse{
+		Success: true,
+		Message: c.message(),
+	}
+	if len(c.outputs) > 0 || len(c.results) > 0 {
+		resp.Outputs = make(map[string]any, len(c.outputs)+1)
+		for k, v := range c.outputs {
+			resp.Outputs[k] = v
+		}
+		// Per-status counts, for dashboards tracking automation health
+		resp.Outputs["result_counts"] = c.counts()
+	}
+
+	var errs []string
+	for _, r := range c.results {
+		if r.Status == statusError {
+			errs = append(errs, r.Reason)
+		}
+	}
+	if len(errs) > 0 {
+		resp.Success = false
+		resp.Error = strings.Join(errs, "; ")
+	}
+	return resp
+}
+
+// report is the machine-readable record of a hook run.
+type report struct {
+	Hook    string               `json:"hook"`
+	Version string               `json:"version"`
+	Counts  map[resultStatus]int `json:"counts"`
+	Results []actionResult       `json:"results"`
+	Audit   []auditEntry         `json:"audit,omitempty"`
+	Outputs map[string]any       `json:"outputs,omitempty"`
+}
+
+// writeReport writes the collected results to path as JSON.
+func (c *resultCollector) writeReport(path string, hook plugin.Hook, releaseCtx plugin.ReleaseContext) error {
+	c.mu.Lock()
+	r := report{
+		Hook:    string(hook),
+		Version: releaseCtx.Version,
+		Counts:  c.counts(),
+		Results: append([]actionResult(nil), c.results...),
+		Audit:   append([]auditEntry(nil), c.auditLog...),
+		Outputs: c.outputs,
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestResultCollectorResponse(t *testing.T) {
+	rc := newResultCollector()
+	rc.success("release_issue", "Created release issue: %s", "ENG-1")
+	rc.skip("previously_released", "Skipped %d issue(s)", 2)
+	rc.warn("comment", "Failed to add comment to %s", "ENG-2")
+	rc.output("release_issue", "ENG-1")
+
+	resp := rc.response()
+	if !resp.Success {
+		t.Errorf("expected success without errors, got error %q", resp.Error)
+	}
+	want := "Created release issue: ENG-1; Skipped 2 issue(s); Warning: Failed to add comment to ENG-2"
+	if resp.Message != want {
+		t.Errorf("Message = %q, want %q", resp.Message, want)
+	}
+	if resp.Outputs["release_issue"] != "ENG-1" {
+		t.Errorf("expected release_issue output, got %v", resp.Outputs)
+	}
+	counts := resp.Outputs["result_counts"].(map[resultStatus]int)
+	if counts[statusSuccess] != 1 || counts[statusSkip] != 1 || counts[statusWarn] != 1 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+}
+
+func TestResultCollectorFailure(t *testing.T) {
+	rc := newResultCollector()
+	rc.success("enrich", "Enriched release notes")
+	rc.fail("release_issue", "Failed to create release issue: %s", "boom")
+
+	resp := rc.response()
+	if resp.Success {
+		t.Error("expected failure")
+	}
+	if resp.Error != "Failed to create release issue: boom" {
+		t.Errorf("Error = %q", resp.Error)
+	}
+	if resp.Message != "Enriched release notes" {
+		t.Errorf("expected completed actions in the message, got %q", resp.Message)
+	}
+}
+
+func TestResultCollectorEmpty(t *testing.T) {
+	if msg := newResultCollector().response().Message; msg != "No actions taken" {
+		t.Errorf("Message = %q", msg)
+	}
+}
+
+func TestExecuteWritesReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:   plugin.HookPostPlan,
+		Config: map[string]any{"team_key": "ENG", "report_file": path},
+		Context: plugin.ReleaseContext{
+			Version: "1.0.0",
+			Changes: &plugin.CategorizedChanges{
+				Features: []plugin.ConventionalCommit{{Description: "add export ENG-12"}},
+			},
+		},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Execute() = %v, %v", resp, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected report file: %v", err)
+	}
+	var r report
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("invalid report: %v", err)
+	}
+	if r.Hook != string(plugin.HookPostPlan) || r.Version != "1.0.0" {
+		t.Errorf("unexpected report header %+v", r)
+	}
+	if len(r.Results) != 1 || r.Results[0].Action != "linked_issues" || r.Results[0].Status != statusSuccess {
+		t.Errorf("unexpected report results %+v", r.Results)
+	}
+}
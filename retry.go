@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultRetryDelay  = 500 * time.Millisecond
+
+	// maxRetryDelay caps both the exponential backoff and the wait
+	// requested by a Retry-After header.
+	maxRetryDelay = 30 * time.Second
+
+	// defaultRetryBudget bounds the resends of one plugin execution.
+	defaultRetryBudget = 30
+)
+
+// StatusError is returned when Linear responds with a non-200 status.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the wait requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API error: %s (status %d)", e.Body, e.StatusCode)
+}
+
+// isRetryable reports whether a failed request may be sent again. Rate
+// limits and server errors are retried; transport errors only for queries,
+// since a mutation may have been applied before the connection dropped.
+func isRetryable(err error, mutation bool) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500 || statusErr.rateLimited()
+	}
+
+	var urlErr *url.Error
+	return !mutation && errors.As(err, &urlErr)
+}
+
+// rateLimited reports whether Linear rejected the request for exceeding its
+// rate limit, which it reports as a RATELIMITED error on a 400 response.
+func (e *StatusError) rateLimited() bool {
+	return e.StatusCode == http.StatusBadRequest && strings.Contains(e.Body, "RATELIMITED")
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. It returns zero when the header is missing or malformed.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// backoff returns the wait before resending a request that failed on the
+// given attempt: the Retry-After of a rate-limited or unavailable response
+// when Linear sent one, otherwise base doubled per attempt with jitter, so
+// concurrent runs do not retry in lockstep. Both are capped at
+// maxRetryDelay.
+func backoff(err error, base time.Duration, attempt int) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, maxRetryDelay)
+	}
+	if base <= 0 {
+		return 0
+	}
+
+	delay := maxRetryDelay
+	if shift := attempt - 1; shift < 16 {
+		delay = min(base<<shift, maxRetryDelay)
+	}
+	// Wait between half and all of the delay
+	return delay/2 + mathrand.N(delay/2+1)
+}
+
+// idempotentMutation reports whether a mutation carries a client-generated
+// ID in its input. Re-sending it cannot create a second entity, since Linear
+// rejects an ID that is already taken.
+func idempotentMutation(variables map[string]any) bool {
+	input, _ := variables["input"].(map[string]any)
+	id, _ := input["id"].(string)
+	return id != ""
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// retryRecord summarizes the retries of one operation.
+type retryRecord struct {
+	Operation string `json:"operation"`
+	Attempts  int    `json:"attempts"`
+	Outcome   string `json:"outcome"`
+	Error     string `json:"error,omitempty"`
+}
+
+// retryLog collects retry records for one plugin run.
+type retryLog struct {
+	mu      sync.Mutex
+	records []retryRecord
+}
+
+type retryLogKey struct{}
+
+// withRetryLog returns a context that records retries into a new log.
+func withRetryLog(ctx context.Context) (context.Context, *retryLog) {
+	l := &retryLog{}
+	return context.WithValue(ctx, retryLogKey{}, l), l
+}
+
+// recordRetry adds a record to the retry log attached to ctx, if any.
+func recordRetry(ctx context.Context, operation string, attempts int, err error) {
+	l, _ := ctx.Value(retryLogKey{}).(*retryLog)
+	if l == nil {
+		return
+	}
+
+	rec := retryRecord{Operation: operation, Attempts: attempts, Outcome: "succeeded"}
+	if err != nil {
+		rec.Outcome = "failed"
+		rec.Error = err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, rec)
+}
+
+// Records returns a copy of the collected retry records, ordered by
+// operation rather than by when concurrent calls finished.
+func (l *retryLog) Records() []retryRecord {
+	l.mu.Lock()
+	records := append([]retryRecord(nil), l.records...)
+	l.mu.Unlock()
+	slices.SortStableFunc(records, func(a, b retryRecord) int {
+		return strings.Compare(a.Operation, b.Operation)
+	})
+	return records
+}
+
+// message summarizes the retries for the response message.
+func (l *retryLog) message() string {
+	records := l.Records()
+	if len(records) == 0 {
+		return ""
+	}
+
+	failed := 0
+	for _, r := range records {
+		if r.Outcome == "failed" {
+			failed++
+		}
+	}
+	return fmt.Sprintf("Retried %d operation(s) (%d still failed)", len(records), failed)
+}
+
+// retryBudget bounds the resends of all calls of one plugin execution, so a
+// flaky API degrades into failed calls instead of every call retrying to
+// its limit.
+type retryBudget struct {
+	mu     sync.Mutex
+	max    int
+	used   int
+	denied int
+}
+
+type retryBudgetKey struct{}
+
+// withRetryBudget returns a context whose calls share max resends.
+func withRetryBudget(ctx context.Context, max int) (context.Context, *retryBudget) {
+	b := &retryBudget{max: max}
+	return context.WithValue(ctx, retryBudgetKey{}, b), b
+}
+
+// retryBudgetFrom returns the budget attached to ctx, if any.
+func retryBudgetFrom(ctx context.Context) *retryBudget {
+	b, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	return b
+}
+
+// allow consumes one resend from the budget, or counts a denied resend
+// once it is exhausted. A nil budget allows every resend.
+func (b *retryBudget) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.used >= b.max {
+		b.denied++
+		return false
+	}
+	b.used++
+	return true
+}
+
+// exhausted reports whether a resend was denied.
+func (b *retryBudget) exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.denied > 0
+}
+
+// summary returns the budget report included in outputs.
+func (b *retryBudget) summary() map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return map[string]any{"max": b.max, "used": b.used, "denied": b.denied}
+}
+
+// message describes the exhausted budget for the response message.
+func (b *retryBudget) message() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return fmt.Sprintf("Retry budget of %d resend(s) exhausted; %d failed call(s) were not retried", b.max, b.denied)
+}
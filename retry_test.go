@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteRetriesAndRecords(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		endpoint:    server.URL,
+		apiKey:      "lin_api_test",
+		httpClient:  http.DefaultClient,
+		maxAttempts: 3,
+	}
+
+	ctx, log := withRetryLog(context.Background())
+	if _, err := client.GetViewer(ctx); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+
+	records := log.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 retry record, got %d", len(records))
+	}
+	if records[0].Attempts != 3 || records[0].Outcome != "succeeded" {
+		t.Errorf("unexpected retry record: %+v", records[0])
+	}
+}
+
+func TestExecuteDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		endpoint:    server.URL,
+		apiKey:      "lin_api_test",
+		httpClient:  http.DefaultClient,
+		maxAttempts: 3,
+	}
+
+	ctx, log := withRetryLog(context.Background())
+	if _, err := client.GetViewer(ctx); err == nil {
+		t.Fatal("expected error for bad request")
+	}
+	if calls.Load() != 1 || len(log.Records()) != 0 {
+		t.Errorf("expected a single attempt without retry records, got %d calls", calls.Load())
+	}
+}
+
+func TestCreateIssueRetriesDroppedConnection(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		input := req.Variables["input"].(map[string]any)
+		ids = append(ids, input["id"].(string))
+
+		// Drop the connection on the first attempt, as a proxy might
+		if len(ids) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"issueCreate":{"success":true,"issue":{"id":"` + ids[1] + `","identifier":"ENG-1"}}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: server.Client(), maxAttempts: 3}
+
+	issue, err := client.CreateIssue(context.Background(), CreateIssueInput{TeamID: "team-1", Title: "Release 1.0.0"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != ids[1] || issue.ID != ids[0] {
+		t.Errorf("expected both attempts to send the same ID, got %v (issue %s)", ids, issue.ID)
+	}
+}
+
+func TestCreateIssueRecoversAppliedCreate(t *testing.T) {
+	var created string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "mutation CreateIssue") && created == "":
+			// The issue is created but the response is lost
+			created = req.Variables["input"].(map[string]any)["id"].(string)
+			w.WriteHeader(http.StatusBadGateway)
+		case strings.Contains(req.Query, "mutation CreateIssue"):
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Entity with this id already exists"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"issue":{"id":"` + created + `","identifier":"ENG-1"}}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient, maxAttempts: 3}
+
+	issue, err := client.CreateIssue(context.Background(), CreateIssueInput{TeamID: "team-1", Title: "Release 1.0.0"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if issue.Identifier != "ENG-1" || issue.ID != created {
+		t.Errorf("expected the created issue to be recovered, got %+v", issue)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.header, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	for attempt := 1; attempt <= 4; attempt++ {
+		ceiling := base << (attempt - 1)
+		for range 20 {
+			if got := backoff(errors.New("unavailable"), base, attempt); got < ceiling/2 || got > ceiling {
+				t.Fatalf("backoff(attempt %d) = %v, want between %v and %v", attempt, got, ceiling/2, ceiling)
+			}
+		}
+	}
+
+	if got := backoff(errors.New("unavailable"), base, 40); got > maxRetryDelay {
+		t.Errorf("backoff() = %v, want at most %v", got, maxRetryDelay)
+	}
+	if got := backoff(&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}, base, 1); got != 2*time.Second {
+		t.Errorf("backoff() = %v, want the Retry-After of 2s", got)
+	}
+	if got := backoff(&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}, base, 1); got != maxRetryDelay {
+		t.Errorf("backoff() = %v, want Retry-After capped at %v", got, maxRetryDelay)
+	}
+}
+
+func TestExecuteHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var first time.Time
+	var waited time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`))
+			return
+		}
+		waited = time.Since(first)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient, maxAttempts: 3}
+
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+	if calls.Load() != 2 || waited < time.Second {
+		t.Errorf("expected one retry after the requested second, got %d calls after %v", calls.Load(), waited)
+	}
+}
+
+func TestExecuteSharesRetryBudget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		endpoint:    server.URL,
+		apiKey:      "lin_api_test",
+		httpClient:  http.DefaultClient,
+		maxAttempts: 3,
+	}
+
+	ctx, budget := withRetryBudget(context.Background(), 3)
+	for range 4 {
+		if _, err := client.execute(ctx, `query GetViewer { viewer { id } }`, nil); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	// The first call resends twice, the second once before the budget
+	// runs out; later calls get a single attempt
+	if got := calls.Load(); got != 4+3 {
+		t.Errorf("expected 7 requests, got %d", got)
+	}
+	summary := budget.summary()
+	if summary["used"] != 3 || summary["denied"] != 3 || !budget.exhausted() {
+		t.Errorf("unexpected budget %v", summary)
+	}
+	if !strings.Contains(budget.message(), "3 failed call(s) were not retried") {
+		t.Errorf("message() = %q", budget.message())
+	}
+}
+
+func TestRetryBudgetDefaults(t *testing.T) {
+	p := &LinearPlugin{}
+	if got := p.parseConfig(map[string]any{}).RetryBudget; got != defaultRetryBudget {
+		t.Errorf("RetryBudget = %d, want %d", got, defaultRetryBudget)
+	}
+	var unlimited *retryBudget
+	if !unlimited.allow() {
+		t.Error("expected a nil budget to allow resends")
+	}
+}
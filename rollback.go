@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// What happens to the release issue on rollback.
+const (
+	rollbackCancel = "cancel"
+	rollbackDelete = "delete"
+	rollbackKeep   = "keep"
+)
+
+// RollbackConfig journals the changes made on publish so a rollback can
+// revert them.
+type RollbackConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Journal      string `json:"journal"`
+	ReleaseIssue string `json:"release_issue"`
+}
+
+// parseRollbackConfig parses the rollback block.
+func parseRollbackConfig(raw map[string]any) RollbackConfig {
+	parser := helpers.NewConfigParser(raw)
+	return RollbackConfig{
+		Enabled:      parser.GetBool("enabled", false),
+		Journal:      parser.GetString("journal", "", ".relicta/linear-journal.json"),
+		ReleaseIssue: parser.GetString("release_issue", "", rollbackCancel),
+	}
+}
+
+// releaseJournal records what publishing a version changed in Linear.
+type releaseJournal struct {
+	Version string `json:"version"`
+	// ReleaseIssue is the release issue created on publish; issues reused
+	// from an earlier publish are not recorded.
+	ReleaseIssue *journalIssue     `json:"release_issue,omitempty"`
+	Transitions  []stateTransition `json:"transitions,omitempty"`
+	RecordedAt   time.Time         `json:"recorded_at"`
+}
+
+// journalIssue identifies an issue in the journal.
+type journalIssue struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+}
+
+// writeJournal records the journal in path.
+func writeJournal(path string, journal releaseJournal) error {
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// readJournal returns the journal recorded in path, or nil if there is none.
+func readJournal(path string) (*releaseJournal, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var journal releaseJournal
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &journal, nil
+}
+
+// revertible reports whether a transition changed the issue's state.
+func (t stateTransition) revertible() bool {
+	return t.FromID != "" && t.ToID != "" && t.ToID != t.FromID
+}
+
+// rollbackResults summarizes a rollback.
+type rollbackResults struct {
+	Reverted []string
+	// Moved lists issues left alone because they changed state since the
+	// release.
+	Moved        []string
+	ReleaseIssue string
+	Errors       []string
+}
+
+// rollbackRelease moves the journal's issues back to their previous states
+// and cancels or deletes the release issue. Issues that moved on since the
+// release are left alone.
+func rollbackRelease(ctx context.Context, client *LinearClient, cfg *Config, journal *releaseJournal) rollbackResults {
+	var res rollbackResults
+	for _, t := range journal.Transitions {
+		if !t.revertible() {
+			continue
+		}
+		issue, err := client.GetIssueByIdentifier(ctx, t.Issue)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("Failed to get %s: %v", t.Issue, err))
+			continue
+		}
+		if issue.State.ID != t.ToID {
+			res.Moved = append(res.Moved, t.Issue)
+			continue
+		}
+		if err := client.UpdateIssueState(ctx, issue.ID, t.FromID); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("Failed to move %s back to %s: %v", t.Issue, t.From, err))
+			continue
+		}
+		res.Reverted = append(res.Reverted, t.Issue)
+	}
+
+	if ri := journal.ReleaseIssue; ri != nil {
+		var err error
+		switch cfg.Rollback.ReleaseIssue {
+		case rollbackDelete:
+			err = client.DeleteIssue(ctx, ri.ID)
+		case rollbackCancel:
+			err = cancelIssue(ctx, client, cfg, ri.ID)
+		default:
+			return res
+		}
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("Failed to %s release issue %s: %v", cfg.Rollback.ReleaseIssue, ri.Identifier, err))
+		} else {
+			res.ReleaseIssue = ri.Identifier
+		}
+	}
+	return res
+}
+
+// cancelIssue moves an issue to the team's first canceled state.
+func cancelIssue(ctx context.Context, client *LinearClient, cfg *Config, issueID string) error {
+	team, err := client.GetTeam(ctx, cfg.TeamID, cfg.TeamKey)
+	if err != nil {
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+	for _, s := range team.States {
+		if s.Type == "canceled" {
+			return client.UpdateIssueState(ctx, issueID, s.ID)
+		}
+	}
+	return fmt.Errorf("team %s has no canceled state", team.Key)
+}
+
+// rollbackOnError reverts the changes journaled when the version was
+// published. The plugin protocol carries no rollback hook, so a release that
+// fails after publish is rolled back from the OnError hook.
+func (p *LinearPlugin) rollbackOnError(ctx context.Context, run *releaseRun) error {
+	cfg, rc := run.cfg, run.results
+	journal, err := readJournal(cfg.Rollback.Journal)
+	switch {
+	case err != nil:
+		rc.fail("rollback", "Failed to read rollback journal: %v", err)
+		return nil
+	case journal == nil || journal.Version != run.release.Version:
+		rc.skip("rollback", "No journal of Linear changes for %s", run.release.Version)
+		return nil
+	}
+
+	if run.dryRun {
+		n := 0
+		for _, t := range journal.Transitions {
+			if t.revertible() {
+				n++
+			}
+		}
+		rc.success("rollback", "Would move %d issue(s) back to their previous state", n)
+		if journal.ReleaseIssue != nil && cfg.Rollback.ReleaseIssue != rollbackKeep {
+			rc.success("rollback", "Would %s release issue %s", cfg.Rollback.ReleaseIssue, journal.ReleaseIssue.Identifier)
+		}
+		return nil
+	}
+
+	res := rollbackRelease(ctx, run.client, cfg, journal)
+	if len(res.Reverted) > 0 {
+		rc.success("rollback", "Moved %d issue(s) back to their previous state", len(res.Reverted))
+		rc.output("reverted_issues", res.Reverted)
+	}
+	if len(res.Moved) > 0 {
+		rc.skip("rollback", "Left %d issue(s) that changed state since the release: %v", len(res.Moved), res.Moved)
+	}
+	if res.ReleaseIssue != "" {
+		rc.success("rollback", "Release issue %s: %s", res.ReleaseIssue, cfg.Rollback.ReleaseIssue)
+	}
+	for _, e := range res.Errors {
+		rc.warn("rollback", "%s", e)
+	}
+
+	// Keep the journal for another attempt unless everything was reverted
+	if len(res.Errors) == 0 {
+		if err := os.Remove(cfg.Rollback.Journal); err != nil {
+			rc.warn("rollback", "Failed to remove rollback journal: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRollbackRelease(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-done"}},
+			"ENG-2": {"id": "uuid-2", "identifier": "ENG-2", "state": map[string]any{"id": "state-qa"}},
+		}),
+		"GetTeam": func(map[string]any) any {
+			return map[string]any{"team": map[string]any{"id": "team-1", "key": "ENG", "states": map[string]any{"nodes": []any{
+				map[string]any{"id": "state-done", "name": "Done", "type": "completed"},
+				map[string]any{"id": "state-canceled", "name": "Canceled", "type": "canceled"},
+			}}}}
+		},
+		"UpdateIssueState": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_id": "team-1", "rollback": map[string]any{"enabled": true}})
+	journal := &releaseJournal{
+		Version:      "1.0.0",
+		ReleaseIssue: &journalIssue{ID: "uuid-100", Identifier: "ENG-100"},
+		Transitions: []stateTransition{
+			{Issue: "ENG-1", From: "In Review", FromID: "state-review", ToID: "state-done", Outcome: transitionUpdated},
+			{Issue: "ENG-2", From: "In Review", FromID: "state-review", ToID: "state-done", Outcome: transitionUpdated},
+			{Issue: "ENG-3", FromID: "state-done", ToID: "state-done", Outcome: transitionUpdated},
+		},
+	}
+
+	res := rollbackRelease(context.Background(), fake.client(), cfg, journal)
+
+	if !reflect.DeepEqual(res.Reverted, []string{"ENG-1"}) || !reflect.DeepEqual(res.Moved, []string{"ENG-2"}) {
+		t.Errorf("Reverted = %v, Moved = %v", res.Reverted, res.Moved)
+	}
+	if res.ReleaseIssue != "ENG-100" || len(res.Errors) > 0 {
+		t.Errorf("ReleaseIssue = %q, errors %v", res.ReleaseIssue, res.Errors)
+	}
+
+	want := map[string]string{"uuid-1": "state-review", "uuid-100": "state-canceled"}
+	got := make(map[string]string)
+	for _, vars := range fake.calls["UpdateIssueState"] {
+		got[vars["id"].(string)] = vars["input"].(map[string]any)["stateId"].(string)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("state updates = %v, want %v", got, want)
+	}
+}
+
+func TestOnErrorRollback(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-done"}},
+		}),
+		"UpdateIssueState": successHandler("issueUpdate"),
+		"DeleteIssue":      successHandler("issueDelete"),
+	})
+	fake.register(t, "lin_api_rollback_test")
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	err := writeJournal(path, releaseJournal{
+		Version:      "1.0.0",
+		ReleaseIssue: &journalIssue{ID: "uuid-100", Identifier: "ENG-100"},
+		Transitions:  []stateTransition{{Issue: "ENG-1", FromID: "state-review", ToID: "state-done", Outcome: transitionUpdated}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"api_key":  "lin_api_rollback_test",
+			"team_id":  "team-1",
+			"rollback": map[string]any{"enabled": true, "journal": path, "release_issue": "delete"},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := resp.Outputs["reverted_issues"]; !reflect.DeepEqual(got, []string{"ENG-1"}) {
+		t.Errorf("reverted_issues = %v (%s)", got, resp.Message)
+	}
+	if fake.callCount("DeleteIssue") != 1 {
+		t.Error("expected the release issue to be deleted")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the journal to be removed, got %v", err)
+	}
+}
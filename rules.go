@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionRule overrides configuration options for releases whose version
+// matches Match, either a version pattern ("*.*.0") or a semver range
+// (">=2.0.0 <3.0.0").
+type versionRule struct {
+	Match     string
+	Overrides map[string]any
+}
+
+// versionRuleProtectedKeys cannot be overridden per version.
+var versionRuleProtectedKeys = map[string]bool{
+	"api_key":          true,
+	"sandbox":          true,
+	"workspace_config": true,
+	"version_rules":    true,
+}
+
+// parseVersionRules parses the version_rules list. Every key of a rule
+// other than "match" is an override.
+func parseVersionRules(raw map[string]any) []versionRule {
+	list, _ := raw["version_rules"].([]any)
+	rules := make([]versionRule, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		match, _ := m["match"].(string)
+		overrides := make(map[string]any, len(m))
+		for k, v := range m {
+			if k != "match" && !versionRuleProtectedKeys[k] {
+				overrides[k] = v
+			}
+		}
+		rules = append(rules, versionRule{Match: match, Overrides: overrides})
+	}
+	return rules
+}
+
+// matches reports whether the rule applies to version.
+func (r versionRule) matches(version string) bool {
+	if isVersionRange(r.Match) {
+		return matchVersionRange(r.Match, version)
+	}
+	return matchVersionPattern(r.Match, version)
+}
+
+// applyVersionRules returns the raw configuration with the overrides of
+// every rule matching version applied in order, so later rules win. It
+// also returns the patterns of the matching rules.
+func applyVersionRules(raw map[string]any, version string) (map[string]any, []string) {
+	var matched []string
+	for _, rule := range parseVersionRules(raw) {
+		if !rule.matches(version) {
+			continue
+		}
+		raw = overlayConfig(raw, rule.Overrides)
+		matched = append(matched, rule.Match)
+	}
+	return raw, matched
+}
+
+// overlayConfig returns base with overrides applied; nested blocks are
+// merged key by key.
+func overlayConfig(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		if baseBlock, ok := merged[k].(map[string]any); ok {
+			if block, ok := v.(map[string]any); ok {
+				merged[k] = mergeBlock(block, baseBlock)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// isVersionRange reports whether match is a semver range rather than a
+// version pattern.
+func isVersionRange(match string) bool {
+	match = strings.TrimSpace(match)
+	return match != "" && strings.ContainsRune("<>=", rune(match[0]))
+}
+
+// matchVersionRange reports whether version satisfies every space-separated
+// comparison of a range such as ">=2.0.0 <3.0.0".
+func matchVersionRange(match, version string) bool {
+	constraints := strings.Fields(match)
+	if len(constraints) == 0 {
+		return false
+	}
+	for _, c := range constraints {
+		op, bound := splitConstraint(c)
+		if bound == "" {
+			return false
+		}
+		cmp := compareVersions(version, bound)
+		var ok bool
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "=":
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// splitConstraint splits a range constraint into its operator and version.
+func splitConstraint(c string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if rest, ok := strings.CutPrefix(c, op); ok {
+			return op, rest
+		}
+	}
+	return "", ""
+}
+
+// isValidVersionRuleMatch reports whether match is a usable version pattern
+// or range.
+func isValidVersionRuleMatch(match string) bool {
+	if !isVersionRange(match) {
+		return strings.TrimSpace(match) != "" && isValidVersionPattern(match)
+	}
+	for _, c := range strings.Fields(match) {
+		_, bound := splitConstraint(c)
+		if bound == "" {
+			return false
+		}
+		core, _, _ := strings.Cut(strings.TrimPrefix(bound, "v"), "-")
+		for _, part := range strings.Split(core, ".") {
+			if _, err := strconv.Atoi(part); err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchVersionRange(t *testing.T) {
+	tests := []struct {
+		match   string
+		version string
+		want    bool
+	}{
+		{">=2.0.0 <3.0.0", "2.4.1", true},
+		{">=2.0.0 <3.0.0", "3.0.0", false},
+		{">1.0.0", "1.0.0", false},
+		{"=1.2.3", "v1.2.3", true},
+		{"<1.0.0", "1.0.0-rc.1", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchVersionRange(tt.match, tt.version); got != tt.want {
+			t.Errorf("matchVersionRange(%q, %q) = %v, want %v", tt.match, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestApplyVersionRules(t *testing.T) {
+	raw := map[string]any{
+		"api_key":              "lin_api_test",
+		"create_release_issue": false,
+		"add_release_comment":  true,
+		"release_issue":        map[string]any{"title": "Release {{.Version}}", "priority": 4},
+		"version_rules": []any{
+			map[string]any{
+				"match":                "*.*.0",
+				"create_release_issue": true,
+				"release_issue":        map[string]any{"priority": 2},
+			},
+			map[string]any{
+				"match":   "*.0.0",
+				"api_key": "lin_api_other",
+				"release_issue": map[string]any{
+					"priority": 1,
+				},
+			},
+		},
+	}
+
+	merged, matched := applyVersionRules(raw, "2.0.0")
+	if !reflect.DeepEqual(matched, []string{"*.*.0", "*.0.0"}) {
+		t.Fatalf("matched = %v", matched)
+	}
+
+	cfg := (&LinearPlugin{}).parseConfig(merged)
+	if !cfg.CreateReleaseIssue || !cfg.AddReleaseComment {
+		t.Error("expected rule overrides on top of the base config")
+	}
+	if p := cfg.ReleaseIssue.Priority; p == nil || *p != 1 {
+		t.Errorf("Priority = %v, want the last matching rule to win", p)
+	}
+	if cfg.ReleaseIssue.Title != "Release {{.Version}}" {
+		t.Errorf("Title = %q, want nested blocks merged key by key", cfg.ReleaseIssue.Title)
+	}
+	if cfg.APIKey != "lin_api_test" {
+		t.Errorf("APIKey = %q, want protected key unchanged", cfg.APIKey)
+	}
+
+	if _, matched := applyVersionRules(raw, "2.0.3"); len(matched) != 0 {
+		t.Errorf("expected no rule to match a patch release, got %v", matched)
+	}
+}
+
+func TestIsValidVersionRuleMatch(t *testing.T) {
+	for _, match := range []string{"*.*.0", ">=2.0.0 <3.0.0", "=1.0.0-rc.1"} {
+		if !isValidVersionRuleMatch(match) {
+			t.Errorf("expected %q to be valid", match)
+		}
+	}
+	for _, match := range []string{"", ">=2.x", "<=", "1.a"} {
+		if isValidVersionRuleMatch(match) {
+			t.Errorf("expected %q to be invalid", match)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// releaseRun is the state of one hook invocation: the configuration, the
+// release, the shared client, the resolved team and the result collector.
+// Handlers and the operations they call take it instead of long parameter
+// lists, so cross-cutting features such as caching, auditing and metrics
+// hook in at one place.
+type releaseRun struct {
+	cfg     *Config
+	release plugin.ReleaseContext
+	dryRun  bool
+	client  *LinearClient
+	results *resultCollector
+
+	// team is the primary team, once resolved.
+	team *Team
+	// project is the configured project, once looked up for templates.
+	// Issues render their comments concurrently, so projectMu guards it.
+	project   *Project
+	projectMu sync.Mutex
+}
+
+// newReleaseRun prepares the run of a hook. It reports into the collector
+// attached to ctx.
+func newReleaseRun(ctx context.Context, cfg *Config, req plugin.ExecuteRequest) *releaseRun {
+	return &releaseRun{
+		cfg:     cfg,
+		release: req.Context,
+		dryRun:  req.DryRun,
+		client:  cfg.client(),
+		results: resultsFrom(ctx),
+	}
+}
+
+// resolveTeam fetches the primary team, once per run.
+func (r *releaseRun) resolveTeam(ctx context.Context) (*Team, error) {
+	if r.team != nil {
+		return r.team, nil
+	}
+	team, err := r.client.ReadOnly().GetTeam(ctx, r.cfg.TeamID, r.cfg.TeamKey)
+	if err != nil {
+		return nil, err
+	}
+	r.team = team
+	return team, nil
+}
+
+// templateData returns the template data of the release with the names of
+// the primary team, when resolved, and of the configured project. The
+// project is only looked up when a template mentions it; a failed lookup
+// leaves the name empty rather than failing the render.
+func (r *releaseRun) templateData(ctx context.Context, tmpl string) templateData {
+	data := newTemplateData(r.release)
+	if r.team != nil {
+		data.Team = r.team.Name
+	}
+	if r.cfg.ProjectID == "" || !mentionsProject(tmpl, r.cfg.TemplatePartials) {
+		return data
+	}
+
+	r.projectMu.Lock()
+	defer r.projectMu.Unlock()
+	if r.project == nil {
+		if project, err := r.client.ReadOnly().GetProject(ctx, r.cfg.ProjectID); err == nil {
+			r.project = project
+		}
+	}
+	if r.project != nil {
+		data.Project = r.project.Name
+	}
+	return data
+}
+
+// render renders a template with the run's template data.
+func (r *releaseRun) render(ctx context.Context, tmpl string) (string, error) {
+	return renderTemplateData(tmpl, r.templateData(ctx, tmpl), r.cfg.TemplatePartials)
+}
+
+// mentionsProject reports whether a template or its partials use .Project.
+func mentionsProject(tmpl string, partials map[string]string) bool {
+	if strings.Contains(tmpl, ".Project") {
+		return true
+	}
+	for _, p := range partials {
+		if strings.Contains(p, ".Project") {
+			return true
+		}
+	}
+	return false
+}
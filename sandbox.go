@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// sandboxEnvVar forces sandbox routing when set to a true value, which lets
+// CI test pipelines rehearse releases without touching production issues.
+const sandboxEnvVar = "LINEAR_SANDBOX"
+
+// SandboxConfig routes rehearsal releases to a separate Linear workspace.
+type SandboxConfig struct {
+	Enabled              bool     `json:"enabled"`
+	Always               bool     `json:"always"`
+	APIKey               string   `json:"api_key"`
+	TeamID               string   `json:"team_id"`
+	TeamKey              string   `json:"team_key"`
+	ProjectID            string   `json:"project_id,omitempty"`
+	IssuePrefix          string   `json:"issue_prefix,omitempty"`
+	ExpectedOrganization string   `json:"expected_organization,omitempty"`
+	ProductionBranches   []string `json:"production_branches"`
+}
+
+// parseSandboxConfig parses the sandbox block.
+func parseSandboxConfig(raw map[string]any) SandboxConfig {
+	parser := helpers.NewConfigParser(raw)
+	return SandboxConfig{
+		Enabled:              parser.GetBool("enabled", false),
+		Always:               parser.GetBool("always", false),
+		APIKey:               parser.GetString("api_key", "LINEAR_SANDBOX_API_KEY", ""),
+		TeamID:               parser.GetString("team_id", "", ""),
+		TeamKey:              parser.GetString("team_key", "", ""),
+		ProjectID:            parser.GetString("project_id", "", ""),
+		IssuePrefix:          parser.GetString("issue_prefix", "", ""),
+		ExpectedOrganization: parser.GetString("expected_organization", "", ""),
+		ProductionBranches:   parser.GetStringSlice("production_branches", []string{"main", "master"}),
+	}
+}
+
+// shouldUseSandbox reports whether a release should target the sandbox
+// workspace: always when forced, otherwise for releases from non-production
+// branches or when the sandbox environment variable is set.
+func (s SandboxConfig) shouldUseSandbox(releaseCtx plugin.ReleaseContext) bool {
+	if !s.Enabled {
+		return false
+	}
+	if s.Always || sandboxRequested(releaseCtx) {
+		return true
+	}
+	if releaseCtx.Branch == "" {
+		return false
+	}
+	for _, b := range s.ProductionBranches {
+		if strings.EqualFold(b, releaseCtx.Branch) {
+			return false
+		}
+	}
+	return true
+}
+
+// sandboxRequested checks the release environment and the process
+// environment for the sandbox toggle.
+func sandboxRequested(releaseCtx plugin.ReleaseContext) bool {
+	val, ok := releaseCtx.Environment[sandboxEnvVar]
+	if !ok {
+		val = os.Getenv(sandboxEnvVar)
+	}
+	b, _ := strconv.ParseBool(val)
+	return b
+}
+
+// applySandbox switches the configuration to the sandbox workspace when the
+// release should be routed there. Production teams are dropped so only the
+// sandbox team is touched. It reports whether the switch happened.
+func applySandbox(cfg *Config, releaseCtx plugin.ReleaseContext) bool {
+	if !cfg.Sandbox.shouldUseSandbox(releaseCtx) {
+		return false
+	}
+
+	cfg.APIKey = cfg.Sandbox.APIKey
+	cfg.Credentials = CredentialsConfig{Provider: credentialStatic}
+	cfg.TeamID = cfg.Sandbox.TeamID
+	cfg.TeamKey = cfg.Sandbox.TeamKey
+	cfg.ProjectID = cfg.Sandbox.ProjectID
+	cfg.Teams = nil
+	cfg.ExpectedOrganization = cfg.Sandbox.ExpectedOrganization
+	if cfg.Sandbox.IssuePrefix != "" {
+		cfg.IssuePrefix = cfg.Sandbox.IssuePrefix
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestApplySandbox(t *testing.T) {
+	p := &LinearPlugin{}
+	raw := map[string]any{
+		"api_key":  "lin_api_prod",
+		"team_key": "ENG",
+		"sandbox": map[string]any{
+			"enabled":  true,
+			"api_key":  "lin_api_sandbox",
+			"team_key": "SBX",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     plugin.ReleaseContext
+		sandbox bool
+	}{
+		{"main branch", plugin.ReleaseContext{Branch: "main"}, false},
+		{"feature branch", plugin.ReleaseContext{Branch: "feature/x"}, true},
+		{"ci toggle", plugin.ReleaseContext{Branch: "main", Environment: map[string]string{"LINEAR_SANDBOX": "true"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := p.parseConfig(raw)
+			got := applySandbox(cfg, tt.ctx)
+			if got != tt.sandbox {
+				t.Fatalf("applySandbox() = %v, want %v", got, tt.sandbox)
+			}
+			wantKey := "lin_api_prod"
+			if tt.sandbox {
+				wantKey = "lin_api_sandbox"
+			}
+			if cfg.APIKey != wantKey {
+				t.Errorf("APIKey = %s, want %s", cfg.APIKey, wantKey)
+			}
+			if cfg.IssuePrefix != "ENG" {
+				t.Errorf("IssuePrefix = %s, want ENG", cfg.IssuePrefix)
+			}
+		})
+	}
+}
+
+func TestApplySandboxDropsTeams(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"api_key": "lin_api_prod",
+		"teams":   []any{map[string]any{"key": "ENG"}, map[string]any{"key": "OPS"}},
+		"sandbox": map[string]any{"enabled": true, "always": true, "api_key": "lin_api_sandbox", "team_key": "SBX"},
+	})
+	if len(cfg.Teams) != 2 {
+		t.Fatalf("Teams = %v, want 2 teams", cfg.Teams)
+	}
+
+	if !applySandbox(cfg, plugin.ReleaseContext{}) {
+		t.Fatal("expected the release to be routed to the sandbox")
+	}
+	if len(cfg.Teams) > 0 || cfg.TeamKey != "SBX" {
+		t.Errorf("Teams = %v, TeamKey = %s, want only the sandbox team", cfg.Teams, cfg.TeamKey)
+	}
+}
+
+func TestSandboxSkipsWorkspaceConfig(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{})
+	fake.register(t, "lin_api_prod_workspace")
+	fake.register(t, "lin_api_sandbox_workspace")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"api_key":          "lin_api_prod_workspace",
+			"team_key":         "ENG",
+			"workspace_config": map[string]any{"document_id": "doc-1"},
+			"sandbox":          map[string]any{"enabled": true, "always": true, "api_key": "lin_api_sandbox_workspace", "team_key": "SBX"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if n := fake.callCount("GetDocument"); n != 0 {
+		t.Errorf("expected no workspace config lookup in the sandbox, got %d", n)
+	}
+	if resp.Outputs["sandbox"] != true {
+		t.Errorf("expected the sandbox output, got %v", resp.Outputs)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Release issue description sections assembled by the plugin.
+const (
+	sectionSummary         = "summary"
+	sectionStats           = "stats"
+	sectionIssuesByProject = "issues-by-project"
+	sectionBreaking        = "breaking-changes"
+	sectionContributors    = "contributors"
+)
+
+// knownSections lists the supported release_issue.sections values.
+var knownSections = []string{
+	sectionSummary,
+	sectionStats,
+	sectionIssuesByProject,
+	sectionBreaking,
+	sectionContributors,
+}
+
+// isKnownSection reports whether name is a supported section.
+func isKnownSection(name string) bool {
+	for _, s := range knownSections {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionsNeedIssues reports whether any section requires linked issue
+// details from Linear.
+func sectionsNeedIssues(sections []string) bool {
+	for _, s := range sections {
+		if s == sectionIssuesByProject || s == sectionContributors || s == sectionStats {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchIssues looks up issue details for the given identifiers, skipping
+// (and reporting) any that cannot be resolved.
+func fetchIssues(ctx context.Context, client *LinearClient, ids []string) ([]*Issue, []string) {
+	var issues []*Issue
+	var errs []string
+	prefetched, _ := prefetchIssues(ctx, client, ids)
+	for _, id := range ids {
+		issue, err := prefetched.lookup(ctx, client, id)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Issue %s not found: %v", id, err))
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, errs
+}
+
+// renderSections assembles the release issue description from the
+// configured sections in order.
+func renderSections(sections []string, releaseCtx plugin.ReleaseContext, linked []*Issue) string {
+	var parts []string
+	for _, name := range sections {
+		var part string
+		switch name {
+		case sectionSummary:
+			part = summarySection(releaseCtx)
+		case sectionStats:
+			part = statsSection(releaseCtx, linked)
+		case sectionIssuesByProject:
+			part = issuesByProjectSection(linked)
+		case sectionBreaking:
+			part = breakingSection(releaseCtx)
+		case sectionContributors:
+			part = contributorsSection(releaseCtx, linked)
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func summarySection(releaseCtx plugin.ReleaseContext) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Release %s\n\n", releaseCtx.Version)
+	fmt.Fprintf(&b, "**Released:** %s\n", time.Now().Format("2006-01-02"))
+	if releaseCtx.TagName != "" {
+		fmt.Fprintf(&b, "**Tag:** %s\n", releaseCtx.TagName)
+	}
+	if releaseCtx.ReleaseType != "" {
+		fmt.Fprintf(&b, "**Type:** %s\n", releaseCtx.ReleaseType)
+	}
+	if releaseCtx.PreviousVersion != "" {
+		fmt.Fprintf(&b, "**Previous version:** %s\n", releaseCtx.PreviousVersion)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func statsSection(releaseCtx plugin.ReleaseContext, linked []*Issue) string {
+	var features, fixes, breaking, other int
+	if c := releaseCtx.Changes; c != nil {
+		features, fixes, breaking = len(c.Features), len(c.Fixes), len(c.Breaking)
+		other = len(c.Performance) + len(c.Refactor) + len(c.Docs) + len(c.Other)
+	}
+
+	var estimate float64
+	for _, issue := range linked {
+		estimate += issue.Estimate
+	}
+
+	return fmt.Sprintf("### Stats\n\n| Features | Fixes | Breaking | Other | Linked issues | Estimate |\n|---|---|---|---|---|---|\n| %d | %d | %d | %d | %d | %g |",
+		features, fixes, breaking, other, len(linked), estimate)
+}
+
+func issuesByProjectSection(linked []*Issue) string {
+	if len(linked) == 0 {
+		return ""
+	}
+
+	groups := make(map[string][]*Issue)
+	for _, issue := range linked {
+		name := "No project"
+		if issue.Project != nil && issue.Project.Name != "" {
+			name = issue.Project.Name
+		}
+		groups[name] = append(groups[name], issue)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("### Issues by project")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n\n**%s**\n", name)
+		for _, issue := range groups[name] {
+			fmt.Fprintf(&b, "\n- %s %s", issue.Identifier, issue.Title)
+		}
+	}
+	return b.String()
+}
+
+func breakingSection(releaseCtx plugin.ReleaseContext) string {
+	if releaseCtx.Changes == nil || len(releaseCtx.Changes.Breaking) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("### Breaking changes\n")
+	for _, c := range releaseCtx.Changes.Breaking {
+		desc := c.Description
+		if c.BreakingDescription != "" {
+			desc = c.BreakingDescription
+		}
+		if c.Scope != "" {
+			desc = fmt.Sprintf("**%s:** %s", c.Scope, desc)
+		}
+		fmt.Fprintf(&b, "\n- %s", desc)
+	}
+	return b.String()
+}
+
+func contributorsSection(releaseCtx plugin.ReleaseContext, linked []*Issue) string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if c := releaseCtx.Changes; c != nil {
+		for _, group := range [][]plugin.ConventionalCommit{c.Features, c.Fixes, c.Breaking, c.Performance, c.Refactor, c.Docs, c.Other} {
+			for _, commit := range group {
+				add(commit.Author)
+			}
+		}
+	}
+	for _, issue := range linked {
+		if issue.Assignee != nil {
+			add(issue.Assignee.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return "### Contributors\n\n" + strings.Join(names, ", ")
+}
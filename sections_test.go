@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderSections(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{
+		Version: "2.0.0",
+		TagName: "v2.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "add export", Author: "Carol"}},
+			Breaking: []plugin.ConventionalCommit{{Description: "drop v1 API", Scope: "api", BreakingDescription: "v1 endpoints removed", Author: "Dave"}},
+		},
+	}
+	linked := []*Issue{
+		{Identifier: "ENG-1", Title: "Export", Estimate: 3, Project: &Project{Name: "Exports"}, Assignee: &User{Name: "Alice"}},
+		{Identifier: "ENG-2", Title: "Cleanup", Estimate: 1},
+	}
+
+	got := renderSections([]string{sectionSummary, sectionStats, sectionIssuesByProject, sectionBreaking, sectionContributors}, releaseCtx, linked)
+
+	for _, want := range []string{
+		"## Release 2.0.0",
+		"| 1 | 0 | 1 | 0 | 2 | 4 |",
+		"**Exports**\n\n- ENG-1 Export",
+		"**No project**\n\n- ENG-2 Cleanup",
+		"- **api:** v1 endpoints removed",
+		"### Contributors\n\nAlice, Carol, Dave",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderSections() missing %q in:\n%s", want, got)
+		}
+	}
+
+	if strings.Index(got, "## Release") > strings.Index(got, "### Stats") {
+		t.Error("expected sections in configured order")
+	}
+}
+
+func TestRenderSectionsSkipsEmpty(t *testing.T) {
+	got := renderSections([]string{sectionBreaking, sectionContributors}, plugin.ReleaseContext{}, nil)
+	if got != "" {
+		t.Errorf("expected empty description, got %q", got)
+	}
+}
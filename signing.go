@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// Supported request signature algorithms.
+const (
+	signingSHA256 = "sha256"
+	signingSHA512 = "sha512"
+)
+
+// reservedHeaders are set by the client and cannot be configured.
+var reservedHeaders = []string{"Authorization", "Content-Type"}
+
+// RequestSigningConfig adds static headers and an HMAC signature to every
+// API request, for organizations routing Linear traffic through a signing
+// gateway.
+type RequestSigningConfig struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	HMAC    HMACConfig        `json:"hmac"`
+}
+
+// HMACConfig controls the request signature: an HMAC of the request body,
+// prefixed with the timestamp and a newline when TimestampHeader is set,
+// sent hex encoded in Header.
+type HMACConfig struct {
+	Header          string `json:"header"`
+	Algorithm       string `json:"algorithm"`
+	TimestampHeader string `json:"timestamp_header,omitempty"`
+	Secret          string `json:"secret,omitempty"`
+	// SecretSource reads the secret from an env, file or exec provider,
+	// as the credentials block does, instead of Secret.
+	SecretSource *CredentialsConfig `json:"secret_source,omitempty"`
+}
+
+// parseRequestSigningConfig parses the request_signing block. The secret
+// defaults to LINEAR_SIGNING_SECRET.
+func parseRequestSigningConfig(raw map[string]any) RequestSigningConfig {
+	parser := helpers.NewConfigParser(raw)
+	cfg := RequestSigningConfig{}
+	for name, value := range parser.GetMap("headers") {
+		if cfg.Headers == nil {
+			cfg.Headers = make(map[string]string)
+		}
+		cfg.Headers[name] = fmt.Sprint(value)
+	}
+
+	hmacRaw := parser.GetMap("hmac")
+	hp := helpers.NewConfigParser(hmacRaw)
+	cfg.HMAC = HMACConfig{
+		Header:          hp.GetString("header", "", "X-Signature"),
+		Algorithm:       strings.ToLower(hp.GetString("algorithm", "", signingSHA256)),
+		TimestampHeader: hp.GetString("timestamp_header", "", ""),
+		Secret:          hp.GetString("secret", "LINEAR_SIGNING_SECRET", ""),
+	}
+	if source, ok := hmacRaw["secret_source"].(map[string]any); ok {
+		sc := parseCredentialsConfig(source)
+		if _, ok := source["env"]; !ok {
+			sc.Env = "LINEAR_SIGNING_SECRET"
+		}
+		cfg.HMAC.SecretSource = &sc
+	}
+	return cfg
+}
+
+// enabled reports whether requests are signed.
+func (h HMACConfig) enabled() bool {
+	return h.Secret != "" || h.SecretSource != nil
+}
+
+// validate reports invalid request signing settings.
+func (c RequestSigningConfig) validate(vb *helpers.ValidationBuilder) {
+	for name := range c.Headers {
+		if slices.ContainsFunc(reservedHeaders, func(h string) bool { return strings.EqualFold(h, name) }) {
+			vb.AddError("request_signing.headers", fmt.Sprintf("Header %s is set by the plugin and cannot be configured", name))
+		}
+	}
+	if !c.HMAC.enabled() {
+		return
+	}
+	if c.HMAC.Header == "" {
+		vb.AddError("request_signing.hmac.header", "The signature header name must not be empty")
+	}
+	if c.HMAC.Algorithm != signingSHA256 && c.HMAC.Algorithm != signingSHA512 {
+		vb.AddError("request_signing.hmac.algorithm", fmt.Sprintf("Unknown algorithm %q (must be sha256 or sha512)", c.HMAC.Algorithm))
+	}
+	if s := c.HMAC.SecretSource; s != nil {
+		switch {
+		case s.Provider != credentialEnv && s.Provider != credentialFile && s.Provider != credentialExec:
+			vb.AddError("request_signing.hmac.secret_source.provider", fmt.Sprintf("Unknown secret provider %q (must be one of env, file, exec)", s.Provider))
+		case s.Provider == credentialFile && s.File == "":
+			vb.AddError("request_signing.hmac.secret_source.file", "The file provider requires a file")
+		case s.Provider == credentialExec && len(s.Command) == 0:
+			vb.AddError("request_signing.hmac.secret_source.command", "The exec provider requires a command")
+		}
+	}
+}
+
+// signer returns the request signer of the configuration, or nil when
+// requests are sent as they are.
+func (c RequestSigningConfig) signer() *requestSigner {
+	if len(c.Headers) == 0 && !c.HMAC.enabled() {
+		return nil
+	}
+	s := &requestSigner{headers: c.Headers}
+	if c.HMAC.enabled() {
+		s.header, s.timestampHeader = c.HMAC.Header, c.HMAC.TimestampHeader
+		s.newHash = sha256.New
+		if c.HMAC.Algorithm == signingSHA512 {
+			s.newHash = sha512.New
+		}
+		s.secret = staticCredentials(c.HMAC.Secret)
+		if c.HMAC.SecretSource != nil {
+			s.secret = c.HMAC.SecretSource.provider("")
+		}
+	}
+	return s
+}
+
+// requestSigner adds the configured headers and signature to requests.
+type requestSigner struct {
+	headers map[string]string
+
+	// header is the signature header; empty when requests are not signed.
+	header          string
+	timestampHeader string
+	newHash         func() hash.Hash
+	secret          CredentialProvider
+}
+
+// ID identifies the signer's settings, so clients are only shared by
+// configurations signing alike. A static secret is hashed rather than
+// kept in the ID.
+func (s *requestSigner) ID() string {
+	if s == nil {
+		return ""
+	}
+	names := make([]string, 0, len(s.headers))
+	for name := range s.headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s: %s\n", name, s.headers[name])
+	}
+	if s.header != "" {
+		fmt.Fprintf(h, "%s %s %x %s\n", s.header, s.timestampHeader, s.newHash().Size(), s.secret.ID())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sign sets the configured headers on req and signs body.
+func (s *requestSigner) sign(ctx context.Context, req *http.Request, body []byte) error {
+	for name, value := range s.headers {
+		req.Header.Set(name, value)
+	}
+	if s.header == "" {
+		return nil
+	}
+
+	secret, err := s.secret.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read signing secret: %w", err)
+	}
+	mac := hmac.New(s.newHash, []byte(secret))
+	if s.timestampHeader != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(s.timestampHeader, timestamp)
+		mac.Write([]byte(timestamp + "\n"))
+	}
+	mac.Write(body)
+	req.Header.Set(s.header, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+func TestSignedRequests(t *testing.T) {
+	type seen struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan seen, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- seen{header: r.Header.Clone(), body: body}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	cfg := (&LinearPlugin{}).parseConfig(map[string]any{
+		"request_signing": map[string]any{
+			"headers": map[string]any{"X-Gateway-Tenant": "acme"},
+			"hmac": map[string]any{
+				"header":           "X-Gateway-Signature",
+				"timestamp_header": "X-Gateway-Timestamp",
+				"secret":           "s3cret",
+			},
+		},
+	})
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient, signer: cfg.RequestSigning.signer()}
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+
+	req := <-requests
+	if req.header.Get("X-Gateway-Tenant") != "acme" {
+		t.Errorf("static header missing: %v", req.header)
+	}
+	if req.header.Get("Authorization") != "lin_api_test" {
+		t.Errorf("Authorization = %q", req.header.Get("Authorization"))
+	}
+	timestamp := req.header.Get("X-Gateway-Timestamp")
+	if timestamp == "" {
+		t.Fatal("expected a timestamp header")
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(timestamp + "\n"))
+	mac.Write(req.body)
+	if got, want := req.header.Get("X-Gateway-Signature"), hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func TestRequestSignerSecretSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := parseRequestSigningConfig(map[string]any{
+		"hmac": map[string]any{
+			"algorithm":     "SHA512",
+			"secret_source": map[string]any{"provider": "file", "file": file},
+		},
+	})
+	signer := cfg.signer()
+	if signer == nil {
+		t.Fatal("expected a signer")
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	if err := signer.sign(context.Background(), req, []byte("{}")); err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+	got := req.Header.Get("X-Signature")
+	if len(got) != 128 {
+		t.Errorf("expected a hex sha512 signature in the default header, got %q", got)
+	}
+
+	_ = os.Remove(file)
+	if err := signer.sign(context.Background(), req, []byte("{}")); err == nil {
+		t.Error("expected an error when the secret cannot be read")
+	}
+}
+
+func TestRequestSignerID(t *testing.T) {
+	t.Setenv("LINEAR_SIGNING_SECRET", "")
+	if s := parseRequestSigningConfig(nil).signer(); s != nil || s.ID() != "" {
+		t.Errorf("expected no signer without settings, got %+v", s)
+	}
+
+	a := parseRequestSigningConfig(map[string]any{"hmac": map[string]any{"secret": "one"}}).signer()
+	b := parseRequestSigningConfig(map[string]any{"hmac": map[string]any{"secret": "two"}}).signer()
+	c := parseRequestSigningConfig(map[string]any{"headers": map[string]any{"X-Tenant": "acme"}}).signer()
+	if a.ID() == b.ID() || a.ID() == c.ID() {
+		t.Error("expected different settings to yield different IDs")
+	}
+	if strings.Contains(a.ID(), "one") {
+		t.Error("the ID must not contain the secret")
+	}
+}
+
+func TestValidateRequestSigning(t *testing.T) {
+	vb := helpers.NewValidationBuilder()
+	parseRequestSigningConfig(map[string]any{
+		"headers": map[string]any{"authorization": "Bearer x"},
+		"hmac": map[string]any{
+			"secret":        "s3cret",
+			"algorithm":     "md5",
+			"secret_source": map[string]any{"provider": "oauth"},
+		},
+	}).validate(vb)
+
+	fields := make(map[string]bool)
+	for _, e := range vb.Build().Errors {
+		fields[e.Field] = true
+	}
+	for _, f := range []string{"request_signing.headers", "request_signing.hmac.algorithm", "request_signing.hmac.secret_source.provider"} {
+		if !fields[f] {
+			t.Errorf("expected an error for %s, got %v", f, fields)
+		}
+	}
+}
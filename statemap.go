@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Commit categories of the state map, in order of precedence: an issue
+// referenced by a breaking change and a fix moves to the breaking state.
+const (
+	categoryBreaking = "breaking"
+	categoryFeatures = "features"
+	categoryFixes    = "fixes"
+	categoryOther    = "other"
+)
+
+// stateMapCategories lists the categories in order of precedence.
+var stateMapCategories = []string{categoryBreaking, categoryFeatures, categoryFixes, categoryOther}
+
+// parseStateMap parses the state_map block, which maps commit categories
+// to the state their issues move to instead of released_state.
+func parseStateMap(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	states := make(map[string]string, len(raw))
+	for category, v := range raw {
+		s, _ := v.(string)
+		states[strings.ToLower(category)] = strings.TrimSpace(s)
+	}
+	return states
+}
+
+// validateStateMap reports unknown categories and empty states.
+func validateStateMap(vb *helpers.ValidationBuilder, states map[string]string) {
+	for category, state := range states {
+		field := "state_map." + category
+		switch {
+		case !slices.Contains(stateMapCategories, category):
+			vb.AddError(field, fmt.Sprintf("Unknown commit category (must be one of %s)", strings.Join(stateMapCategories, ", ")))
+		case state == "":
+			vb.AddError(field, "State must not be empty")
+		}
+	}
+}
+
+// categoryCommits returns the commits of the release in a category.
+func categoryCommits(changes *plugin.CategorizedChanges, category string) []plugin.ConventionalCommit {
+	switch category {
+	case categoryBreaking:
+		return changes.Breaking
+	case categoryFeatures:
+		return changes.Features
+	case categoryFixes:
+		return changes.Fixes
+	default:
+		return changes.Other
+	}
+}
+
+// issueCategories returns the category of the commits referencing each
+// identifier. An issue referenced from several categories gets the one
+// that takes precedence.
+func issueCategories(cfg *Config, releaseCtx plugin.ReleaseContext) map[string]string {
+	categories := make(map[string]string)
+	if releaseCtx.Changes == nil {
+		return categories
+	}
+	pattern := cfg.issueRegexp()
+	for _, category := range stateMapCategories {
+		for _, c := range categoryCommits(releaseCtx.Changes, category) {
+			for _, id := range extractIssuesMatching(pattern, []string{c.Description}, cfg.IssuePrefix) {
+				if _, ok := categories[id]; !ok {
+					categories[id] = category
+				}
+			}
+		}
+	}
+	return categories
+}
+
+// resolveCategoryStates resolves the mapped states of a team plan from the
+// team's workflow, returning an error for each state the team lacks.
+func resolveCategoryStates(cfg *Config, tp *teamPlan, team *Team) []string {
+	var errs []string
+	for _, category := range stateMapCategories {
+		state, ok := cfg.StateMap[category]
+		if !ok {
+			continue
+		}
+		mapped := &teamPlan{teamID: tp.teamID, teamKey: tp.teamKey, state: state, projectID: tp.projectID}
+		mapped.stateID = resolveStateID(team.States, state)
+		if mapped.stateID == "" {
+			errs = append(errs, fmt.Sprintf("State '%s' for %s not found in the workflow of team %s", state, category, team.Key))
+		}
+		if tp.categories == nil {
+			tp.categories = make(map[string]*teamPlan)
+		}
+		tp.categories[category] = mapped
+	}
+	return errs
+}
+
+// stateTarget returns the plan of the state an issue moves to: the state
+// mapped to the category of the commits referencing it, or its team's
+// released state. It is nil if the issue's team is not configured.
+func (p *linkedIssuePlan) stateTarget(issue *Issue, extractedID string) *teamPlan {
+	tp := p.teamFor(issue)
+	if tp == nil {
+		return nil
+	}
+	if mapped, ok := tp.categories[p.categories[strings.ToUpper(extractedID)]]; ok {
+		return mapped
+	}
+	return tp
+}
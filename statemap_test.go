@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// categorizedRelease references ENG-1 from a feature, ENG-2 from a fix,
+// ENG-3 from a feature and a breaking change, and ENG-4 from a chore.
+var categorizedRelease = plugin.ReleaseContext{
+	Version: "2.0.0",
+	Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "add export ENG-1"}, {Description: "add import ENG-3"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "fix crash ENG-2"}},
+		Breaking: []plugin.ConventionalCommit{{Description: "drop the v1 API ENG-3"}},
+		Other:    []plugin.ConventionalCommit{{Description: "bump deps ENG-4"}},
+	},
+}
+
+func TestIssueCategories(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(nil)
+	got := issueCategories(cfg, categorizedRelease)
+	want := map[string]string{"ENG-1": categoryFeatures, "ENG-2": categoryFixes, "ENG-3": categoryBreaking, "ENG-4": categoryOther}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issueCategories() = %v, want %v", got, want)
+	}
+}
+
+func TestStateMapMovesIssuesByCategory(t *testing.T) {
+	issues := map[string]map[string]any{}
+	for _, id := range []string{"ENG-1", "ENG-2", "ENG-3", "ENG-4"} {
+		issues[id] = map[string]any{"id": "uuid-" + id[4:], "identifier": id, "state": map[string]any{"id": "state-review", "name": "In Review"}}
+	}
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue":          issueHandler(issues),
+		"UpdateIssueStates": batchStateHandler(nil),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"add_release_comment": false,
+		"released_state":      "Done",
+		"state_map": map[string]any{
+			"features": "Released",
+			"breaking": "Released (verify)",
+		},
+	})
+	team := &Team{Key: "ENG", States: []State{
+		{ID: "state-done", Name: "Done"},
+		{ID: "state-released", Name: "Released"},
+		{ID: "state-verify", Name: "Released (verify)"},
+	}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, categorizedRelease, team), []string{"ENG-1", "ENG-2", "ENG-3", "ENG-4"})
+	if len(res.Errors) != 0 {
+		t.Fatalf("unexpected errors %v", res.Errors)
+	}
+
+	moved := map[string]string{}
+	for _, call := range fake.calls["UpdateIssueStates"] {
+		state := call["input"].(map[string]any)["stateId"].(string)
+		for _, id := range call["ids"].([]any) {
+			moved[id.(string)] = state
+		}
+	}
+	want := map[string]string{"uuid-1": "state-released", "uuid-2": "state-done", "uuid-3": "state-verify", "uuid-4": "state-done"}
+	if !reflect.DeepEqual(moved, want) {
+		t.Errorf("moved = %v, want %v", moved, want)
+	}
+}
+
+func TestStateMapMissingState(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"state_map": map[string]any{"fixes": "Shipped"}})
+	team := &Team{Key: "ENG", States: []State{{ID: "state-done", Name: "Done"}}}
+
+	_, _, errs := resolveTeamPlans(context.Background(), nil, cfg, team)
+	if len(errs) != 1 || errs[0] != "State 'Shipped' for fixes not found in the workflow of team ENG" {
+		t.Errorf("errs = %v", errs)
+	}
+}
+
+func TestValidateStateMap(t *testing.T) {
+	vb := helpers.NewValidationBuilder()
+	validateStateMap(vb, parseStateMap(map[string]any{"Features": "Released", "chores": "Done", "fixes": ""}))
+	fields := map[string]bool{}
+	for _, e := range vb.Build().Errors {
+		fields[e.Field] = true
+	}
+	if len(fields) != 2 || !fields["state_map.chores"] || !fields["state_map.fixes"] {
+		t.Errorf("errors for %v", fields)
+	}
+}
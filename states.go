@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// resolveStateID returns the ID of the workflow state with the given name,
+// ignoring case, or "" if the team has no such state.
+func resolveStateID(states []State, name string) string {
+	for _, state := range states {
+		if strings.EqualFold(state.Name, name) {
+			return state.ID
+		}
+	}
+	return ""
+}
+
+// Workflow state types a missing released state can fall back to.
+var workflowStateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
+// noStateFallback turns the released state fallback off.
+const noStateFallback = "none"
+
+// resolveReleasedState returns the ID and name of the team's released
+// state: the state with the given name or, if the team has none, its first
+// state of the fallback type in workflow order. This lets one
+// configuration serve teams whose states are named differently. Without a
+// match the ID is "" and the name is the configured one.
+func resolveReleasedState(states []State, name, fallbackType string) (string, string) {
+	if id := resolveStateID(states, name); id != "" || fallbackType == "" || fallbackType == noStateFallback {
+		return id, name
+	}
+	var fallback *State
+	for i := range states {
+		if states[i].Type == fallbackType && (fallback == nil || states[i].Position < fallback.Position) {
+			fallback = &states[i]
+		}
+	}
+	if fallback == nil {
+		return "", name
+	}
+	return fallback.ID, fallback.Name
+}
+
+// stateFallbacks maps the keys of the teams whose released state fell back
+// to another state to that state, or returns nil if none did.
+func stateFallbacks(primary *teamPlan, teams map[string]*teamPlan, team *Team) map[string]string {
+	fallbacks := make(map[string]string)
+	if primary.fallback {
+		fallbacks[team.Key] = primary.state
+	}
+	for key, tp := range teams {
+		if tp.fallback {
+			fallbacks[key] = tp.state
+		}
+	}
+	if len(fallbacks) == 0 {
+		return nil
+	}
+	return fallbacks
+}
+
+// releasedState returns the primary team's released state as resolved,
+// or released_state if it was not resolved.
+func (r *linkedIssueResults) releasedState(cfg *Config) string {
+	if r.ReleasedState != "" {
+		return r.ReleasedState
+	}
+	return cfg.ReleasedState
+}
+
+// isInvalidStateError reports whether an update failed because the state ID
+// no longer exists, e.g. after the team's workflow was edited.
+func isInvalidStateError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, e := range apiErr.Errors {
+		msg := strings.ToLower(e.Message + " " + e.Extensions.UserPresentableMessage)
+		if !strings.Contains(msg, "state") {
+			continue
+		}
+		if strings.Contains(msg, "invalid") || strings.Contains(msg, "not found") ||
+			strings.Contains(msg, "does not exist") || strings.Contains(msg, "validation") {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshStateID re-reads the team's workflow states and re-resolves the
+// released state, for when the cached state ID was rejected.
+func refreshStateID(ctx context.Context, client *LinearClient, tp *teamPlan) (string, error) {
+	client.metadata.forgetTeam(tp.teamID, tp.teamKey)
+	team, err := client.GetTeam(ctx, tp.teamID, tp.teamKey)
+	if err != nil {
+		return "", err
+	}
+	return resolveStateID(team.States, tp.state), nil
+}
+
+// updateState moves the issue to the team's released state. If Linear
+// rejects the state ID, the team's states are refreshed and the update
+// retried once with the re-resolved ID, which later issues then use as well.
+func updateState(ctx context.Context, client *LinearClient, tp *teamPlan, issueID string) error {
+	err := client.UpdateIssueState(ctx, issueID, tp.stateID)
+	if err == nil || !isInvalidStateError(err) {
+		return err
+	}
+
+	stateID, refreshErr := refreshStateID(ctx, client, tp)
+	if refreshErr != nil || stateID == "" || stateID == tp.stateID {
+		return err
+	}
+	tp.stateID = stateID
+	return client.UpdateIssueState(ctx, issueID, stateID)
+}
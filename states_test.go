@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestIsInvalidStateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"invalid state", &APIError{Errors: []GraphQLError{{Message: "Argument Validation Error: stateId must be a valid workflow state"}}}, true},
+		{"state not found", &APIError{Errors: []GraphQLError{{Message: "Entity not found: WorkflowState"}}}, true},
+		{"other API error", &APIError{Errors: []GraphQLError{{Message: "Rate limited"}}}, false},
+		{"status error", &StatusError{StatusCode: 500}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInvalidStateError(tt.err); got != tt.want {
+				t.Errorf("isInvalidStateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessLinkedIssuesRefreshesStaleState(t *testing.T) {
+	var mu sync.Mutex
+	current := map[string]any{"id": "state-review", "name": "In Review"}
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			return map[string]any{"issue": map[string]any{"id": "uuid-1", "identifier": "ENG-1", "state": current}}
+		},
+		"GetTeams": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []map[string]any{{
+				"id": "team-1", "key": "ENG",
+				"states": map[string]any{"nodes": []map[string]any{{"id": "state-done-new", "name": "Done"}}},
+			}}}}
+		},
+		"UpdateIssueState": func(vars map[string]any) any {
+			input := vars["input"].(map[string]any)
+			if input["stateId"] != "state-done-new" {
+				return fakeErrors{{"message": "Entity not found: WorkflowState"}}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			current = map[string]any{"id": "state-done-new", "name": "Done"}
+			return map[string]any{"issueUpdate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"team_key": "ENG", "add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done-old", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1"})
+
+	if res.Updated != 1 || len(res.Errors) != 0 {
+		t.Errorf("expected the update to succeed after refreshing states, got %d updated, errors %v", res.Updated, res.Errors)
+	}
+	if fake.callCount("UpdateIssueState") != 2 {
+		t.Errorf("expected exactly one retry, got %d calls", fake.callCount("UpdateIssueState"))
+	}
+}
+
+func TestResolveReleasedStateFallback(t *testing.T) {
+	states := []State{
+		{ID: "state-progress", Name: "In Progress", Type: "started", Position: 1},
+		{ID: "state-shipped", Name: "Shipped", Type: "completed", Position: 3},
+		{ID: "state-closed", Name: "Closed", Type: "completed", Position: 2},
+		{ID: "state-canceled", Name: "Canceled", Type: "canceled", Position: 4},
+	}
+	tests := []struct {
+		name, fallback     string
+		wantID, wantResult string
+	}{
+		{"shipped", "completed", "state-shipped", "shipped"},
+		{"Done", "completed", "state-closed", "Closed"},
+		{"Done", "canceled", "state-canceled", "Canceled"},
+		{"Done", "none", "", "Done"},
+		{"Done", "triage", "", "Done"},
+	}
+	for _, tt := range tests {
+		id, name := resolveReleasedState(states, tt.name, tt.fallback)
+		if id != tt.wantID || name != tt.wantResult {
+			t.Errorf("resolveReleasedState(%q, %q) = %q, %q; want %q, %q", tt.name, tt.fallback, id, name, tt.wantID, tt.wantResult)
+		}
+	}
+}
+
+func TestResolveTeamPlansFallsBackToCompletedState(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"released_state": "Done"})
+	team := &Team{Key: "ENG", States: []State{{ID: "state-released", Name: "Released", Type: "completed"}}}
+
+	primary, teams, errs := resolveTeamPlans(context.Background(), nil, cfg, team)
+	if len(errs) != 0 || primary.stateID != "state-released" || primary.state != "Released" {
+		t.Fatalf("primary = %+v, errs = %v", primary, errs)
+	}
+	if got := stateFallbacks(primary, teams, team); len(got) != 1 || got["ENG"] != "Released" {
+		t.Errorf("stateFallbacks() = %v", got)
+	}
+
+	cfg = p.parseConfig(map[string]any{"released_state": "Done", "released_state_fallback": "none"})
+	primary, _, errs = resolveTeamPlans(context.Background(), nil, cfg, team)
+	if len(errs) != 1 || primary.stateID != "" {
+		t.Errorf("expected the missing state to be reported without a fallback, got %+v, %v", primary, errs)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// TeamConfig is one entry of the teams list, for releases whose commits
+// reference issues of several teams. Key is the team's issue prefix.
+type TeamConfig struct {
+	ID            string `json:"id,omitempty"`
+	Key           string `json:"key"`
+	ReleasedState string `json:"released_state,omitempty"`
+	ProjectID     string `json:"project_id,omitempty"`
+}
+
+// parseTeamConfigs parses the teams list.
+func parseTeamConfigs(raw map[string]any) []TeamConfig {
+	list, _ := raw["teams"].([]any)
+	teams := make([]TeamConfig, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		t := TeamConfig{}
+		t.ID, _ = m["id"].(string)
+		t.Key, _ = m["key"].(string)
+		t.ReleasedState, _ = m["released_state"].(string)
+		t.ProjectID, _ = m["project_id"].(string)
+		teams = append(teams, t)
+	}
+	return teams
+}
+
+// teamPlan holds where one team's linked issues are moved.
+type teamPlan struct {
+	teamID    string
+	teamKey   string
+	state     string
+	stateID   string
+	projectID string
+	// fallback reports that the configured released state was missing and
+	// state is the team's first state of the fallback type instead.
+	fallback bool
+	// categories holds the plans of the states mapped to commit
+	// categories by state_map.
+	categories map[string]*teamPlan
+}
+
+// issueKey returns the team key of an issue, preferring the team Linear
+// reports over the identifier prefix.
+func issueKey(issue *Issue) string {
+	if issue.Team != nil && issue.Team.Key != "" {
+		return strings.ToUpper(issue.Team.Key)
+	}
+	key, _, _ := strings.Cut(issue.Identifier, "-")
+	return strings.ToUpper(key)
+}
+
+// compareIssueIDs orders identifiers by team key, then by number, so
+// ENG-9 sorts before ENG-10.
+func compareIssueIDs(a, b string) int {
+	keyA, numA, _ := strings.Cut(a, "-")
+	keyB, numB, _ := strings.Cut(b, "-")
+	if c := strings.Compare(strings.ToUpper(keyA), strings.ToUpper(keyB)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(numA), len(numB)); c != 0 {
+		return c
+	}
+	return strings.Compare(numA, numB)
+}
+
+// sortedIssueIDs returns a sorted copy of identifiers, so re-runs process
+// and report issues in the same order whatever the commit order.
+func sortedIssueIDs(ids []string) []string {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, compareIssueIDs)
+	return sorted
+}
+
+// linkedIssueIDs extracts the issues referenced by the release's commits,
+// and with branch_issues by its branch names, that pass the extraction
+// filters, sorted by team and number.
+func linkedIssueIDs(cfg *Config, releaseCtx plugin.ReleaseContext) []string {
+	pattern := cfg.issueRegexp()
+	ids := extractIssuesMatching(pattern, commitMessages(releaseCtx), "")
+	if cfg.BranchIssues {
+		for _, id := range branchIssues(pattern, branchNames(releaseCtx)) {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	var issues []string
+	for _, id := range ids {
+		if issueFilterReason(cfg, id) == "" {
+			issues = append(issues, id)
+		}
+	}
+	return sortedIssueIDs(issues)
+}
+
+// Reasons an extracted identifier is not linked.
+const (
+	filteredPrefix     = "issue_prefix"
+	filteredDenylist   = "denylist"
+	filteredTeams      = "teams"
+	filteredUnknownKey = "learn_prefixes"
+)
+
+// issueFilterReason returns the option that keeps an extracted identifier
+// from being linked, or "" if it passes: it must carry issue_prefix, not be
+// denylisted, belong to one of several configured teams and have a learned
+// team key.
+func issueFilterReason(cfg *Config, id string) string {
+	key, _, _ := strings.Cut(id, "-")
+	switch {
+	case cfg.IssuePrefix != "" && !strings.EqualFold(key, cfg.IssuePrefix):
+		return filteredPrefix
+	case denied(cfg, id):
+		return filteredDenylist
+	case len(cfg.Teams) > 0 && !slices.ContainsFunc(cfg.Teams, func(t TeamConfig) bool { return strings.EqualFold(t.Key, key) }):
+		return filteredTeams
+	case len(cfg.KnownPrefixes) > 0 && !slices.ContainsFunc(cfg.KnownPrefixes, func(k string) bool { return strings.EqualFold(k, key) }):
+		return filteredUnknownKey
+	}
+	return ""
+}
+
+// resolveTeamPlans resolves the released state of the primary team and,
+// when several teams are configured, of each of them. States missing from
+// a team's workflow are reported as errors.
+func resolveTeamPlans(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, team *Team) (*teamPlan, map[string]*teamPlan, []string) {
+	var errs []string
+	primary := &teamPlan{teamID: cfg.TeamID, teamKey: cfg.TeamKey, state: cfg.ReleasedState, projectID: cfg.ProjectID}
+	if cfg.UpdateLinkedIssues && cfg.ReleasedState != "" {
+		primary.stateID, primary.state = resolveReleasedState(team.States, cfg.ReleasedState, cfg.ReleasedStateFallback)
+		primary.fallback = primary.state != cfg.ReleasedState
+		if primary.stateID == "" {
+			errs = append(errs, fmt.Sprintf("State '%s' not found in team workflow", cfg.ReleasedState))
+		}
+	}
+	if cfg.UpdateLinkedIssues {
+		errs = append(errs, resolveCategoryStates(cfg, primary, team)...)
+	}
+	if len(cfg.Teams) == 0 {
+		return primary, nil, errs
+	}
+	teams, teamErrs := planTeams(ctx, client, cfg, team)
+	return primary, teams, append(errs, teamErrs...)
+}
+
+// planTeams resolves the released state of every configured team, keyed by
+// team key. The primary team is not fetched again.
+func planTeams(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, primary *Team) (map[string]*teamPlan, []string) {
+	plans := make(map[string]*teamPlan, len(cfg.Teams))
+	var errs []string
+	for _, tc := range cfg.Teams {
+		team := primary
+		if !strings.EqualFold(tc.Key, primary.Key) && (tc.ID == "" || tc.ID != primary.ID) {
+			var err error
+			team, err = client.GetTeam(ctx, tc.ID, tc.Key)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Failed to get team %s: %v", tc.Key, err))
+				continue
+			}
+		}
+
+		tp := &teamPlan{teamID: team.ID, teamKey: tc.Key, state: tc.ReleasedState, projectID: tc.ProjectID}
+		if tp.state == "" {
+			tp.state = cfg.ReleasedState
+		}
+		if cfg.UpdateLinkedIssues && tp.state != "" {
+			configured := tp.state
+			tp.stateID, tp.state = resolveReleasedState(team.States, configured, cfg.ReleasedStateFallback)
+			tp.fallback = tp.state != configured
+			if tp.stateID == "" {
+				errs = append(errs, fmt.Sprintf("State '%s' not found in the workflow of team %s", tp.state, tc.Key))
+			}
+		}
+		if cfg.UpdateLinkedIssues {
+			errs = append(errs, resolveCategoryStates(cfg, tp, team)...)
+		}
+		plans[strings.ToUpper(tc.Key)] = tp
+	}
+	return plans, errs
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseConfigTeams(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"teams": []any{
+			map[string]any{"key": "ENG"},
+			map[string]any{"key": "OPS", "released_state": "Deployed", "project_id": "project-ops"},
+		},
+	})
+
+	want := []TeamConfig{{Key: "ENG"}, {Key: "OPS", ReleasedState: "Deployed", ProjectID: "project-ops"}}
+	if !reflect.DeepEqual(cfg.Teams, want) {
+		t.Errorf("Teams = %+v, want %+v", cfg.Teams, want)
+	}
+	if cfg.TeamKey != "ENG" || cfg.IssuePrefix != "" {
+		t.Errorf("expected ENG as primary team without a single issue prefix, got %q / %q", cfg.TeamKey, cfg.IssuePrefix)
+	}
+}
+
+func TestLinkedIssueIDsRestrictedToTeams(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"teams": []any{map[string]any{"key": "ENG"}, map[string]any{"key": "OPS"}},
+	})
+	releaseCtx := plugin.ReleaseContext{Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "ENG-1 and OPS-2 use ISO-8601 dates"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "DEV-3 fix"}},
+	}}
+
+	if got := linkedIssueIDs(cfg, releaseCtx); !reflect.DeepEqual(got, []string{"ENG-1", "OPS-2"}) {
+		t.Errorf("linkedIssueIDs() = %v", got)
+	}
+}
+
+func TestSortedIssueIDs(t *testing.T) {
+	ids := []string{"OPS-2", "ENG-10", "eng-9", "ENG-100", "ENG-1"}
+
+	want := []string{"ENG-1", "eng-9", "ENG-10", "ENG-100", "OPS-2"}
+	if got := sortedIssueIDs(ids); !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedIssueIDs() = %v, want %v", got, want)
+	}
+	if ids[0] != "OPS-2" {
+		t.Errorf("sortedIssueIDs() modified its input: %v", ids)
+	}
+}
+
+func TestLinkedIssueIDsSorted(t *testing.T) {
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"teams": []any{map[string]any{"key": "ENG"}, map[string]any{"key": "OPS"}},
+	})
+	releaseCtx := plugin.ReleaseContext{Changes: &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "OPS-2 rotate keys"}, {Description: "ENG-12 add export"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "ENG-3 fix import"}},
+	}}
+
+	if got := linkedIssueIDs(cfg, releaseCtx); !reflect.DeepEqual(got, []string{"ENG-3", "ENG-12", "OPS-2"}) {
+		t.Errorf("linkedIssueIDs() = %v", got)
+	}
+}
+
+func TestProcessLinkedIssuesSorted(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-2":  {"id": "uuid-2", "identifier": "ENG-2"},
+			"ENG-10": {"id": "uuid-10", "identifier": "ENG-10"},
+			"OPS-1":  {"id": "uuid-ops", "identifier": "OPS-1"},
+		}),
+		"AddComment": successHandler("commentCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"update_linked_issues": false})
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{}), []string{"OPS-1", "ENG-10", "ENG-2"})
+
+	var shipped []string
+	for _, issue := range res.Shipped {
+		shipped = append(shipped, issue.Identifier)
+	}
+	if want := []string{"ENG-2", "ENG-10", "OPS-1"}; !reflect.DeepEqual(shipped, want) {
+		t.Errorf("shipped in order %v, want %v", shipped, want)
+	}
+}
+
+func TestProcessLinkedIssuesPerTeamStates(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeams": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []any{map[string]any{
+				"id": "team-ops", "key": "OPS",
+				"states": map[string]any{"nodes": []any{map[string]any{"id": "ops-deployed", "name": "Deployed"}}},
+			}}}}
+		},
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "team": map[string]any{"id": "team-eng", "key": "ENG"}},
+			"OPS-2": {"id": "uuid-2", "identifier": "OPS-2", "team": map[string]any{"id": "team-ops", "key": "OPS"}},
+		}),
+		"UpdateIssueState": successHandler("issueUpdate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"add_release_comment": false,
+		"teams": []any{
+			map[string]any{"key": "ENG"},
+			map[string]any{"key": "OPS", "released_state": "Deployed"},
+		},
+	})
+	team := &Team{ID: "team-eng", Key: "ENG", States: []State{{ID: "eng-done", Name: "Done"}}}
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1", "OPS-2"})
+
+	got := make(map[string]string)
+	for _, vars := range fake.calls["UpdateIssueState"] {
+		got[vars["id"].(string)] = vars["input"].(map[string]any)["stateId"].(string)
+	}
+	want := map[string]string{"uuid-1": "eng-done", "uuid-2": "ops-deployed"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("state updates = %v, want %v (errors %v)", got, want, res.Errors)
+	}
+}
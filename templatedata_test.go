@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestTemplateDataFromRelease(t *testing.T) {
+	ctx := plugin.ReleaseContext{
+		Version:         "2.0.0",
+		PreviousVersion: "1.4.0",
+		RepositoryURL:   "https://github.com/acme/app",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Type: "feat", Description: "add export"}, {Type: "feat", Description: "add import"}},
+			Fixes:    []plugin.ConventionalCommit{{Type: "fix", Description: "fix crash"}},
+			Breaking: []plugin.ConventionalCommit{{Type: "feat", Description: "drop v1 API", Breaking: true}},
+		},
+	}
+
+	tmpl := `{{.PreviousVersion}}..{{.Version}} {{.RepositoryURL}} ` +
+		`{{.FeatureCount}}/{{.FixCount}}/{{.BreakingCount}}{{range .Features}} {{.Description}}{{end}}`
+	got, err := renderTemplate(tmpl, ctx, nil)
+	if err != nil {
+		t.Fatalf("renderTemplate() error = %v", err)
+	}
+	want := "1.4.0..2.0.0 https://github.com/acme/app 2/1/1 add export add import"
+	if got != want {
+		t.Errorf("renderTemplate() = %q, want %q", got, want)
+	}
+
+	// Without categorized changes, the lists are empty
+	got, err = renderTemplate(`{{.FeatureCount}}{{range .Fixes}}x{{end}}`, plugin.ReleaseContext{}, nil)
+	if err != nil || got != "0" {
+		t.Errorf("renderTemplate() without changes = %q, %v", got, err)
+	}
+}
+
+func TestRunTemplateDataNames(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetProject": func(map[string]any) any {
+			return map[string]any{"project": map[string]any{"id": "proj-1", "name": "Apollo"}}
+		},
+	})
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"project_id": "proj-1"})
+	run := fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, &Team{ID: "team-1", Name: "Engineering"})
+
+	got, err := run.render(context.Background(), "{{.Team}}")
+	if err != nil || got != "Engineering" {
+		t.Errorf("render() = %q, %v", got, err)
+	}
+	if fake.callCount("GetProject") != 0 {
+		t.Error("expected no project lookup for a template without .Project")
+	}
+
+	for range 2 {
+		got, err = run.render(context.Background(), "{{.Project}} {{.Version}}")
+		if err != nil || got != "Apollo 1.0.0" {
+			t.Errorf("render() = %q, %v", got, err)
+		}
+	}
+	if n := fake.callCount("GetProject"); n != 1 {
+		t.Errorf("expected the project to be looked up once, got %d", n)
+	}
+}
+
+func TestRunTemplateDataProjectLookupFailure(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetProject": func(map[string]any) any { return map[string]any{"project": nil} },
+	})
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"project_id": "proj-1"})
+	run := fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, nil)
+
+	got, err := run.render(context.Background(), "{{.Project}}|{{.Team}}")
+	if err != nil || got != "|" {
+		t.Errorf("render() = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFuncs are the functions available in templates. Names and
+// argument order follow Sprig, so the value comes last and can be piped:
+// {{.ReleaseNotes | trunc 200 | escapeMarkdown}}.
+var templateFuncs = template.FuncMap{
+	"upper":          strings.ToUpper,
+	"lower":          strings.ToLower,
+	"trim":           strings.TrimSpace,
+	"replace":        replaceAll,
+	"trunc":          trunc,
+	"default":        defaultValue,
+	"join":           join,
+	"splitList":      splitList,
+	"date":           formatDate,
+	"now":            time.Now,
+	"escapeMarkdown": escapeMarkdown,
+}
+
+// replaceAll replaces every old in s with replacement.
+func replaceAll(old, replacement, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+// trunc shortens s to n runes; a negative n keeps the last -n runes.
+func trunc(n int, s string) string {
+	runes := []rune(s)
+	switch {
+	case n >= 0 && len(runes) > n:
+		return string(runes[:n])
+	case n < 0 && len(runes) > -n:
+		return string(runes[len(runes)+n:])
+	}
+	return s
+}
+
+// defaultValue returns def when value is empty: nil, zero, or an empty
+// string, slice or map.
+func defaultValue(def, value any) any {
+	if value == nil {
+		return def
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	default:
+		if v.IsZero() {
+			return def
+		}
+	}
+	return value
+}
+
+// join joins the elements of a list, formatted as with fmt.Sprint, with
+// sep. A string is returned as it is.
+func join(sep string, list any) (string, error) {
+	switch l := list.(type) {
+	case string:
+		return l, nil
+	case []string:
+		return strings.Join(l, sep), nil
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: cannot join %T", list)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// splitList splits s around sep.
+func splitList(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// formatDate formats a time, or a date string such as {{.Date}} in
+// YYYY-MM-DD or RFC 3339 form, with a Go layout.
+func formatDate(layout string, value any) (string, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout), nil
+	case string:
+		for _, in := range []string{time.DateOnly, time.RFC3339} {
+			if t, err := time.Parse(in, v); err == nil {
+				return t.Format(layout), nil
+			}
+		}
+		return "", fmt.Errorf("date: cannot parse %q", v)
+	}
+	return "", fmt.Errorf("date: cannot format %T", value)
+}
+
+// markdownEscaper backslash-escapes the characters Markdown interprets.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `{`, `\{`, `}`, `\}`,
+	`[`, `\[`, `]`, `\]`, `(`, `\(`, `)`, `\)`, `#`, `\#`, `+`, `\+`,
+	`-`, `\-`, `.`, `\.`, `!`, `\!`, `|`, `\|`, `<`, `\<`, `>`, `\>`, `~`, `\~`,
+)
+
+// escapeMarkdown escapes s so Linear renders it literally.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	data := templateData{
+		Version:      "1.2.0",
+		Branch:       "release/1.2",
+		ReleaseType:  "minor",
+		ReleaseNotes: "Fix *bold* crash in [parser]",
+		Date:         "2024-03-05",
+	}
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{.ReleaseType | upper}}`, "MINOR"},
+		{`{{lower "RC"}}`, "rc"},
+		{`{{.ReleaseNotes | trunc 9}}`, "Fix *bold"},
+		{`{{.ReleaseNotes | trunc -8}}`, "[parser]"},
+		{`{{.Version | trunc 10}}`, "1.2.0"},
+		{`{{.CommitSHA | default "unknown"}}`, "unknown"},
+		{`{{.Branch | default "main"}}`, "release/1.2"},
+		{`{{.Branch | splitList "/" | join ", "}}`, "release, 1.2"},
+		{`{{.Date | date "Jan 2, 2006"}}`, "Mar 5, 2024"},
+		{`{{.ReleaseNotes | escapeMarkdown}}`, `Fix \*bold\* crash in \[parser\]`},
+		{`{{.Branch | replace "/" "-"}}`, "release-1.2"},
+		{`{{"  padded " | trim}}`, "padded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			got, err := renderTemplateData(tt.tmpl, data, nil)
+			if err != nil {
+				t.Fatalf("render error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncsInPartials(t *testing.T) {
+	partials := map[string]string{"heading": `## {{.Version | upper}}`}
+	got, err := renderTemplateData(`{{template "heading" .}}`, templateData{Version: "v1-beta"}, partials)
+	if err != nil || got != "## V1-BETA" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestTemplateFuncErrors(t *testing.T) {
+	for _, tmpl := range []string{`{{.Date | date "2006"}}`, `{{join ", " 3}}`} {
+		if _, err := renderTemplateData(tmpl, templateData{Date: "yesterday"}, nil); err == nil {
+			t.Errorf("%s: expected an error", tmpl)
+		} else if !strings.Contains(err.Error(), "date") && !strings.Contains(err.Error(), "join") {
+			t.Errorf("%s: unexpected error %v", tmpl, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validationCodeTokenInfo marks the validation entry that reports the token
+// metadata. It is informational and does not make the config invalid.
+const validationCodeTokenInfo = "token_info"
+
+// tokenInfo describes who an API key authenticates as. Linear does not
+// report scopes or expiry for personal API keys, so only the actor and
+// organization are known.
+type tokenInfo struct {
+	Actor        string `json:"actor"`
+	Email        string `json:"email,omitempty"`
+	Organization string `json:"organization,omitempty"`
+	URLKey       string `json:"url_key,omitempty"`
+}
+
+// newTokenInfo extracts the token metadata from the viewer.
+func newTokenInfo(viewer *Viewer) tokenInfo {
+	info := tokenInfo{Actor: viewer.Name, Email: viewer.Email}
+	if org := viewer.Organization; org != nil {
+		info.Organization, info.URLKey = org.Name, org.URLKey
+	}
+	return info
+}
+
+// String formats the token metadata, e.g. "Jane Doe <jane@acme.com> in
+// Acme (acme)".
+func (t tokenInfo) String() string {
+	s := t.Actor
+	if t.Email != "" {
+		s += " <" + t.Email + ">"
+	}
+	if t.Organization != "" {
+		s += " in " + t.Organization
+		if t.URLKey != "" {
+			s += " (" + t.URLKey + ")"
+		}
+	}
+	return s
+}
+
+// checkTeamAccess verifies that the configured team exists in the
+// organization the API key belongs to, catching keys for the wrong
+// workspace.
+func checkTeamAccess(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config, info tokenInfo) error {
+	if cfg.TeamID == "" && cfg.TeamKey == "" {
+		return nil
+	}
+	team, err := client.GetTeam(ctx, cfg.TeamID, cfg.TeamKey)
+	if err == nil && team.ID != "" {
+		return nil
+	}
+
+	name := cfg.TeamKey
+	if name == "" {
+		name = cfg.TeamID
+	}
+	msg := fmt.Sprintf("Team '%s' not found for the API key of %s; the key may belong to a different workspace", name, info)
+	if err != nil {
+		msg += fmt.Sprintf(": %v", err)
+	}
+	return errors.New(msg)
+}
+
+// errWrongOrganization is returned when the API key belongs to a different
+// workspace than expected_organization.
+var errWrongOrganization = errors.New("API key belongs to an unexpected Linear organization")
+
+// checkOrganization verifies that the viewer's organization matches the
+// expected one by URL key, name or ID.
+func checkOrganization(viewer *Viewer, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if org := viewer.Organization; org != nil {
+		for _, v := range []string{org.URLKey, org.Name, org.ID} {
+			if v != "" && strings.EqualFold(v, expected) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: expected '%s', authenticated as %s", errWrongOrganization, expected, newTokenInfo(viewer))
+}
+
+// verifyOrganization looks up the authenticated organization and checks it
+// against expected_organization before any mutation is made.
+func verifyOrganization(ctx context.Context, client *ReadOnlyLinearClient, cfg *Config) error {
+	if cfg.ExpectedOrganization == "" {
+		return nil
+	}
+	viewer, err := client.GetViewer(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up the authenticated organization: %w", err)
+	}
+	return checkOrganization(viewer, cfg.ExpectedOrganization)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestTokenInfo(t *testing.T) {
+	info := newTokenInfo(&Viewer{
+		Name:         "Jane Doe",
+		Email:        "jane@acme.com",
+		Organization: &Organization{Name: "Acme", URLKey: "acme"},
+	})
+	if got := info.String(); got != "Jane Doe <jane@acme.com> in Acme (acme)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCheckTeamAccess(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetTeams": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []map[string]any{{"id": "team-1", "key": "OPS"}}}}
+		},
+	})
+	info := tokenInfo{Actor: "Jane Doe", Organization: "Other Corp"}
+	p := &LinearPlugin{}
+
+	if err := checkTeamAccess(context.Background(), fake.client().ReadOnly(), p.parseConfig(map[string]any{"team_key": "OPS"}), info); err != nil {
+		t.Errorf("expected team in organization, got %v", err)
+	}
+
+	err := checkTeamAccess(context.Background(), fake.client().ReadOnly(), p.parseConfig(map[string]any{"team_key": "ENG"}), info)
+	if err == nil {
+		t.Fatal("expected an error for a team outside the organization")
+	}
+	if !strings.Contains(err.Error(), "Other Corp") || !strings.Contains(err.Error(), "'ENG'") {
+		t.Errorf("expected the error to name the team and organization, got %q", err)
+	}
+}
+
+func TestCheckOrganization(t *testing.T) {
+	viewer := &Viewer{Name: "Jane Doe", Organization: &Organization{ID: "org-1", Name: "Acme", URLKey: "acme"}}
+
+	for _, expected := range []string{"", "acme", "Acme", "org-1"} {
+		if err := checkOrganization(viewer, expected); err != nil {
+			t.Errorf("checkOrganization(%q) error = %v", expected, err)
+		}
+	}
+	if err := checkOrganization(viewer, "globex"); !errors.Is(err, errWrongOrganization) {
+		t.Errorf("expected errWrongOrganization, got %v", err)
+	}
+}
+
+func TestPostPublishStopsInUnexpectedOrganization(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{
+				"id": "user-1", "name": "Jane Doe",
+				"organization": map[string]any{"id": "org-2", "name": "Globex", "urlKey": "globex"},
+			}}
+		},
+	})
+	fake.register(t, "lin_api_org_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"api_key":               "lin_api_org_test",
+			"team_key":              "ENG",
+			"expected_organization": "acme",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected the hook to fail in an unexpected organization")
+	}
+	if fake.callCount("GetTeams") != 0 {
+		t.Error("expected no further requests after the organization check failed")
+	}
+}
+
+func TestValidateReportsTokenInfo(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetViewer": func(map[string]any) any {
+			return map[string]any{"viewer": map[string]any{
+				"id": "user-1", "name": "Jane Doe",
+				"organization": map[string]any{"id": "org-1", "name": "Acme", "urlKey": "acme"},
+			}}
+		},
+		"GetTeams": func(map[string]any) any {
+			return map[string]any{"teams": map[string]any{"nodes": []map[string]any{{"id": "team-1", "key": "ENG"}}}}
+		},
+	})
+	fake.register(t, "lin_api_token_info_test")
+
+	p := &LinearPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{"api_key": "lin_api_token_info_test", "team_key": "ENG"})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !resp.Valid {
+		t.Errorf("expected the token note to leave the config valid, got %v", resp.Errors)
+	}
+	for _, e := range resp.Errors {
+		if e.Code == validationCodeTokenInfo && strings.Contains(e.Message, "Jane Doe in Acme (acme)") {
+			return
+		}
+	}
+	t.Errorf("expected the token metadata in the validation output, got %v", resp.Errors)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// TracingConfig enables per-request tracing of Linear API calls.
+type TracingConfig struct {
+	Enabled bool `json:"enabled"`
+	// File receives one JSON event per call; stderr when empty.
+	File string `json:"file,omitempty"`
+	// SlowThreshold marks calls reported in the slow_calls output.
+	SlowThreshold time.Duration `json:"slow_threshold"`
+}
+
+// parseTracingConfig parses the tracing block.
+func parseTracingConfig(raw map[string]any) TracingConfig {
+	parser := helpers.NewConfigParser(raw)
+	cfg := TracingConfig{
+		Enabled:       parser.GetBool("enabled", false),
+		File:          parser.GetString("file", "", ""),
+		SlowThreshold: 2 * time.Second,
+	}
+	if d, err := time.ParseDuration(parser.GetString("slow_threshold", "", "")); err == nil {
+		cfg.SlowThreshold = d
+	}
+	return cfg
+}
+
+// callTrace describes one Linear API call. Attribute names follow the
+// OpenTelemetry GraphQL semantic conventions where they exist.
+type callTrace struct {
+	Operation string        `json:"graphql.operation.name"`
+	Type      string        `json:"graphql.operation.type"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration_ns"`
+	// Throttled is how long the call waited for the rate limit budget.
+	Throttled time.Duration `json:"linear.throttled_ns,omitempty"`
+	// Complexity is the query complexity Linear reports, 0 if unknown.
+	Complexity int    `json:"linear.complexity,omitempty"`
+	Attempts   int    `json:"linear.attempts"`
+	Error      string `json:"error,omitempty"`
+}
+
+// CallTracer receives a trace for every Linear API call. It can be backed
+// by structured logs or adapted to an OpenTelemetry tracer by starting and
+// ending a span per call.
+type CallTracer interface {
+	TraceCall(ctx context.Context, trace callTrace)
+}
+
+// slogTracer emits each call as a structured log event.
+type slogTracer struct {
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	threshold time.Duration
+	slow      []callTrace
+}
+
+// newSlogTracer creates a tracer logging JSON events to w.
+func newSlogTracer(w io.Writer, threshold time.Duration) *slogTracer {
+	return &slogTracer{
+		logger:    slog.New(slog.NewJSONHandler(w, nil)),
+		threshold: threshold,
+	}
+}
+
+// TraceCall implements CallTracer.
+func (t *slogTracer) TraceCall(ctx context.Context, trace callTrace) {
+	level := slog.LevelInfo
+	if trace.Error != "" {
+		level = slog.LevelWarn
+	}
+	t.logger.LogAttrs(ctx, level, "linear.call",
+		slog.String("graphql.operation.name", trace.Operation),
+		slog.String("graphql.operation.type", trace.Type),
+		slog.Time("start", trace.Start),
+		slog.Float64("duration_ms", float64(trace.Duration)/float64(time.Millisecond)),
+		slog.Int("linear.complexity", trace.Complexity),
+		slog.Int("linear.attempts", trace.Attempts),
+		slog.Float64("linear.throttled_ms", float64(trace.Throttled)/float64(time.Millisecond)),
+		slog.String("error", trace.Error),
+	)
+
+	if t.threshold > 0 && trace.Duration >= t.threshold {
+		t.mu.Lock()
+		t.slow = append(t.slow, trace)
+		t.mu.Unlock()
+	}
+}
+
+// Slow returns the calls that took at least the slow threshold, in the
+// order they started.
+func (t *slogTracer) Slow() []callTrace {
+	t.mu.Lock()
+	slow := append([]callTrace(nil), t.slow...)
+	t.mu.Unlock()
+	slices.SortStableFunc(slow, func(a, b callTrace) int {
+		return a.Start.Compare(b.Start)
+	})
+	return slow
+}
+
+// openTracer creates the tracer for a run and a function closing its
+// output file.
+func openTracer(cfg TracingConfig) (*slogTracer, func() error, error) {
+	if cfg.File == "" {
+		return newSlogTracer(os.Stderr, cfg.SlowThreshold), func() error { return nil }, nil
+	}
+	f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newSlogTracer(f, cfg.SlowThreshold), f.Close, nil
+}
+
+type tracerKey struct{}
+
+// withTracer returns a context whose Linear calls are traced.
+func withTracer(ctx context.Context, tracer CallTracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// traceCall hands a call trace to the tracer attached to ctx, if any.
+func traceCall(ctx context.Context, trace callTrace) {
+	if tracer, _ := ctx.Value(tracerKey{}).(CallTracer); tracer != nil {
+		tracer.TraceCall(ctx, trace)
+	}
+}
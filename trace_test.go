@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// recordingTracer keeps every call trace.
+type recordingTracer struct {
+	traces []callTrace
+}
+
+func (r *recordingTracer) TraceCall(_ context.Context, trace callTrace) {
+	r.traces = append(r.traces, trace)
+}
+
+func TestExecuteTracesCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Complexity", "42")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{endpoint: server.URL, apiKey: "lin_api_test", httpClient: http.DefaultClient}
+	tracer := &recordingTracer{}
+
+	if _, err := client.GetViewer(withTracer(context.Background(), tracer)); err != nil {
+		t.Fatalf("GetViewer() error = %v", err)
+	}
+
+	if len(tracer.traces) != 1 {
+		t.Fatalf("expected one trace, got %d", len(tracer.traces))
+	}
+	got := tracer.traces[0]
+	if got.Operation != "GetViewer" || got.Type != "query" || got.Complexity != 42 || got.Attempts != 1 || got.Error != "" {
+		t.Errorf("unexpected trace %+v", got)
+	}
+}
+
+func TestSlogTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := newSlogTracer(&buf, time.Second)
+
+	tracer.TraceCall(context.Background(), callTrace{Operation: "GetTeams", Type: "query", Duration: 10 * time.Millisecond, Attempts: 1})
+	tracer.TraceCall(context.Background(), callTrace{Operation: "AddComment", Type: "mutation", Duration: 3 * time.Second, Attempts: 2})
+
+	var event map[string]any
+	if err := json.Unmarshal(bytes.SplitN(buf.Bytes(), []byte("\n"), 2)[0], &event); err != nil {
+		t.Fatalf("expected JSON events, got %q", buf.String())
+	}
+	if event["msg"] != "linear.call" || event["graphql.operation.name"] != "GetTeams" || event["duration_ms"] != 10.0 {
+		t.Errorf("unexpected event %v", event)
+	}
+
+	slow := tracer.Slow()
+	if len(slow) != 1 || slow[0].Operation != "AddComment" {
+		t.Errorf("Slow() = %+v", slow)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// Outcomes of a state transition.
+const (
+	transitionUpdated    = "updated"
+	transitionDiverted   = "diverted"
+	transitionFailed     = "failed"
+	transitionUnverified = "unverified"
+	transitionBounced    = "bounced"
+)
+
+// TransitionVerification re-checks updated issues after a delay, catching
+// workflow automations that move them out of the released state later.
+type TransitionVerification struct {
+	Enabled bool          `json:"enabled"`
+	Delay   time.Duration `json:"delay"`
+}
+
+// parseTransitionVerification parses the verify_transitions block.
+func parseTransitionVerification(raw map[string]any) TransitionVerification {
+	parser := helpers.NewConfigParser(raw)
+	v := TransitionVerification{
+		Enabled: parser.GetBool("enabled", false),
+		Delay:   10 * time.Second,
+	}
+	if d, err := time.ParseDuration(parser.GetString("delay", "", "")); err == nil {
+		v.Delay = d
+	}
+	return v
+}
+
+// stateTransition records an issue's state before and after the release
+// update, as reported by Linear rather than assumed from the mutation. The
+// before state is captured ahead of the update, so failed updates are
+// recorded too.
+type stateTransition struct {
+	Issue     string `json:"issue"`
+	From      string `json:"from"`
+	FromID    string `json:"from_id"`
+	Requested string `json:"requested"`
+	To        string `json:"to"`
+	ToID      string `json:"to_id"`
+	// Outcome is "updated", "diverted" when the issue did not end up in the
+	// requested state (e.g. workflow automation moved it on immediately),
+	// "failed" when the update was rejected, or "unverified" when the
+	// resulting state could not be read back. With verify_transitions,
+	// "bounced" marks issues that left the released state during the delay.
+	Outcome string `json:"outcome"`
+}
+
+// newTransition captures the issue's state before it is updated.
+func newTransition(before *Issue, requested string) stateTransition {
+	return stateTransition{
+		Issue:     before.Identifier,
+		From:      before.State.Name,
+		FromID:    before.State.ID,
+		Requested: requested,
+		To:        before.State.Name,
+		ToID:      before.State.ID,
+		Outcome:   transitionFailed,
+	}
+}
+
+// verify re-reads the issue after a state update and records the state it
+// actually ended up in.
+func (t *stateTransition) verify(ctx context.Context, client *LinearClient, stateID string) error {
+	after, err := client.GetIssueByIdentifier(ctx, t.Issue)
+	if err != nil {
+		t.To, t.ToID, t.Outcome = "", "", transitionUnverified
+		return err
+	}
+
+	t.observe(after, stateID)
+	return nil
+}
+
+// observe records the state an issue ended up in after being moved to the
+// state with ID stateID.
+func (t *stateTransition) observe(after *Issue, stateID string) {
+	t.To, t.ToID = after.State.Name, after.State.ID
+	t.Outcome = transitionUpdated
+	if after.State.ID != stateID {
+		t.Outcome = transitionDiverted
+	}
+}
+
+// String describes a diverted transition for warnings.
+func (t stateTransition) String() string {
+	return fmt.Sprintf("%s moved to '%s' instead of '%s' after the update", t.Issue, t.To, t.Requested)
+}
+
+// recheckTransitions waits for the configured delay and re-reads every
+// updated issue, marking those no longer in the state they were moved to as
+// bounced. Issues that cannot be read are left as they were and reported as
+// errors.
+func recheckTransitions(ctx context.Context, client *LinearClient, delay time.Duration, transitions []stateTransition) []error {
+	select {
+	case <-ctx.Done():
+		return []error{fmt.Errorf("transition verification skipped: %w", ctx.Err())}
+	case <-time.After(delay):
+	}
+
+	var errs []error
+	for i := range transitions {
+		t := &transitions[i]
+		if t.Outcome != transitionUpdated {
+			continue
+		}
+		after, err := client.GetIssueByIdentifier(ctx, t.Issue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not re-check the state of %s: %w", t.Issue, err))
+			continue
+		}
+		if after.State.ID != t.ToID {
+			t.To, t.ToID, t.Outcome = after.State.Name, after.State.ID, transitionBounced
+		}
+	}
+	return errs
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestProcessLinkedIssuesRecordsTransitions(t *testing.T) {
+	var mu sync.Mutex
+	states := map[string]map[string]any{
+		"ENG-1": {"id": "state-review", "name": "In Review"},
+		"ENG-2": {"id": "state-review", "name": "In Review"},
+	}
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			id := vars["id"].(string)
+			return map[string]any{"issue": map[string]any{"id": id, "identifier": id, "state": states[id]}}
+		},
+		"UpdateIssueState": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			// Workflow automation immediately moves ENG-2 on to "Closed"
+			if vars["id"] == "ENG-2" {
+				states["ENG-2"] = map[string]any{"id": "state-closed", "name": "Closed"}
+			} else {
+				states[vars["id"].(string)] = map[string]any{"id": "state-done", "name": "Done"}
+			}
+			return map[string]any{"issueUpdate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1", "ENG-2"})
+
+	if len(res.Transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %v", res.Transitions)
+	}
+	want := stateTransition{
+		Issue: "ENG-1", From: "In Review", FromID: "state-review",
+		Requested: "Done", To: "Done", ToID: "state-done", Outcome: transitionUpdated,
+	}
+	if res.Transitions[0] != want {
+		t.Errorf("Transitions[0] = %+v, want %+v", res.Transitions[0], want)
+	}
+	if res.Transitions[1].Outcome != transitionDiverted || res.Transitions[1].To != "Closed" {
+		t.Errorf("expected ENG-2 to be reported as diverted to Closed, got %+v", res.Transitions[1])
+	}
+	if res.Updated != 1 {
+		t.Errorf("expected only ENG-1 counted as updated, got %d", res.Updated)
+	}
+	if len(res.Errors) != 1 {
+		t.Errorf("expected a warning for the diverted issue, got %v", res.Errors)
+	}
+}
+
+func TestProcessLinkedIssuesRecordsFailedTransitions(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"ENG-1": {"id": "uuid-1", "identifier": "ENG-1", "state": map[string]any{"id": "state-review", "name": "In Review"}},
+		}),
+		"UpdateIssueState": func(map[string]any) any {
+			return fakeErrors{{"message": "Issue is locked"}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"add_release_comment": false})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1"})
+
+	if len(res.Transitions) != 1 {
+		t.Fatalf("expected the failed transition to be recorded, got %v", res.Transitions)
+	}
+	got := res.Transitions[0]
+	if got.Outcome != transitionFailed || got.From != "In Review" || got.To != "In Review" {
+		t.Errorf("unexpected transition %+v", got)
+	}
+
+	rc := newResultCollector()
+	res.report(rc, cfg)
+	if len(rc.auditLog) != 1 || rc.auditLog[0].Before != "In Review" || rc.auditLog[0].Outcome != transitionFailed {
+		t.Errorf("expected an audit entry for the transition, got %+v", rc.auditLog)
+	}
+}
+
+func TestRecheckTransitionsReportsBouncedIssues(t *testing.T) {
+	var mu sync.Mutex
+	states := map[string]map[string]any{
+		"ENG-1": {"id": "state-review", "name": "In Review"},
+		"ENG-2": {"id": "state-review", "name": "In Review"},
+	}
+	readsAfterUpdate := 0
+
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			id := vars["id"].(string)
+			// Automation moves ENG-2 back to "Reopened" once the update was verified
+			if id == "ENG-2" && states[id]["id"] == "state-done" {
+				readsAfterUpdate++
+				if readsAfterUpdate > 1 {
+					states[id] = map[string]any{"id": "state-reopened", "name": "Reopened"}
+				}
+			}
+			return map[string]any{"issue": map[string]any{"id": id, "identifier": id, "state": states[id]}}
+		},
+		"UpdateIssueState": func(vars map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			states[vars["id"].(string)] = map[string]any{"id": "state-done", "name": "Done"}
+			return map[string]any{"issueUpdate": map[string]any{"success": true}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"add_release_comment": false,
+		"verify_transitions":  map[string]any{"enabled": true, "delay": "1ms"},
+	})
+	team := &Team{States: []State{{ID: "state-done", Name: "Done"}}}
+
+	res := p.processLinkedIssues(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "1.0.0"}, team), []string{"ENG-1", "ENG-2"})
+
+	if res.Transitions[0].Outcome != transitionUpdated {
+		t.Errorf("expected ENG-1 to stay updated, got %+v", res.Transitions[0])
+	}
+	if got := res.Transitions[1]; got.Outcome != transitionBounced || got.To != "Reopened" {
+		t.Errorf("expected ENG-2 to be reported as bounced to Reopened, got %+v", got)
+	}
+
+	_, rc := withResultCollector(context.Background())
+	res.report(rc, cfg)
+	resp := rc.response()
+	bounced, ok := resp.Outputs["bounced_issues"].(map[string]string)
+	if !ok || bounced["ENG-2"] != "Reopened" || len(bounced) != 1 {
+		t.Errorf("bounced_issues = %v", resp.Outputs["bounced_issues"])
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// upstreamEnvVar lists the upstream dependency releases that triggered or
+// are part of this release, as "owner/repo@version" pairs separated by
+// commas. It is provided by the host in the release environment.
+const upstreamEnvVar = "LINEAR_UPSTREAM_RELEASES"
+
+// upstreamRelease is a release of a dependency in another repository.
+type upstreamRelease struct {
+	Repo    string
+	Version string
+}
+
+// String formats the release as "owner/repo@version".
+func (u upstreamRelease) String() string {
+	return u.Repo + "@" + u.Version
+}
+
+// upstreamReleases parses the upstream releases from the release environment.
+func upstreamReleases(releaseCtx plugin.ReleaseContext) []upstreamRelease {
+	var out []upstreamRelease
+	for _, part := range strings.Split(releaseCtx.Environment[upstreamEnvVar], ",") {
+		repo, version, ok := strings.Cut(strings.TrimSpace(part), "@")
+		if !ok || repo == "" || version == "" {
+			continue
+		}
+		out = append(out, upstreamRelease{Repo: repo, Version: strings.TrimPrefix(version, "v")})
+	}
+	return out
+}
+
+// findUpstreamReleaseIssue locates the release issue of an upstream release
+// by its release marker, narrowed to issues whose title mentions the
+// repository or that carry a label named after it. The marker is matched
+// without its kind's naming prefix, which the upstream may set differently.
+func findUpstreamReleaseIssue(ctx context.Context, client *LinearClient, upstream upstreamRelease) (*Issue, error) {
+	candidates, err := client.SearchIssuesByDescription(ctx, markerRelease+"="+upstream.Version+"`")
+	if err != nil {
+		return nil, err
+	}
+
+	name := path.Base(upstream.Repo)
+	for i := range candidates {
+		issue := &candidates[i]
+		if strings.Contains(strings.ToLower(issue.Title), strings.ToLower(name)) ||
+			issue.hasLabel(name) || issue.hasLabel(upstream.Repo) {
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("no release issue found for %s", upstream)
+}
+
+// linkUpstreamReleases relates the release issue to the release issues of
+// its upstream dependencies, so upstream releases block downstream ones. It
+// returns the linked upstream issues keyed by upstream release, and warnings.
+func linkUpstreamReleases(ctx context.Context, client *LinearClient, releaseIssue *Issue, upstreams []upstreamRelease) (map[string]string, []string) {
+	linked := make(map[string]string)
+	var warnings []string
+	for _, upstream := range upstreams {
+		issue, err := findUpstreamReleaseIssue(ctx, client, upstream)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not link upstream release %s: %v", upstream, err))
+			continue
+		}
+		if err := client.CreateIssueRelation(ctx, issue.ID, releaseIssue.ID, "blocks"); err != nil {
+			if !errors.Is(err, errMutationSkipped) {
+				warnings = append(warnings, fmt.Sprintf("Failed to link upstream release %s (%s): %v", upstream, issue.Identifier, err))
+			}
+			continue
+		}
+		linked[upstream.String()] = issue.Identifier
+	}
+	return linked, warnings
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestUpstreamReleases(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{Environment: map[string]string{
+		upstreamEnvVar: "acme/core@v2.3.0, acme/ui@1.0.0-rc.1,invalid",
+	}}
+
+	got := upstreamReleases(releaseCtx)
+	want := []upstreamRelease{{"acme/core", "2.3.0"}, {"acme/ui", "1.0.0-rc.1"}}
+	if len(got) != len(want) {
+		t.Fatalf("upstreamReleases() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("upstreamReleases()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := upstreamReleases(plugin.ReleaseContext{}); len(got) != 0 {
+		t.Errorf("expected no upstream releases, got %v", got)
+	}
+}
+
+func TestLinkUpstreamReleases(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"SearchIssuesByDescription": func(vars map[string]any) any {
+			var nodes []map[string]any
+			if vars["text"] == "release=2.3.0`" {
+				nodes = []map[string]any{
+					{"id": "uuid-other", "identifier": "WEB-4", "title": "Release 2.3.0 (web)"},
+					{"id": "uuid-core", "identifier": "CORE-7", "title": "Release 2.3.0",
+						"labels": map[string]any{"nodes": []map[string]any{{"id": "l1", "name": "core"}}}},
+				}
+			}
+			return map[string]any{"issues": map[string]any{"nodes": nodes}}
+		},
+		"CreateIssueRelation": successHandler("issueRelationCreate"),
+	})
+
+	upstreams := []upstreamRelease{{"acme/core", "2.3.0"}, {"acme/ui", "1.0.0"}}
+	linked, warnings := linkUpstreamReleases(context.Background(), fake.client(), &Issue{ID: "uuid-app"}, upstreams)
+
+	if linked["acme/core@2.3.0"] != "CORE-7" {
+		t.Errorf("expected acme/core linked to CORE-7, got %v", linked)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected a warning for the unresolved upstream, got %v", warnings)
+	}
+
+	calls := fake.calls["CreateIssueRelation"]
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 relation, got %d", len(calls))
+	}
+	input := calls[0]["input"].(map[string]any)
+	if input["issueId"] != "uuid-core" || input["relatedIssueId"] != "uuid-app" || input["type"] != "blocks" {
+		t.Errorf("unexpected relation input %v", input)
+	}
+}
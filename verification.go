@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// markerVerification tags verification issues with the release version, so
+// a re-publish does not create a second one.
+const markerVerification = "verification"
+
+// defaultVerificationChecklist is checked off after every release unless
+// a checklist is configured.
+var defaultVerificationChecklist = []string{
+	"Run the smoke tests against {{.Version}}",
+	"Check dashboards and error rates",
+	"Announce the release",
+}
+
+// VerificationConfig controls the post-release verification issue, a
+// checklist for QA kept apart from the release issue.
+type VerificationConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Title     string   `json:"title"`
+	Checklist []string `json:"checklist"`
+	Assignee  string   `json:"assignee,omitempty"`
+}
+
+// parseVerificationConfig parses the verification block. The assignee
+// defaults to LINEAR_QA_ASSIGNEE.
+func parseVerificationConfig(raw map[string]any) VerificationConfig {
+	parser := helpers.NewConfigParser(raw)
+	return VerificationConfig{
+		Enabled:   parser.GetBool("enabled", false),
+		Title:     parser.GetString("title", "", "Verify release {{.Version}}"),
+		Checklist: parser.GetStringSlice("checklist", defaultVerificationChecklist),
+		Assignee:  parser.GetString("assignee", "LINEAR_QA_ASSIGNEE", ""),
+	}
+}
+
+// ensureVerificationIssue creates the verification issue of the release,
+// assigned to QA and related to the release issue, if any. It returns an
+// existing verification issue for the version instead of creating another,
+// and reports whether it created one.
+func ensureVerificationIssue(ctx context.Context, run *releaseRun, releaseIssue *Issue) (*Issue, bool, error) {
+	cfg, client := run.cfg, run.client
+	marker := formatMarker(cfg.Naming.marker(markerVerification), run.release.Version)
+	existing, err := client.FindIssuesByDescription(ctx, run.team.ID, marker)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up existing verification issue: %w", err)
+	}
+	if len(existing) > 0 {
+		return &existing[0], false, nil
+	}
+
+	title, err := run.render(ctx, cfg.Verification.Title)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to render verification title: %w", err)
+	}
+	checklist, err := verificationChecklist(cfg, run)
+	if err != nil {
+		return nil, false, err
+	}
+
+	input := CreateIssueInput{
+		TeamID:      run.team.ID,
+		Title:       cfg.Naming.title(strings.TrimSpace(title)),
+		Description: appendMarker(verificationDescription(run.release.Version, releaseIssue, checklist), cfg.Naming.marker(markerVerification), run.release.Version),
+		ProjectID:   cfg.ProjectID,
+	}
+	if cfg.Verification.Assignee != "" {
+		input.AssigneeID, err = resolveUser(ctx, client, "QA assignee", cfg.Verification.Assignee)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	issue, err := client.CreateIssue(ctx, input)
+	if err != nil {
+		return nil, false, err
+	}
+	if releaseIssue != nil {
+		if err := client.CreateIssueRelation(ctx, issue.ID, releaseIssue.ID, "related"); err != nil && !errors.Is(err, errMutationSkipped) {
+			return issue, true, fmt.Errorf("failed to relate %s to release issue %s: %w", issue.Identifier, releaseIssue.Identifier, err)
+		}
+	}
+	return issue, true, nil
+}
+
+// verificationChecklist renders the checklist items, which may use the
+// release context like any other template.
+func verificationChecklist(cfg *Config, run *releaseRun) ([]string, error) {
+	items := make([]string, 0, len(cfg.Verification.Checklist))
+	for _, item := range cfg.Verification.Checklist {
+		rendered, err := renderTemplate(item, run.release, cfg.TemplatePartials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render verification checklist item %q: %w", item, err)
+		}
+		if rendered = strings.TrimSpace(rendered); rendered != "" {
+			items = append(items, rendered)
+		}
+	}
+	return items, nil
+}
+
+// verificationDescription lists the checklist as markdown tasks, pointing
+// at the release issue when there is one.
+func verificationDescription(version string, releaseIssue *Issue, checklist []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Verify release %s after it is published.", version)
+	if releaseIssue != nil {
+		fmt.Fprintf(&b, " Release record: %s.", releaseIssue.Identifier)
+	}
+	b.WriteString("\n\n")
+	for _, item := range checklist {
+		fmt.Fprintf(&b, "- [ ] %s\n", item)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestEnsureVerificationIssue(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []any{}}}
+		},
+		"FindUserByEmail": func(vars map[string]any) any {
+			return map[string]any{"users": map[string]any{"nodes": []any{
+				map[string]any{"id": "user-qa", "name": "Quinn", "email": vars["email"]},
+			}}}
+		},
+		"CreateIssue": func(map[string]any) any {
+			return map[string]any{"issueCreate": map[string]any{
+				"success": true,
+				"issue":   map[string]any{"id": "uuid-v", "identifier": "ENG-20"},
+			}}
+		},
+		"CreateIssueRelation": successHandler("issueRelationCreate"),
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"verification": map[string]any{
+			"enabled":   true,
+			"assignee":  "qa@example.com",
+			"checklist": []any{"Smoke test {{.Version}}", "Check the dashboards"},
+		},
+	})
+	rel := plugin.ReleaseContext{Version: "2.0.0"}
+	releaseIssue := &Issue{ID: "uuid-r", Identifier: "ENG-19"}
+
+	issue, created, err := ensureVerificationIssue(context.Background(), fake.run(cfg, rel, &Team{ID: "team-1"}), releaseIssue)
+	if err != nil || !created || issue.Identifier != "ENG-20" {
+		t.Fatalf("ensureVerificationIssue() = %v, %v, %v", issue, created, err)
+	}
+
+	input := fake.calls["CreateIssue"][0]["input"].(map[string]any)
+	if input["title"] != "Verify release 2.0.0" || input["assigneeId"] != "user-qa" {
+		t.Errorf("unexpected input %v", input)
+	}
+	desc, _ := input["description"].(string)
+	for _, want := range []string{"- [ ] Smoke test 2.0.0\n- [ ] Check the dashboards", "ENG-19", formatMarker(markerVerification, "2.0.0")} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("description %q is missing %q", desc, want)
+		}
+	}
+
+	relation := fake.calls["CreateIssueRelation"][0]["input"].(map[string]any)
+	if relation["issueId"] != "uuid-v" || relation["relatedIssueId"] != "uuid-r" || relation["type"] != "related" {
+		t.Errorf("unexpected relation %v", relation)
+	}
+}
+
+func TestEnsureVerificationIssueExisting(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"FindIssuesByDescription": func(map[string]any) any {
+			return map[string]any{"issues": map[string]any{"nodes": []any{
+				map[string]any{"id": "uuid-v", "identifier": "ENG-20", "description": "`relicta:verification=2.0.0`"},
+			}}}
+		},
+	})
+
+	p := &LinearPlugin{}
+	cfg := p.parseConfig(map[string]any{"verification": map[string]any{"enabled": true}})
+
+	issue, created, err := ensureVerificationIssue(context.Background(), fake.run(cfg, plugin.ReleaseContext{Version: "2.0.0"}, &Team{ID: "team-1"}), nil)
+	if err != nil || created || issue.Identifier != "ENG-20" {
+		t.Fatalf("ensureVerificationIssue() = %v, %v, %v", issue, created, err)
+	}
+	if fake.callCount("CreateIssue") != 0 {
+		t.Error("expected no second verification issue")
+	}
+}
+
+func TestVerificationDefaults(t *testing.T) {
+	cfg := parseVerificationConfig(nil)
+	if cfg.Enabled || cfg.Title != "Verify release {{.Version}}" || len(cfg.Checklist) != len(defaultVerificationChecklist) {
+		t.Errorf("unexpected defaults %+v", cfg)
+	}
+
+	desc := verificationDescription("1.0.0", nil, []string{"Announce"})
+	if desc != "Verify release 1.0.0 after it is published.\n\n- [ ] Announce" {
+		t.Errorf("verificationDescription() = %q", desc)
+	}
+}
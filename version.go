@@ -0,0 +1,166 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two semantic versions and returns -1, 0 or 1.
+// A leading "v" is ignored and build metadata does not affect ordering.
+// Prerelease versions sort before the corresponding release.
+func compareVersions(a, b string) int {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+
+	for i := 0; i < len(aCore) || i < len(bCore); i++ {
+		var x, y int
+		if i < len(aCore) {
+			x = aCore[i]
+		}
+		if i < len(bCore) {
+			y = bCore[i]
+		}
+		if x != y {
+			return sign(x - y)
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return comparePrerelease(aPre, bPre)
+}
+
+// isPrerelease reports whether the version carries a prerelease tag.
+func isPrerelease(version string) bool {
+	_, pre := splitVersion(version)
+	return pre != ""
+}
+
+// splitVersion returns the numeric core and prerelease tag of a version.
+func splitVersion(version string) ([]int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+
+	var pre string
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		version, pre = version[:i], version[i+1:]
+	}
+
+	var core []int
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		core = append(core, n)
+	}
+	return core, pre
+}
+
+// comparePrerelease orders prerelease tags per the semver precedence rules.
+func comparePrerelease(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		x, xErr := strconv.Atoi(aParts[i])
+		y, yErr := strconv.Atoi(bParts[i])
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return sign(x - y)
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(aParts) - len(bParts))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// joinCore formats a numeric version core as "1.2.3".
+func joinCore(core []int) string {
+	parts := make([]string, len(core))
+	for i, n := range core {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// matchVersionPattern reports whether version matches a glob-style pattern
+// such as "*.*.0" or "2.x". Core segments match exactly or via "*" / "x";
+// a pattern without a prerelease part matches only final releases, while
+// "*-*" or "*.*.*-rc.*" match prereleases.
+func matchVersionPattern(pattern, version string) bool {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "v")
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+
+	patCore, patPre, patHasPre := strings.Cut(pattern, "-")
+	verCore, verPre, verHasPre := strings.Cut(version, "-")
+	if patHasPre != verHasPre {
+		return false
+	}
+	if patHasPre {
+		if ok, err := path.Match(patPre, verPre); err != nil || !ok {
+			return false
+		}
+	}
+
+	patParts := strings.Split(patCore, ".")
+	verParts := strings.Split(verCore, ".")
+	for i, p := range patParts {
+		if i >= len(verParts) {
+			return false
+		}
+		if p != "*" && p != "x" && p != verParts[i] {
+			return false
+		}
+	}
+	// A trailing wildcard covers the remaining segments ("2.*" matches "2.1.0")
+	return len(patParts) == len(verParts) || patParts[len(patParts)-1] == "*" || patParts[len(patParts)-1] == "x"
+}
+
+// isValidVersionPattern reports whether pattern is usable with
+// matchVersionPattern.
+func isValidVersionPattern(pattern string) bool {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "v")
+	core, pre, hasPre := strings.Cut(pattern, "-")
+	if hasPre {
+		if _, err := path.Match(pre, ""); err != nil || pre == "" {
+			return false
+		}
+	}
+	for _, part := range strings.Split(core, ".") {
+		if part == "*" || part == "x" {
+			continue
+		}
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.4.0", "1.4.1", -1},
+		{"v1.4.1", "1.4.0", 1},
+		{"1.4.0", "1.4.0+build.5", 0},
+		{"1.4.0-rc.2", "1.4.0", -1},
+		{"1.4.0-rc.2", "1.4.0-rc.10", -1},
+		{"1.4.0-beta", "1.4.0-alpha", 1},
+		{"1.4.0-rc.1", "1.4.0-rc", 1},
+		{"2.0", "1.9.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindMarkers(t *testing.T) {
+	body := appendMarker("Released in 1.4.0", markerReleased, "1.4.0")
+	body = appendMarker(body, "other", "x")
+
+	got := findMarkers(body, markerReleased)
+	if len(got) != 1 || got[0] != "1.4.0" {
+		t.Errorf("findMarkers() = %v, want [1.4.0]", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "sort"
+
+// assigneeWorkload summarizes what one assignee shipped in a release.
+type assigneeWorkload struct {
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Email    string   `json:"email,omitempty"`
+	Issues   []string `json:"issues"`
+	Count    int      `json:"count"`
+	Estimate float64  `json:"estimate"`
+}
+
+// unassignedName labels shipped issues without an assignee.
+const unassignedName = "Unassigned"
+
+// workloadTracker aggregates shipped issues per assignee.
+type workloadTracker map[string]*assigneeWorkload
+
+// add records a shipped issue against its assignee.
+func (w workloadTracker) add(issue *Issue) {
+	key, entry := "", &assigneeWorkload{Name: unassignedName}
+	if issue.Assignee != nil {
+		key = issue.Assignee.ID
+		entry = &assigneeWorkload{ID: issue.Assignee.ID, Name: issue.Assignee.Name, Email: issue.Assignee.Email}
+	}
+
+	if existing, ok := w[key]; ok {
+		entry = existing
+	} else {
+		w[key] = entry
+	}
+
+	entry.Issues = append(entry.Issues, issue.Identifier)
+	entry.Count++
+	entry.Estimate += issue.Estimate
+}
+
+// summary returns the workloads ordered by issue count, then name.
+func (w workloadTracker) summary() []assigneeWorkload {
+	out := make([]assigneeWorkload, 0, len(w))
+	for _, entry := range w {
+		out = append(out, *entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
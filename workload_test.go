@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestWorkloadTracker(t *testing.T) {
+	alice := &User{ID: "u1", Name: "Alice"}
+	bob := &User{ID: "u2", Name: "Bob"}
+
+	w := make(workloadTracker)
+	w.add(&Issue{Identifier: "ENG-1", Estimate: 3, Assignee: alice})
+	w.add(&Issue{Identifier: "ENG-2", Estimate: 2, Assignee: bob})
+	w.add(&Issue{Identifier: "ENG-3", Estimate: 5, Assignee: alice})
+	w.add(&Issue{Identifier: "ENG-4"})
+
+	got := w.summary()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(got))
+	}
+
+	if got[0].Name != "Alice" || got[0].Count != 2 || got[0].Estimate != 8 {
+		t.Errorf("unexpected first entry: %+v", got[0])
+	}
+	if got[1].Name != "Bob" || got[2].Name != unassignedName {
+		t.Errorf("unexpected ordering: %s, %s", got[1].Name, got[2].Name)
+	}
+}
